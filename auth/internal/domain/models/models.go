@@ -5,4 +5,5 @@ type User struct{
 	Login string
 	TelegramLogin string
 	PassHash []byte
+	ChatID int64
 }
\ No newline at end of file
@@ -14,10 +14,11 @@ import (
 )
 
 type Auth interface {
-	Register(ctx context.Context, telegramLogin, login, password string) (string, error)
-	Login(ctx context.Context, telegramLogin, login, password string) (string, error)
+	Register(ctx context.Context, telegramLogin, login, password string, chatID int64) (string, error)
+	Login(ctx context.Context, telegramLogin, login, password string, chatID int64) (string, error)
 	IsLogged(ctx context.Context, telegramLogin string) (string, error)
 	Logout(ctx context.Context, telegramLogin string) error
+	ChatID(ctx context.Context, userID string) (int64, error)
 }
 
 type ServerAPI struct {
@@ -32,6 +33,7 @@ var (
 	ErrInvalidCredentials = "invalid credentials"
 	ErrTokenNotFound      = "token not found"
 	ErrTokenExists        = "token already exists"
+	ErrChatNotFound       = "chat not found"
 )
 
 func Register(grpc *grpc.Server, auth Auth) {
@@ -43,7 +45,7 @@ func (s *ServerAPI) Register(ctx context.Context, req *authpb.RegisterRequest) (
 		return nil, err
 	}
 
-	userID, err := s.auth.Register(ctx, req.GetTelegramLogin(), req.GetLogin(), req.GetPassword())
+	userID, err := s.auth.Register(ctx, req.GetTelegramLogin(), req.GetLogin(), req.GetPassword(), req.GetChatId())
 	if err != nil {
 		return nil, formatError(err)
 	}
@@ -58,7 +60,7 @@ func (s *ServerAPI) Login(ctx context.Context, req *authpb.LoginRequest) (*authp
 		return nil, err
 	}
 
-	token, err := s.auth.Login(ctx, req.GetTelegramLogin(), req.GetLogin(), req.GetPassword())
+	token, err := s.auth.Login(ctx, req.GetTelegramLogin(), req.GetLogin(), req.GetPassword(), req.GetChatId())
 	if err != nil {
 		return nil, formatError(err)
 	}
@@ -94,6 +96,21 @@ func (s *ServerAPI) Logout(ctx context.Context, req *authpb.LogoutRequest) (*emp
 	return &emptypb.Empty{}, nil
 }
 
+func (s *ServerAPI) ChatID(ctx context.Context, req *authpb.ChatIDRequest) (*authpb.ChatIDResponse, error) {
+	if err := validateChatID(req); err != nil {
+		return nil, err
+	}
+
+	chatID, err := s.auth.ChatID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, formatError(err)
+	}
+
+	return &authpb.ChatIDResponse{
+		ChatId: chatID,
+	}, nil
+}
+
 func validateRegister(req *authpb.RegisterRequest) error {
 	if req.GetLogin() == "" {
 		return status.Error(codes.InvalidArgument, "login is required")
@@ -136,6 +153,14 @@ func validateLogout(req *authpb.LogoutRequest) error {
 	return nil
 }
 
+func validateChatID(req *authpb.ChatIDRequest) error {
+	if req.GetUserId() == "" {
+		return status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	return nil
+}
+
 func formatError(err error) error {
 	if errors.Is(err, auth.ErrUserExists) {
 		return status.Error(codes.AlreadyExists, ErrUserExists)
@@ -147,6 +172,8 @@ func formatError(err error) error {
 		return status.Error(codes.NotFound, ErrTokenNotFound)
 	} else if errors.Is(err, auth.ErrTokenExists) {
 		return status.Error(codes.AlreadyExists, ErrTokenExists)
+	} else if errors.Is(err, auth.ErrChatNotFound) {
+		return status.Error(codes.NotFound, ErrChatNotFound)
 	}
 
 	return status.Error(codes.Internal, ErrInternal)
@@ -25,10 +25,12 @@ type Auth struct {
 
 type UserChanger interface {
 	SaveUser(ctx context.Context, user models.User) error
+	SaveChatID(ctx context.Context, userID string, chatID int64) error
 }
 
 type UserProvider interface {
 	UserLoginsByTelegram(ctx context.Context, telegramLogin string) ([]models.User, error)
+	ChatID(ctx context.Context, userID string) (int64, error)
 }
 
 type TokenChanger interface {
@@ -46,6 +48,7 @@ var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrTokenNotFound      = errors.New("token not found")
 	ErrTokenExists        = errors.New("token already exists")
+	ErrChatNotFound       = errors.New("chat not found")
 )
 
 func New(log *slog.Logger, userChanger UserChanger, userProvider UserProvider, tokenChanger TokenChanger,
@@ -60,7 +63,7 @@ func New(log *slog.Logger, userChanger UserChanger, userProvider UserProvider, t
 	}
 }
 
-func (a *Auth) Register(ctx context.Context, telegramLogin, login, password string) (string, error) {
+func (a *Auth) Register(ctx context.Context, telegramLogin, login, password string, chatID int64) (string, error) {
 	const op = "auth.Register"
 
 	log := a.log.With(
@@ -84,6 +87,7 @@ func (a *Auth) Register(ctx context.Context, telegramLogin, login, password stri
 		Login:         login,
 		TelegramLogin: telegramLogin,
 		PassHash:      passHash,
+		ChatID:        chatID,
 	}
 
 	if err := a.userChanger.SaveUser(ctx, user); err != nil {
@@ -102,7 +106,7 @@ func (a *Auth) Register(ctx context.Context, telegramLogin, login, password stri
 	return id, nil
 }
 
-func (a *Auth) Login(ctx context.Context, telegramLogin, login, password string) (string, error) {
+func (a *Auth) Login(ctx context.Context, telegramLogin, login, password string, chatID int64) (string, error) {
 	const op = "auth.Login"
 
 	log := a.log.With(
@@ -167,6 +171,12 @@ func (a *Auth) Login(ctx context.Context, telegramLogin, login, password string)
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
+	if chatID != 0 && chatID != requiredUser.ChatID {
+		if err := a.userChanger.SaveChatID(ctx, requiredUser.ID, chatID); err != nil {
+			log.Error("failed to save chat id", slog.String("error", err.Error()))
+		}
+	}
+
 	log.Info("successfully logged the user in")
 
 	return token, nil
@@ -220,3 +230,32 @@ func (a *Auth) Logout(ctx context.Context, telegramLogin string) error {
 
 	return nil
 }
+
+// ChatID returns the Telegram chat the user last logged in from.
+func (a *Auth) ChatID(ctx context.Context, userID string) (int64, error) {
+	const op = "auth.ChatID"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("user id", userID),
+	)
+
+	chatID, err := a.userProvider.ChatID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Warn("user not found", slog.String("error", err.Error()))
+
+			return 0, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+		}
+		if errors.Is(err, storage.ErrChatNotFound) {
+			log.Warn("chat not found", slog.String("error", err.Error()))
+
+			return 0, fmt.Errorf("%s: %w", op, ErrChatNotFound)
+		}
+		log.Error("failed to get chat id", slog.String("error", err.Error()))
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return chatID, nil
+}
@@ -3,6 +3,7 @@ package psql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	_ "github.com/lib/pq"
@@ -61,8 +62,8 @@ func (a *AuthStorage) SaveUser(ctx context.Context, user models.User) error {
 	}
 
 	_, err := a.db.ExecContext(ctx,
-		"INSERT INTO auth.users (id, telegram_login, login, pass_hash) VALUES ($1, $2, $3, $4)",
-		user.ID, user.TelegramLogin, user.Login, user.PassHash)
+		"INSERT INTO auth.users (id, telegram_login, login, pass_hash, chat_id) VALUES ($1, $2, $3, $4, NULLIF($5, 0))",
+		user.ID, user.TelegramLogin, user.Login, user.PassHash, user.ChatID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -77,7 +78,7 @@ func (a *AuthStorage) UserLoginsByTelegram(ctx context.Context, telegramLogin st
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	query := `SELECT id, login, telegram_login, pass_hash FROM auth.users WHERE telegram_login = $1`
+	query := `SELECT id, login, telegram_login, pass_hash, COALESCE(chat_id, 0) FROM auth.users WHERE telegram_login = $1`
 
 	rows, err := a.db.QueryContext(ctx, query, telegramLogin)
 	if err != nil {
@@ -93,6 +94,7 @@ func (a *AuthStorage) UserLoginsByTelegram(ctx context.Context, telegramLogin st
 			&user.Login,
 			&user.TelegramLogin,
 			&user.PassHash,
+			&user.ChatID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
@@ -107,6 +109,35 @@ func (a *AuthStorage) UserLoginsByTelegram(ctx context.Context, telegramLogin st
 	return users, nil
 }
 
+func (a *AuthStorage) SaveChatID(ctx context.Context, userID string, chatID int64) error {
+	const op = "storage.psql.SaveChatID"
+
+	_, err := a.db.ExecContext(ctx, "UPDATE auth.users SET chat_id = $2 WHERE id = $1", userID, chatID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (a *AuthStorage) ChatID(ctx context.Context, userID string) (int64, error) {
+	const op = "storage.psql.ChatID"
+
+	var chatID sql.NullInt64
+	err := a.db.QueryRowContext(ctx, "SELECT chat_id FROM auth.users WHERE id = $1", userID).Scan(&chatID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("%s: %w", op, storage.ErrUserNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if !chatID.Valid {
+		return 0, fmt.Errorf("%s: %w", op, storage.ErrChatNotFound)
+	}
+
+	return chatID.Int64, nil
+}
+
 func (a *AuthStorage) findLogins(ctx context.Context, telegramLogin, login string) error {
 	var exists bool
 	err := a.db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM auth.users WHERE telegram_login = $1 AND login = $2)",
@@ -7,4 +7,5 @@ var (
 	ErrUserNotFound   = errors.New("user not found")
 	ErrTokenExists   = errors.New("token for that user already exists")
 	ErrTokenNotFound = errors.New("token for that user not found")
+	ErrChatNotFound  = errors.New("chat for that user not found")
 )
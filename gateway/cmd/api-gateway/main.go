@@ -12,9 +12,12 @@ import (
 
 	authclient "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/gateway/internal/clients/auth/grpc"
 	trackerclient "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/gateway/internal/clients/tracker/grpc"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/gateway/internal/domain/models"
 	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/gateway/internal/lib/jwt"
 
 	"github.com/gorilla/mux"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type GatewayServer struct {
@@ -35,7 +38,7 @@ func main() {
 		panic("failed to connect to auth server")
 	}
 
-	trackerClient, err := trackerclient.New(logger, trackerAddr, time.Second*5, 1)
+	trackerClient, err := trackerclient.New(logger, trackerAddr, time.Second*5, 1, "")
 	if err != nil {
 		panic("failed to connect to tracker server")
 	}
@@ -51,6 +54,14 @@ func main() {
 	r.HandleFunc("/logout", server.handleLogout).Methods("POST")
 	r.HandleFunc("/check_item", server.handleGetItem).Methods("POST")
 	r.HandleFunc("/get_all_items", server.handleGetAllItems).Methods("GET")
+	r.HandleFunc("/price_history", server.handlePriceHistory).Methods("POST")
+	r.HandleFunc("/items/{id}", server.handleRemoveItem).Methods("DELETE")
+	r.HandleFunc("/items/{id}", server.handleUpdateItem).Methods("PATCH")
+	r.HandleFunc("/webhooks", server.handleCreateWebhook).Methods("POST")
+	r.HandleFunc("/webhooks", server.handleListWebhooks).Methods("GET")
+	r.HandleFunc("/webhooks/{id}", server.handleDeleteWebhook).Methods("DELETE")
+	r.HandleFunc("/webhooks/{id}/deliveries", server.handleWebhookDeliveries).Methods("GET")
+	r.HandleFunc("/settings/currency", server.handleSetCurrency).Methods("POST")
 
 	log.Println("API Gateway running on :8080")
 	log.Fatal(http.ListenAndServe(":8080", r))
@@ -61,6 +72,7 @@ func (s *GatewayServer) handleRegister(w http.ResponseWriter, r *http.Request) {
 		Login         string `json:"login"`
 		Password      string `json:"password"`
 		TelegramLogin string `json:"telegram_login"`
+		ChatID        int64  `json:"chat_id"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -74,7 +86,7 @@ func (s *GatewayServer) handleRegister(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := s.authClient.Register(context.Background(), req.TelegramLogin, req.Login, req.Password)
+	resp, err := s.authClient.Register(context.Background(), req.TelegramLogin, req.Login, req.Password, req.ChatID)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("registraton failed: %v", err), http.StatusUnauthorized)
 		return
@@ -91,6 +103,7 @@ func (s *GatewayServer) handleLogin(w http.ResponseWriter, r *http.Request) {
 		Login         string `json:"login"`
 		Password      string `json:"password"`
 		TelegramLogin string `json:"telegram_login"`
+		ChatID        int64  `json:"chat_id"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -104,7 +117,7 @@ func (s *GatewayServer) handleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := s.authClient.Login(context.Background(), req.TelegramLogin, req.Login, req.Password)
+	resp, err := s.authClient.Login(context.Background(), req.TelegramLogin, req.Login, req.Password, req.ChatID)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("login failed: %v", err), http.StatusInternalServerError)
 		return
@@ -145,6 +158,7 @@ func (s *GatewayServer) handleGetItem(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		TelegramLogin string `json:"telegram_login"`
 		Link          string `json:"link"`
+		VariantID     string `json:"variant_id"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -165,9 +179,9 @@ func (s *GatewayServer) handleGetItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := s.trackerClient.GetItem(context.Background(), userID, req.Link)
+	resp, err := s.trackerClient.GetItem(context.Background(), userID, req.Link, req.VariantID)
 	if err != nil {
-		http.Error(w, fmt.Sprintf(`{"error": "%v"}`, err), http.StatusInternalServerError)
+		trackerError(w, err)
 		return
 	}
 
@@ -180,6 +194,7 @@ func (s *GatewayServer) handleGetItem(w http.ResponseWriter, r *http.Request) {
 func (s *GatewayServer) handleGetAllItems(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		TelegramLogin string `json:"telegram_login"`
+		Cached        bool   `json:"cached"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -199,9 +214,9 @@ func (s *GatewayServer) handleGetAllItems(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	resp, err := s.trackerClient.GetAllItems(context.Background(), userID)
+	resp, err := s.trackerClient.GetAllItems(context.Background(), userID, req.Cached)
 	if err != nil {
-		http.Error(w, fmt.Sprintf(`{"error": "%v"}`, err), http.StatusInternalServerError)
+		trackerError(w, err)
 		return
 	}
 
@@ -210,3 +225,324 @@ func (s *GatewayServer) handleGetAllItems(w http.ResponseWriter, r *http.Request
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
 	}
 }
+
+func (s *GatewayServer) handlePriceHistory(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TelegramLogin string    `json:"telegram_login"`
+		Link          string    `json:"link"`
+		From          time.Time `json:"from"`
+		To            time.Time `json:"to"`
+		Step          string    `json:"step"`
+		MaxPoints     uint32    `json:"max_points"`
+		Aggregation   string    `json:"aggregation"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var step time.Duration
+	if req.Step != "" {
+		var err error
+		if step, err = time.ParseDuration(req.Step); err != nil {
+			http.Error(w, "invalid step", http.StatusBadRequest)
+			return
+		}
+	}
+
+	token, err := s.authClient.IsLogged(context.Background(), req.TelegramLogin)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := jwt.GetUserID(token)
+	if err != nil {
+		http.Error(w, "failed to validate user's token", http.StatusUnauthorized)
+		return
+	}
+
+	resp, err := s.trackerClient.GetPriceHistory(context.Background(), userID, req.Link, models.PriceHistoryQuery{
+		From:        req.From,
+		To:          req.To,
+		Step:        step,
+		MaxPoints:   req.MaxPoints,
+		Aggregation: req.Aggregation,
+	})
+	if err != nil {
+		trackerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (s *GatewayServer) handleRemoveItem(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TelegramLogin string `json:"telegram_login"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	token, err := s.authClient.IsLogged(context.Background(), req.TelegramLogin)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := jwt.GetUserID(token)
+	if err != nil {
+		http.Error(w, "failed to validate user's token", http.StatusUnauthorized)
+		return
+	}
+
+	err = s.trackerClient.RemoveItem(context.Background(), userID, mux.Vars(r)["id"])
+	if err != nil {
+		trackerError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *GatewayServer) handleUpdateItem(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TelegramLogin string `json:"telegram_login"`
+		models.SubscriptionUpdate
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	token, err := s.authClient.IsLogged(context.Background(), req.TelegramLogin)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := jwt.GetUserID(token)
+	if err != nil {
+		http.Error(w, "failed to validate user's token", http.StatusUnauthorized)
+		return
+	}
+
+	resp, err := s.trackerClient.UpdateSubscription(context.Background(), userID, mux.Vars(r)["id"], req.SubscriptionUpdate)
+	if err != nil {
+		trackerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// handleCreateWebhook registers a URL that receives the user's price changes
+// and alerts. The signing secret is only part of this response.
+func (s *GatewayServer) handleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TelegramLogin string `json:"telegram_login"`
+		URL           string `json:"url"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	token, err := s.authClient.IsLogged(context.Background(), req.TelegramLogin)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := jwt.GetUserID(token)
+	if err != nil {
+		http.Error(w, "failed to validate user's token", http.StatusUnauthorized)
+		return
+	}
+
+	resp, err := s.trackerClient.CreateWebhook(context.Background(), userID, req.URL)
+	if err != nil {
+		trackerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (s *GatewayServer) handleListWebhooks(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TelegramLogin string `json:"telegram_login"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	token, err := s.authClient.IsLogged(context.Background(), req.TelegramLogin)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := jwt.GetUserID(token)
+	if err != nil {
+		http.Error(w, "failed to validate user's token", http.StatusUnauthorized)
+		return
+	}
+
+	resp, err := s.trackerClient.ListWebhooks(context.Background(), userID)
+	if err != nil {
+		trackerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func (s *GatewayServer) handleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TelegramLogin string `json:"telegram_login"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	token, err := s.authClient.IsLogged(context.Background(), req.TelegramLogin)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := jwt.GetUserID(token)
+	if err != nil {
+		http.Error(w, "failed to validate user's token", http.StatusUnauthorized)
+		return
+	}
+
+	err = s.trackerClient.DeleteWebhook(context.Background(), userID, mux.Vars(r)["id"])
+	if err != nil {
+		trackerError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *GatewayServer) handleWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TelegramLogin string `json:"telegram_login"`
+		Limit         uint32 `json:"limit"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	token, err := s.authClient.IsLogged(context.Background(), req.TelegramLogin)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := jwt.GetUserID(token)
+	if err != nil {
+		http.Error(w, "failed to validate user's token", http.StatusUnauthorized)
+		return
+	}
+
+	resp, err := s.trackerClient.ListWebhookDeliveries(context.Background(), userID, mux.Vars(r)["id"], req.Limit)
+	if err != nil {
+		trackerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// trackerError writes an error returned by the tracker service as a JSON
+// object with the status of trackerStatus.
+func trackerError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(trackerStatus(err))
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+// trackerStatus picks the HTTP status for an error returned by the tracker service.
+func trackerStatus(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// handleSetCurrency sets the currency the user's prices are converted to.
+func (s *GatewayServer) handleSetCurrency(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TelegramLogin string `json:"telegram_login"`
+		Currency      string `json:"currency"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	token, err := s.authClient.IsLogged(context.Background(), req.TelegramLogin)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := jwt.GetUserID(token)
+	if err != nil {
+		http.Error(w, "failed to validate user's token", http.StatusUnauthorized)
+		return
+	}
+
+	currency, err := s.trackerClient.SetDisplayCurrency(context.Background(), userID, req.Currency)
+	if err != nil {
+		trackerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]string{"currency": currency}); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
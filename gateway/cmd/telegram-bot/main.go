@@ -2,15 +2,23 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	authclient "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/gateway/internal/clients/auth/grpc"
+	trackerclient "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/gateway/internal/clients/tracker/grpc"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/gateway/internal/domain/models"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/gateway/internal/notifier"
 )
 
 type loginResponse struct {
@@ -35,6 +43,8 @@ func main() {
 	bot.Debug = true
 	log.Printf("Authorized as %s", bot.Self.UserName)
 
+	startNotifier(bot)
+
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 	updates := bot.GetUpdatesChan(u)
@@ -56,14 +66,47 @@ func main() {
 				handleCheckItem(update.Message, bot, update.Message.Chat.UserName)
 			case "get_all_items":
 				handleGetAllItems(update.Message, bot, update.Message.Chat.UserName)
+			case "remove":
+				handleRemove(update.Message, bot, update.Message.Chat.UserName)
+			case "pause":
+				handlePause(update.Message, bot, update.Message.Chat.UserName)
+			case "currency":
+				handleCurrency(update.Message, bot, update.Message.Chat.UserName)
 			default:
 				sendMessage(bot, update.Message.Chat.ID,
-					"Unknown command. Try /login, /register, /logout, /check_item, /get_all_items")
+					"Unknown command. Try /login, /register, /logout, /check_item, /get_all_items, /remove, /pause, /currency")
 			}
 		}
 	}
 }
 
+// startNotifier pushes price alerts to the users in the background. The bot
+// keeps answering commands without it if the services are not configured.
+func startNotifier(bot *tgbotapi.BotAPI) {
+	authAddr := os.Getenv("AUTH_SERVICE_ADDR")
+	trackerAddr := os.Getenv("PRICE_SERVICE_ADDR")
+	if authAddr == "" || trackerAddr == "" {
+		log.Println("AUTH_SERVICE_ADDR or PRICE_SERVICE_ADDR not set, price alerts are disabled")
+		return
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	authClient, err := authclient.New(logger, authAddr, time.Second*5, 1)
+	if err != nil {
+		log.Printf("failed to connect to auth server, price alerts are disabled: %v", err)
+		return
+	}
+
+	trackerClient, err := trackerclient.New(logger, trackerAddr, time.Second*5, 1, os.Getenv("TRACKER_ADMIN_TOKEN"))
+	if err != nil {
+		log.Printf("failed to connect to tracker server, price alerts are disabled: %v", err)
+		return
+	}
+
+	go notifier.New(logger, trackerClient, authClient, bot, notifier.DefaultConfig()).Run(context.Background())
+}
+
 func handleLogin(message *tgbotapi.Message, bot *tgbotapi.BotAPI, telegramLogin string) {
 	args := strings.Fields(message.CommandArguments())
 	if len(args) != 2 {
@@ -74,10 +117,11 @@ func handleLogin(message *tgbotapi.Message, bot *tgbotapi.BotAPI, telegramLogin
 	username, password := args[0], args[1]
 	log.Printf("Received login: %s, password: %s", username, password)
 
-	loginData := map[string]string{
+	loginData := map[string]interface{}{
 		"login":          username,
 		"password":       password,
 		"telegram_login": telegramLogin,
+		"chat_id":        message.Chat.ID,
 	}
 
 	jsonData, err := json.Marshal(loginData)
@@ -126,10 +170,11 @@ func handleRegister(message *tgbotapi.Message, bot *tgbotapi.BotAPI, telegramLog
 	username, password := args[0], args[1]
 	log.Printf("Received login: %s, password: %s", username, password)
 
-	registerData := map[string]string{
+	registerData := map[string]interface{}{
 		"login":          username,
 		"password":       password,
 		"telegram_login": telegramLogin,
+		"chat_id":        message.Chat.ID,
 	}
 
 	jsonData, err := json.Marshal(registerData)
@@ -206,8 +251,8 @@ func handleLogout(message *tgbotapi.Message, bot *tgbotapi.BotAPI, telegramLogin
 
 func handleCheckItem(message *tgbotapi.Message, bot *tgbotapi.BotAPI, telegramLogin string) {
 	args := strings.Fields(message.CommandArguments())
-	if len(args) != 1 {
-		sendMessage(bot, message.Chat.ID, "Usage: /check_item <link>")
+	if len(args) < 1 || len(args) > 2 {
+		sendMessage(bot, message.Chat.ID, "Usage: /check_item <link> [variant id]")
 		return
 	}
 
@@ -218,6 +263,9 @@ func handleCheckItem(message *tgbotapi.Message, bot *tgbotapi.BotAPI, telegramLo
 		"telegram_login": telegramLogin,
 		"link":           link,
 	}
+	if len(args) == 2 {
+		checkItemData["variant_id"] = args[1]
+	}
 
 	jsonData, err := json.Marshal(checkItemData)
 	if err != nil {
@@ -243,35 +291,87 @@ func handleCheckItem(message *tgbotapi.Message, bot *tgbotapi.BotAPI, telegramLo
 
 	fmt.Println("Raw response:", string(bodyBytes))
 
-	var respBody map[string]interface{}
-	if err := json.Unmarshal(bodyBytes, &respBody); err != nil {
-		sendMessage(bot, message.Chat.ID, "Invalid server response format")
+	if resp.StatusCode == http.StatusBadRequest {
+		sendMessage(bot, message.Chat.ID, "Check the link and the variant id, /check_item <link> lists the variants")
 		return
 	}
-
-	fmt.Println("Parsed response:", respBody)
-
 	if resp.StatusCode != http.StatusOK {
 		sendMessage(bot, message.Chat.ID, "you need to login to use this method")
 		return
 	}
 
-	sendMessage(bot, message.Chat.ID, fmt.Sprintf(
-		"Item: %s\nStart Price: %.2f RUB\nCurrent Price: %.2f RUB\nDifference: %.2f",
-		respBody["name"], respBody["start_price"], respBody["current_price"],
-		respBody["difference_price"],
-	))
+	var item models.Item
+	if err := json.Unmarshal(bodyBytes, &item); err != nil {
+		sendMessage(bot, message.Chat.ID, "Invalid server response format")
+		return
+	}
+
+	fmt.Println("Parsed response:", item)
+
+	sendItem(bot, message.Chat.ID, item)
+}
+
+// sendItem describes item, as the caption of its image if it has one.
+func sendItem(bot *tgbotapi.BotAPI, chatID int64, item models.Item) {
+	var text strings.Builder
+	fmt.Fprintf(&text, "Item: %s\nID: %s\n", item.Name, item.ID)
+	if item.VariantName != "" {
+		fmt.Fprintf(&text, "Variant: %s\n", item.VariantName)
+	}
+	if item.Brand != "" {
+		fmt.Fprintf(&text, "Brand: %s\n", item.Brand)
+	}
+	if item.Seller != "" {
+		fmt.Fprintf(&text, "Seller: %s\n", item.Seller)
+	}
+	fmt.Fprintf(&text, "Start Price: %s\nCurrent Price: %s\n",
+		priceText(item.StartPrice, item.ConvertedStartPrice), priceText(item.CurrentPrice, item.ConvertedCurrentPrice))
+	if item.OriginalPrice != nil {
+		fmt.Fprintf(&text, "Without discount: %s (-%d%%)\n", item.OriginalPrice, item.DiscountPercent)
+	}
+	if item.DifferencePrice != nil {
+		fmt.Fprintf(&text, "Difference: %s\n", item.DifferencePrice)
+	} else if item.ConvertedDiffPrice != nil {
+		fmt.Fprintf(&text, "Difference: %s\n", item.ConvertedDiffPrice)
+	}
+	if item.ReviewCount > 0 {
+		fmt.Fprintf(&text, "Rating: %.1f (%d reviews)\n", item.Rating, item.ReviewCount)
+	}
+	fmt.Fprintf(&text, "Status: %s", statusText(item.Status))
+	if item.Quantity > 0 {
+		fmt.Fprintf(&text, ", %d left", item.Quantity)
+	}
+	if len(item.Variants) > 0 {
+		text.WriteString("\nVariants:")
+		for _, variant := range item.Variants {
+			fmt.Fprintf(&text, "\n- %s: ", variant.Name)
+			if variant.Price != nil {
+				fmt.Fprintf(&text, "%s, ", variant.Price)
+			}
+			fmt.Fprintf(&text, "%s (id %s)", statusText(variant.Status), variant.ID)
+		}
+	}
+
+	if item.ImageURL != "" {
+		photo := tgbotapi.NewPhoto(chatID, tgbotapi.FileURL(item.ImageURL))
+		photo.Caption = text.String()
+		if _, err := bot.Send(photo); err == nil {
+			return
+		}
+	}
+	sendMessage(bot, chatID, text.String())
 }
 
 func handleGetAllItems(message *tgbotapi.Message, bot *tgbotapi.BotAPI, telegramLogin string) {
 	args := strings.Fields(message.CommandArguments())
-	if len(args) != 0 {
-		sendMessage(bot, message.Chat.ID, "Usage: /get_all_items")
+	if len(args) > 1 || (len(args) == 1 && args[0] != "cached") {
+		sendMessage(bot, message.Chat.ID, "Usage: /get_all_items [cached]")
 		return
 	}
 
-	getAllItemsData := map[string]string{
+	getAllItemsData := map[string]any{
 		"telegram_login": telegramLogin,
+		"cached":         len(args) == 1,
 	}
 
 	jsonData, err := json.Marshal(getAllItemsData)
@@ -298,7 +398,7 @@ func handleGetAllItems(message *tgbotapi.Message, bot *tgbotapi.BotAPI, telegram
 	}
 	fmt.Println("Raw response:", string(bodyBytes))
 
-	var items []map[string]interface{}
+	var items []models.Item
 	err = json.Unmarshal(bodyBytes, &items)
 	if err != nil {
 		sendMessage(bot, message.Chat.ID, "cannot show your items")
@@ -318,11 +418,175 @@ func handleGetAllItems(message *tgbotapi.Message, bot *tgbotapi.BotAPI, telegram
 	var msg strings.Builder
 	msg.WriteString("Your items:\n")
 	for _, item := range items {
-		msg.WriteString(fmt.Sprintf("- %s: %.2f RUB\n", item["name"], item["current_price"]))
+		paused := ""
+		if item.Paused {
+			paused = ", paused"
+		}
+		stale := ""
+		if item.Error != "" {
+			stale = ", last known price"
+		}
+		name := item.Name
+		if item.VariantName != "" {
+			name += ", " + item.VariantName
+		}
+		discount := ""
+		if item.DiscountPercent > 0 {
+			discount = fmt.Sprintf(" -%d%%", item.DiscountPercent)
+		}
+		msg.WriteString(fmt.Sprintf("- %s: %s%s (%s%s%s)\n  id: %s\n",
+			name, priceText(item.CurrentPrice, item.ConvertedCurrentPrice), discount, statusText(item.Status), paused, stale, item.ID))
 	}
 	sendMessage(bot, message.Chat.ID, msg.String())
 }
 
+func handleRemove(message *tgbotapi.Message, bot *tgbotapi.BotAPI, telegramLogin string) {
+	args := strings.Fields(message.CommandArguments())
+	if len(args) != 1 {
+		sendMessage(bot, message.Chat.ID, "Usage: /remove <id>")
+		return
+	}
+
+	removeData := map[string]string{
+		"telegram_login": telegramLogin,
+	}
+
+	jsonData, err := json.Marshal(removeData)
+	if err != nil {
+		sendMessage(bot, message.Chat.ID, "Failed to marshal request data")
+		return
+	}
+
+	req, _ := http.NewRequest("DELETE", os.Getenv("GATEWAY_URL")+"/items/"+url.PathEscape(args[0]), bytes.NewBuffer(jsonData))
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		sendMessage(bot, message.Chat.ID, "Failed to connect to server")
+		return
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		sendMessage(bot, message.Chat.ID, "Item removed")
+	case http.StatusNotFound, http.StatusBadRequest:
+		sendMessage(bot, message.Chat.ID, "Item not found, see /get_all_items for the ids")
+	default:
+		sendMessage(bot, message.Chat.ID, "you need to login to use this method")
+	}
+}
+
+func handlePause(message *tgbotapi.Message, bot *tgbotapi.BotAPI, telegramLogin string) {
+	args := strings.Fields(message.CommandArguments())
+	if len(args) < 1 || len(args) > 2 || (len(args) == 2 && args[1] != "on" && args[1] != "off") {
+		sendMessage(bot, message.Chat.ID, "Usage: /pause <id> [on|off]")
+		return
+	}
+
+	paused := len(args) == 1 || args[1] == "on"
+
+	pauseData := map[string]interface{}{
+		"telegram_login": telegramLogin,
+		"paused":         paused,
+	}
+
+	jsonData, err := json.Marshal(pauseData)
+	if err != nil {
+		sendMessage(bot, message.Chat.ID, "Failed to marshal request data")
+		return
+	}
+
+	req, _ := http.NewRequest("PATCH", os.Getenv("GATEWAY_URL")+"/items/"+url.PathEscape(args[0]), bytes.NewBuffer(jsonData))
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		sendMessage(bot, message.Chat.ID, "Failed to connect to server")
+		return
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if paused {
+			sendMessage(bot, message.Chat.ID, "Tracking paused")
+		} else {
+			sendMessage(bot, message.Chat.ID, "Tracking resumed")
+		}
+	case http.StatusNotFound, http.StatusBadRequest:
+		sendMessage(bot, message.Chat.ID, "Item not found, see /get_all_items for the ids")
+	default:
+		sendMessage(bot, message.Chat.ID, "you need to login to use this method")
+	}
+}
+
+func handleCurrency(message *tgbotapi.Message, bot *tgbotapi.BotAPI, telegramLogin string) {
+	args := strings.Fields(message.CommandArguments())
+	if len(args) != 1 {
+		sendMessage(bot, message.Chat.ID, "Usage: /currency <code>, e.g. /currency USD")
+		return
+	}
+
+	currencyData := map[string]string{
+		"telegram_login": telegramLogin,
+		"currency":       args[0],
+	}
+
+	jsonData, err := json.Marshal(currencyData)
+	if err != nil {
+		sendMessage(bot, message.Chat.ID, "Failed to marshal request data")
+		return
+	}
+
+	req, _ := http.NewRequest("POST", os.Getenv("GATEWAY_URL")+"/settings/currency", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		sendMessage(bot, message.Chat.ID, "Failed to connect to server")
+		return
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var body struct {
+			Currency string `json:"currency"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			sendMessage(bot, message.Chat.ID, "Failed to read server response")
+			return
+		}
+		sendMessage(bot, message.Chat.ID, "Prices are now shown in "+body.Currency)
+	case http.StatusBadRequest:
+		sendMessage(bot, message.Chat.ID, "Unknown currency, use a three letter code like USD")
+	default:
+		sendMessage(bot, message.Chat.ID, "you need to login to use this method")
+	}
+}
+
+// priceText formats price followed by its converted amount when that is in
+// another currency: "10.00 USD (800.00 RUB)".
+func priceText(price models.Money, converted *models.Money) string {
+	if converted == nil || converted.Currency == price.Currency {
+		return price.String()
+	}
+	return fmt.Sprintf("%s (%s)", price, converted)
+}
+
+func statusText(status string) string {
+	switch status {
+	case "in_stock":
+		return "in stock"
+	case "out_of_stock":
+		return "out of stock"
+	default:
+		return "unknown"
+	}
+}
+
 func sendMessage(bot *tgbotapi.BotAPI, chatID int64, text string) {
 	msg := tgbotapi.NewMessage(chatID, text)
 	_, err := bot.Send(msg)
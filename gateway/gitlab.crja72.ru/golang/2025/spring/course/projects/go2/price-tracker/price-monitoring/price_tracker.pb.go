@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.5
+// 	protoc-gen-go v1.36.6
 // 	protoc        v5.29.3
 // source: price_tracker.proto
 
@@ -9,6 +9,8 @@ package price_monitoring
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,31 +23,2414 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type StockStatus int32
+
+const (
+	StockStatus_STOCK_STATUS_UNKNOWN      StockStatus = 0
+	StockStatus_STOCK_STATUS_IN_STOCK     StockStatus = 1
+	StockStatus_STOCK_STATUS_OUT_OF_STOCK StockStatus = 2
+)
+
+// Enum value maps for StockStatus.
+var (
+	StockStatus_name = map[int32]string{
+		0: "STOCK_STATUS_UNKNOWN",
+		1: "STOCK_STATUS_IN_STOCK",
+		2: "STOCK_STATUS_OUT_OF_STOCK",
+	}
+	StockStatus_value = map[string]int32{
+		"STOCK_STATUS_UNKNOWN":      0,
+		"STOCK_STATUS_IN_STOCK":     1,
+		"STOCK_STATUS_OUT_OF_STOCK": 2,
+	}
+)
+
+func (x StockStatus) Enum() *StockStatus {
+	p := new(StockStatus)
+	*p = x
+	return p
+}
+
+func (x StockStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StockStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_price_tracker_proto_enumTypes[0].Descriptor()
+}
+
+func (StockStatus) Type() protoreflect.EnumType {
+	return &file_price_tracker_proto_enumTypes[0]
+}
+
+func (x StockStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StockStatus.Descriptor instead.
+func (StockStatus) EnumDescriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{0}
+}
+
+type PriceSource int32
+
+const (
+	// Scraped while handling the request.
+	PriceSource_PRICE_SOURCE_LIVE PriceSource = 0
+	// The last observed price, from the catalog.
+	PriceSource_PRICE_SOURCE_CACHED PriceSource = 1
+)
+
+// Enum value maps for PriceSource.
+var (
+	PriceSource_name = map[int32]string{
+		0: "PRICE_SOURCE_LIVE",
+		1: "PRICE_SOURCE_CACHED",
+	}
+	PriceSource_value = map[string]int32{
+		"PRICE_SOURCE_LIVE":   0,
+		"PRICE_SOURCE_CACHED": 1,
+	}
+)
+
+func (x PriceSource) Enum() *PriceSource {
+	p := new(PriceSource)
+	*p = x
+	return p
+}
+
+func (x PriceSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PriceSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_price_tracker_proto_enumTypes[1].Descriptor()
+}
+
+func (PriceSource) Type() protoreflect.EnumType {
+	return &file_price_tracker_proto_enumTypes[1]
+}
+
+func (x PriceSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PriceSource.Descriptor instead.
+func (PriceSource) EnumDescriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{1}
+}
+
+type Aggregation int32
+
+const (
+	Aggregation_AGGREGATION_LAST Aggregation = 0
+	Aggregation_AGGREGATION_MIN  Aggregation = 1
+	Aggregation_AGGREGATION_MAX  Aggregation = 2
+	Aggregation_AGGREGATION_AVG  Aggregation = 3
+)
+
+// Enum value maps for Aggregation.
+var (
+	Aggregation_name = map[int32]string{
+		0: "AGGREGATION_LAST",
+		1: "AGGREGATION_MIN",
+		2: "AGGREGATION_MAX",
+		3: "AGGREGATION_AVG",
+	}
+	Aggregation_value = map[string]int32{
+		"AGGREGATION_LAST": 0,
+		"AGGREGATION_MIN":  1,
+		"AGGREGATION_MAX":  2,
+		"AGGREGATION_AVG":  3,
+	}
+)
+
+func (x Aggregation) Enum() *Aggregation {
+	p := new(Aggregation)
+	*p = x
+	return p
+}
+
+func (x Aggregation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Aggregation) Descriptor() protoreflect.EnumDescriptor {
+	return file_price_tracker_proto_enumTypes[2].Descriptor()
+}
+
+func (Aggregation) Type() protoreflect.EnumType {
+	return &file_price_tracker_proto_enumTypes[2]
+}
+
+func (x Aggregation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Aggregation.Descriptor instead.
+func (Aggregation) EnumDescriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{2}
+}
+
+type AlertKind int32
+
+const (
+	AlertKind_ALERT_KIND_UNSPECIFIED AlertKind = 0
+	// The price fell to target_price or below.
+	AlertKind_ALERT_KIND_TARGET_PRICE AlertKind = 1
+	// The price fell by drop_percent from the start price of the item.
+	AlertKind_ALERT_KIND_PERCENT_DROP AlertKind = 2
+	// The price is lower than on the previous check.
+	AlertKind_ALERT_KIND_ANY_DROP AlertKind = 3
+	// The item was out of stock on the previous check and is available now.
+	AlertKind_ALERT_KIND_BACK_IN_STOCK AlertKind = 4
+)
+
+// Enum value maps for AlertKind.
+var (
+	AlertKind_name = map[int32]string{
+		0: "ALERT_KIND_UNSPECIFIED",
+		1: "ALERT_KIND_TARGET_PRICE",
+		2: "ALERT_KIND_PERCENT_DROP",
+		3: "ALERT_KIND_ANY_DROP",
+		4: "ALERT_KIND_BACK_IN_STOCK",
+	}
+	AlertKind_value = map[string]int32{
+		"ALERT_KIND_UNSPECIFIED":   0,
+		"ALERT_KIND_TARGET_PRICE":  1,
+		"ALERT_KIND_PERCENT_DROP":  2,
+		"ALERT_KIND_ANY_DROP":      3,
+		"ALERT_KIND_BACK_IN_STOCK": 4,
+	}
+)
+
+func (x AlertKind) Enum() *AlertKind {
+	p := new(AlertKind)
+	*p = x
+	return p
+}
+
+func (x AlertKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlertKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_price_tracker_proto_enumTypes[3].Descriptor()
+}
+
+func (AlertKind) Type() protoreflect.EnumType {
+	return &file_price_tracker_proto_enumTypes[3]
+}
+
+func (x AlertKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlertKind.Descriptor instead.
+func (AlertKind) EnumDescriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{3}
+}
+
+type DeliveryStatus int32
+
+const (
+	DeliveryStatus_DELIVERY_STATUS_PENDING   DeliveryStatus = 0
+	DeliveryStatus_DELIVERY_STATUS_DELIVERED DeliveryStatus = 1
+	DeliveryStatus_DELIVERY_STATUS_FAILED    DeliveryStatus = 2
+)
+
+// Enum value maps for DeliveryStatus.
+var (
+	DeliveryStatus_name = map[int32]string{
+		0: "DELIVERY_STATUS_PENDING",
+		1: "DELIVERY_STATUS_DELIVERED",
+		2: "DELIVERY_STATUS_FAILED",
+	}
+	DeliveryStatus_value = map[string]int32{
+		"DELIVERY_STATUS_PENDING":   0,
+		"DELIVERY_STATUS_DELIVERED": 1,
+		"DELIVERY_STATUS_FAILED":    2,
+	}
+)
+
+func (x DeliveryStatus) Enum() *DeliveryStatus {
+	p := new(DeliveryStatus)
+	*p = x
+	return p
+}
+
+func (x DeliveryStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeliveryStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_price_tracker_proto_enumTypes[4].Descriptor()
+}
+
+func (DeliveryStatus) Type() protoreflect.EnumType {
+	return &file_price_tracker_proto_enumTypes[4]
+}
+
+func (x DeliveryStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeliveryStatus.Descriptor instead.
+func (DeliveryStatus) EnumDescriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{4}
+}
+
+type BreakerState int32
+
+const (
+	// Requests are sent to the upstream.
+	BreakerState_BREAKER_STATE_CLOSED BreakerState = 0
+	// The upstream is failing, requests fail fast with an unavailable error.
+	BreakerState_BREAKER_STATE_OPEN BreakerState = 1
+	// A probe request is checking whether the upstream is back.
+	BreakerState_BREAKER_STATE_HALF_OPEN BreakerState = 2
+)
+
+// Enum value maps for BreakerState.
+var (
+	BreakerState_name = map[int32]string{
+		0: "BREAKER_STATE_CLOSED",
+		1: "BREAKER_STATE_OPEN",
+		2: "BREAKER_STATE_HALF_OPEN",
+	}
+	BreakerState_value = map[string]int32{
+		"BREAKER_STATE_CLOSED":    0,
+		"BREAKER_STATE_OPEN":      1,
+		"BREAKER_STATE_HALF_OPEN": 2,
+	}
+)
+
+func (x BreakerState) Enum() *BreakerState {
+	p := new(BreakerState)
+	*p = x
+	return p
+}
+
+func (x BreakerState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BreakerState) Descriptor() protoreflect.EnumDescriptor {
+	return file_price_tracker_proto_enumTypes[5].Descriptor()
+}
+
+func (BreakerState) Type() protoreflect.EnumType {
+	return &file_price_tracker_proto_enumTypes[5]
+}
+
+func (x BreakerState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BreakerState.Descriptor instead.
+func (BreakerState) EnumDescriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{5}
+}
+
+// An exact amount in the minor units of an ISO 4217 currency, e.g. 199950
+// RUB is 1999.50 rubles.
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_price_tracker_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
 type ItemResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Name   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status StockStatus            `protobuf:"varint,5,opt,name=status,proto3,enum=price_tracker.StockStatus" json:"status,omitempty"`
+	// Subscription id, used to remove or update the item.
+	Id     string      `protobuf:"bytes,6,opt,name=id,proto3" json:"id,omitempty"`
+	Paused bool        `protobuf:"varint,7,opt,name=paused,proto3" json:"paused,omitempty"`
+	Source PriceSource `protobuf:"varint,8,opt,name=source,proto3,enum=price_tracker.PriceSource" json:"source,omitempty"`
+	// Set by GetAllItems when the link could not be scraped, the item
+	// carries the last observed price then.
+	Error        string `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	StartPrice   *Money `protobuf:"bytes,10,opt,name=start_price,json=startPrice,proto3" json:"start_price,omitempty"`
+	CurrentPrice *Money `protobuf:"bytes,11,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
+	// current_price - start_price, not set when they are in different currencies.
+	DiffPrice *Money `protobuf:"bytes,12,opt,name=diff_price,json=diffPrice,proto3" json:"diff_price,omitempty"`
+	// The price before the discount, not set if there is no discount.
+	OriginalPrice   *Money  `protobuf:"bytes,13,opt,name=original_price,json=originalPrice,proto3" json:"original_price,omitempty"`
+	DiscountPercent int32   `protobuf:"varint,14,opt,name=discount_percent,json=discountPercent,proto3" json:"discount_percent,omitempty"`
+	Brand           string  `protobuf:"bytes,15,opt,name=brand,proto3" json:"brand,omitempty"`
+	Seller          string  `protobuf:"bytes,16,opt,name=seller,proto3" json:"seller,omitempty"`
+	Rating          float32 `protobuf:"fixed32,17,opt,name=rating,proto3" json:"rating,omitempty"`
+	ReviewCount     int32   `protobuf:"varint,18,opt,name=review_count,json=reviewCount,proto3" json:"review_count,omitempty"`
+	Quantity        int32   `protobuf:"varint,19,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ImageUrl        string  `protobuf:"bytes,20,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	// The prices in the display currency of the user, each not set when
+	// there is no exchange rate for it.
+	ConvertedStartPrice   *Money `protobuf:"bytes,21,opt,name=converted_start_price,json=convertedStartPrice,proto3" json:"converted_start_price,omitempty"`
+	ConvertedCurrentPrice *Money `protobuf:"bytes,22,opt,name=converted_current_price,json=convertedCurrentPrice,proto3" json:"converted_current_price,omitempty"`
+	ConvertedDiffPrice    *Money `protobuf:"bytes,23,opt,name=converted_diff_price,json=convertedDiffPrice,proto3" json:"converted_diff_price,omitempty"`
+	// The variant the subscription is pinned to, the prices, status and
+	// quantity are those of the variant then.
+	VariantId   string `protobuf:"bytes,24,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	VariantName string `protobuf:"bytes,25,opt,name=variant_name,json=variantName,proto3" json:"variant_name,omitempty"`
+	// The sizes or colors the product comes in, only set on live items.
+	Variants      []*Variant `protobuf:"bytes,26,rep,name=variants,proto3" json:"variants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemResponse) Reset() {
+	*x = ItemResponse{}
+	mi := &file_price_tracker_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemResponse) ProtoMessage() {}
+
+func (x *ItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemResponse.ProtoReflect.Descriptor instead.
+func (*ItemResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ItemResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ItemResponse) GetStatus() StockStatus {
+	if x != nil {
+		return x.Status
+	}
+	return StockStatus_STOCK_STATUS_UNKNOWN
+}
+
+func (x *ItemResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ItemResponse) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *ItemResponse) GetSource() PriceSource {
+	if x != nil {
+		return x.Source
+	}
+	return PriceSource_PRICE_SOURCE_LIVE
+}
+
+func (x *ItemResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ItemResponse) GetStartPrice() *Money {
+	if x != nil {
+		return x.StartPrice
+	}
+	return nil
+}
+
+func (x *ItemResponse) GetCurrentPrice() *Money {
+	if x != nil {
+		return x.CurrentPrice
+	}
+	return nil
+}
+
+func (x *ItemResponse) GetDiffPrice() *Money {
+	if x != nil {
+		return x.DiffPrice
+	}
+	return nil
+}
+
+func (x *ItemResponse) GetOriginalPrice() *Money {
+	if x != nil {
+		return x.OriginalPrice
+	}
+	return nil
+}
+
+func (x *ItemResponse) GetDiscountPercent() int32 {
+	if x != nil {
+		return x.DiscountPercent
+	}
+	return 0
+}
+
+func (x *ItemResponse) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *ItemResponse) GetSeller() string {
+	if x != nil {
+		return x.Seller
+	}
+	return ""
+}
+
+func (x *ItemResponse) GetRating() float32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *ItemResponse) GetReviewCount() int32 {
+	if x != nil {
+		return x.ReviewCount
+	}
+	return 0
+}
+
+func (x *ItemResponse) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ItemResponse) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *ItemResponse) GetConvertedStartPrice() *Money {
+	if x != nil {
+		return x.ConvertedStartPrice
+	}
+	return nil
+}
+
+func (x *ItemResponse) GetConvertedCurrentPrice() *Money {
+	if x != nil {
+		return x.ConvertedCurrentPrice
+	}
+	return nil
+}
+
+func (x *ItemResponse) GetConvertedDiffPrice() *Money {
+	if x != nil {
+		return x.ConvertedDiffPrice
+	}
+	return nil
+}
+
+func (x *ItemResponse) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+func (x *ItemResponse) GetVariantName() string {
+	if x != nil {
+		return x.VariantName
+	}
+	return ""
+}
+
+func (x *ItemResponse) GetVariants() []*Variant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type Variant struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Pass as GetItemRequest.variant_id to track this variant.
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Not set when the variant is out of stock.
+	Price         *Money      `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	OriginalPrice *Money      `protobuf:"bytes,4,opt,name=original_price,json=originalPrice,proto3" json:"original_price,omitempty"`
+	Status        StockStatus `protobuf:"varint,5,opt,name=status,proto3,enum=price_tracker.StockStatus" json:"status,omitempty"`
+	Quantity      int32       `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Variant) Reset() {
+	*x = Variant{}
+	mi := &file_price_tracker_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Variant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Variant) ProtoMessage() {}
+
+func (x *Variant) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Variant.ProtoReflect.Descriptor instead.
+func (*Variant) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Variant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Variant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Variant) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *Variant) GetOriginalPrice() *Money {
+	if x != nil {
+		return x.OriginalPrice
+	}
+	return nil
+}
+
+func (x *Variant) GetStatus() StockStatus {
+	if x != nil {
+		return x.Status
+	}
+	return StockStatus_STOCK_STATUS_UNKNOWN
+}
+
+func (x *Variant) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type GetItemRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Link   string                 `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Pins the subscription to one of the variants of the product, or
+	// re-pins it, resetting the start price. Empty keeps the current one.
+	VariantId     string `protobuf:"bytes,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemRequest) Reset() {
+	*x = GetItemRequest{}
+	mi := &file_price_tracker_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemRequest) ProtoMessage() {}
+
+func (x *GetItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemRequest.ProtoReflect.Descriptor instead.
+func (*GetItemRequest) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetItemRequest) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *GetItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetItemRequest) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+type GetItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *ItemResponse          `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemResponse) Reset() {
+	*x = GetItemResponse{}
+	mi := &file_price_tracker_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemResponse) ProtoMessage() {}
+
+func (x *GetItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemResponse.ProtoReflect.Descriptor instead.
+func (*GetItemResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetItemResponse) GetItem() *ItemResponse {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type GetAllItemsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Returns the last observed prices without scraping the links.
+	Cached bool `protobuf:"varint,2,opt,name=cached,proto3" json:"cached,omitempty"`
+	// Items per page, at most 100. Zero returns all the items.
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page, empty for the first page.
+	PageToken     string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllItemsRequest) Reset() {
+	*x = GetAllItemsRequest{}
+	mi := &file_price_tracker_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllItemsRequest) ProtoMessage() {}
+
+func (x *GetAllItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllItemsRequest.ProtoReflect.Descriptor instead.
+func (*GetAllItemsRequest) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetAllItemsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetAllItemsRequest) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+func (x *GetAllItemsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetAllItemsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetAllItemsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Items []*ItemResponse        `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// Token of the next page, empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllItemsResponse) Reset() {
+	*x = GetAllItemsResponse{}
+	mi := &file_price_tracker_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllItemsResponse) ProtoMessage() {}
+
+func (x *GetAllItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllItemsResponse.ProtoReflect.Descriptor instead.
+func (*GetAllItemsResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetAllItemsResponse) GetItems() []*ItemResponse {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetAllItemsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetPriceHistoryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Link   string                 `protobuf:"bytes,2,opt,name=link,proto3" json:"link,omitempty"`
+	// Defaults to 30 days before to.
+	From *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	// Defaults to now.
+	To *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	// Width of a downsampling bucket. Zero returns every observation
+	// unless max_points is set.
+	Step *durationpb.Duration `protobuf:"bytes,5,opt,name=step,proto3" json:"step,omitempty"`
+	// Picks the step so that at most max_points buckets cover the range.
+	MaxPoints     uint32      `protobuf:"varint,6,opt,name=max_points,json=maxPoints,proto3" json:"max_points,omitempty"`
+	Aggregation   Aggregation `protobuf:"varint,7,opt,name=aggregation,proto3,enum=price_tracker.Aggregation" json:"aggregation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryRequest) Reset() {
+	*x = GetPriceHistoryRequest{}
+	mi := &file_price_tracker_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryRequest) ProtoMessage() {}
+
+func (x *GetPriceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetPriceHistoryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetPriceHistoryRequest) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *GetPriceHistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetPriceHistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetPriceHistoryRequest) GetStep() *durationpb.Duration {
+	if x != nil {
+		return x.Step
+	}
+	return nil
+}
+
+func (x *GetPriceHistoryRequest) GetMaxPoints() uint32 {
+	if x != nil {
+		return x.MaxPoints
+	}
+	return 0
+}
+
+func (x *GetPriceHistoryRequest) GetAggregation() Aggregation {
+	if x != nil {
+		return x.Aggregation
+	}
+	return Aggregation_AGGREGATION_LAST
+}
+
+type PricePoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Status        StockStatus            `protobuf:"varint,3,opt,name=status,proto3,enum=price_tracker.StockStatus" json:"status,omitempty"`
+	Price         *Money                 `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PricePoint) Reset() {
+	*x = PricePoint{}
+	mi := &file_price_tracker_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PricePoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PricePoint) ProtoMessage() {}
+
+func (x *PricePoint) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PricePoint.ProtoReflect.Descriptor instead.
+func (*PricePoint) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PricePoint) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *PricePoint) GetStatus() StockStatus {
+	if x != nil {
+		return x.Status
+	}
+	return StockStatus_STOCK_STATUS_UNKNOWN
+}
+
+func (x *PricePoint) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+type GetPriceHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        []*PricePoint          `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryResponse) Reset() {
+	*x = GetPriceHistoryResponse{}
+	mi := &file_price_tracker_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryResponse) ProtoMessage() {}
+
+func (x *GetPriceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetPriceHistoryResponse) GetPoints() []*PricePoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type RemoveItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveItemRequest) Reset() {
+	*x = RemoveItemRequest{}
+	mi := &file_price_tracker_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveItemRequest) ProtoMessage() {}
+
+func (x *RemoveItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveItemRequest.ProtoReflect.Descriptor instead.
+func (*RemoveItemRequest) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RemoveItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RemoveItemRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RemoveItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveItemResponse) Reset() {
+	*x = RemoveItemResponse{}
+	mi := &file_price_tracker_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveItemResponse) ProtoMessage() {}
+
+func (x *RemoveItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveItemResponse.ProtoReflect.Descriptor instead.
+func (*RemoveItemResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{11}
+}
+
+type UpdateSubscriptionRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id     string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Renames the item for this user, an empty name restores the product name.
+	Name *string `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// Paused items are not polled in the background.
+	Paused *bool `protobuf:"varint,4,opt,name=paused,proto3,oneof" json:"paused,omitempty"`
+	// Sets the baseline price to the latest observed price.
+	ResetStartPrice bool `protobuf:"varint,5,opt,name=reset_start_price,json=resetStartPrice,proto3" json:"reset_start_price,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateSubscriptionRequest) Reset() {
+	*x = UpdateSubscriptionRequest{}
+	mi := &file_price_tracker_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSubscriptionRequest) ProtoMessage() {}
+
+func (x *UpdateSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateSubscriptionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateSubscriptionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateSubscriptionRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateSubscriptionRequest) GetPaused() bool {
+	if x != nil && x.Paused != nil {
+		return *x.Paused
+	}
+	return false
+}
+
+func (x *UpdateSubscriptionRequest) GetResetStartPrice() bool {
+	if x != nil {
+		return x.ResetStartPrice
+	}
+	return false
+}
+
+type UpdateSubscriptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *ItemResponse          `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSubscriptionResponse) Reset() {
+	*x = UpdateSubscriptionResponse{}
+	mi := &file_price_tracker_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSubscriptionResponse) ProtoMessage() {}
+
+func (x *UpdateSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateSubscriptionResponse) GetItem() *ItemResponse {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type AlertRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Kind          AlertKind              `protobuf:"varint,3,opt,name=kind,proto3,enum=price_tracker.AlertKind" json:"kind,omitempty"`
+	DropPercent   float32                `protobuf:"fixed32,5,opt,name=drop_percent,json=dropPercent,proto3" json:"drop_percent,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	TargetPrice   *Money                 `protobuf:"bytes,7,opt,name=target_price,json=targetPrice,proto3" json:"target_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertRule) Reset() {
+	*x = AlertRule{}
+	mi := &file_price_tracker_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertRule) ProtoMessage() {}
+
+func (x *AlertRule) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertRule.ProtoReflect.Descriptor instead.
+func (*AlertRule) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AlertRule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AlertRule) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *AlertRule) GetKind() AlertKind {
+	if x != nil {
+		return x.Kind
+	}
+	return AlertKind_ALERT_KIND_UNSPECIFIED
+}
+
+func (x *AlertRule) GetDropPercent() float32 {
+	if x != nil {
+		return x.DropPercent
+	}
+	return 0
+}
+
+func (x *AlertRule) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AlertRule) GetTargetPrice() *Money {
+	if x != nil {
+		return x.TargetPrice
+	}
+	return nil
+}
+
+type CreateAlertRuleRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Subscription id of the item, see ItemResponse.id.
+	ItemId        string    `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Kind          AlertKind `protobuf:"varint,3,opt,name=kind,proto3,enum=price_tracker.AlertKind" json:"kind,omitempty"`
+	DropPercent   float32   `protobuf:"fixed32,5,opt,name=drop_percent,json=dropPercent,proto3" json:"drop_percent,omitempty"`
+	TargetPrice   *Money    `protobuf:"bytes,6,opt,name=target_price,json=targetPrice,proto3" json:"target_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAlertRuleRequest) Reset() {
+	*x = CreateAlertRuleRequest{}
+	mi := &file_price_tracker_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAlertRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAlertRuleRequest) ProtoMessage() {}
+
+func (x *CreateAlertRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAlertRuleRequest.ProtoReflect.Descriptor instead.
+func (*CreateAlertRuleRequest) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateAlertRuleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateAlertRuleRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *CreateAlertRuleRequest) GetKind() AlertKind {
+	if x != nil {
+		return x.Kind
+	}
+	return AlertKind_ALERT_KIND_UNSPECIFIED
+}
+
+func (x *CreateAlertRuleRequest) GetDropPercent() float32 {
+	if x != nil {
+		return x.DropPercent
+	}
+	return 0
+}
+
+func (x *CreateAlertRuleRequest) GetTargetPrice() *Money {
+	if x != nil {
+		return x.TargetPrice
+	}
+	return nil
+}
+
+type CreateAlertRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *AlertRule             `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAlertRuleResponse) Reset() {
+	*x = CreateAlertRuleResponse{}
+	mi := &file_price_tracker_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAlertRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAlertRuleResponse) ProtoMessage() {}
+
+func (x *CreateAlertRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAlertRuleResponse.ProtoReflect.Descriptor instead.
+func (*CreateAlertRuleResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateAlertRuleResponse) GetRule() *AlertRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type ListAlertRulesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Lists the rules of a single item, all rules of the user if empty.
+	ItemId        string `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertRulesRequest) Reset() {
+	*x = ListAlertRulesRequest{}
+	mi := &file_price_tracker_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertRulesRequest) ProtoMessage() {}
+
+func (x *ListAlertRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListAlertRulesRequest) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListAlertRulesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListAlertRulesRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+type ListAlertRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*AlertRule           `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertRulesResponse) Reset() {
+	*x = ListAlertRulesResponse{}
+	mi := &file_price_tracker_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertRulesResponse) ProtoMessage() {}
+
+func (x *ListAlertRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListAlertRulesResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListAlertRulesResponse) GetRules() []*AlertRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type DeleteAlertRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAlertRuleRequest) Reset() {
+	*x = DeleteAlertRuleRequest{}
+	mi := &file_price_tracker_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAlertRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAlertRuleRequest) ProtoMessage() {}
+
+func (x *DeleteAlertRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAlertRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAlertRuleRequest) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteAlertRuleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteAlertRuleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteAlertRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAlertRuleResponse) Reset() {
+	*x = DeleteAlertRuleResponse{}
+	mi := &file_price_tracker_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAlertRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAlertRuleResponse) ProtoMessage() {}
+
+func (x *DeleteAlertRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAlertRuleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAlertRuleResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{20}
+}
+
+type AlertEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Link      string                 `protobuf:"bytes,3,opt,name=link,proto3" json:"link,omitempty"`
+	Name      string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Kind      AlertKind              `protobuf:"varint,5,opt,name=kind,proto3,enum=price_tracker.AlertKind" json:"kind,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Price     *Money                 `protobuf:"bytes,9,opt,name=price,proto3" json:"price,omitempty"`
+	// Not set when the product had no previous observation.
+	PreviousPrice *Money `protobuf:"bytes,10,opt,name=previous_price,json=previousPrice,proto3" json:"previous_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertEvent) Reset() {
+	*x = AlertEvent{}
+	mi := &file_price_tracker_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertEvent) ProtoMessage() {}
+
+func (x *AlertEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertEvent.ProtoReflect.Descriptor instead.
+func (*AlertEvent) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AlertEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AlertEvent) GetKind() AlertKind {
+	if x != nil {
+		return x.Kind
+	}
+	return AlertKind_ALERT_KIND_UNSPECIFIED
+}
+
+func (x *AlertEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AlertEvent) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *AlertEvent) GetPreviousPrice() *Money {
+	if x != nil {
+		return x.PreviousPrice
+	}
+	return nil
+}
+
+type PendingAlertsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of events to return, 100 if not set.
+	Limit         uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingAlertsRequest) Reset() {
+	*x = PendingAlertsRequest{}
+	mi := &file_price_tracker_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingAlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingAlertsRequest) ProtoMessage() {}
+
+func (x *PendingAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingAlertsRequest.ProtoReflect.Descriptor instead.
+func (*PendingAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PendingAlertsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type PendingAlertsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Undelivered events, oldest first.
+	Events        []*AlertEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingAlertsResponse) Reset() {
+	*x = PendingAlertsResponse{}
+	mi := &file_price_tracker_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingAlertsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingAlertsResponse) ProtoMessage() {}
+
+func (x *PendingAlertsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingAlertsResponse.ProtoReflect.Descriptor instead.
+func (*PendingAlertsResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PendingAlertsResponse) GetEvents() []*AlertEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type AckAlertsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckAlertsRequest) Reset() {
+	*x = AckAlertsRequest{}
+	mi := &file_price_tracker_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckAlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckAlertsRequest) ProtoMessage() {}
+
+func (x *AckAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckAlertsRequest.ProtoReflect.Descriptor instead.
+func (*AckAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AckAlertsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type AckAlertsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckAlertsResponse) Reset() {
+	*x = AckAlertsResponse{}
+	mi := &file_price_tracker_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckAlertsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckAlertsResponse) ProtoMessage() {}
+
+func (x *AckAlertsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckAlertsResponse.ProtoReflect.Descriptor instead.
+func (*AckAlertsResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{25}
+}
+
+type ReplayOutboxEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Dead events to replay, all of them if empty.
+	Ids           []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayOutboxEventsRequest) Reset() {
+	*x = ReplayOutboxEventsRequest{}
+	mi := &file_price_tracker_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayOutboxEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayOutboxEventsRequest) ProtoMessage() {}
+
+func (x *ReplayOutboxEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayOutboxEventsRequest.ProtoReflect.Descriptor instead.
+func (*ReplayOutboxEventsRequest) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ReplayOutboxEventsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type ReplayOutboxEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Replayed      int64                  `protobuf:"varint,1,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayOutboxEventsResponse) Reset() {
+	*x = ReplayOutboxEventsResponse{}
+	mi := &file_price_tracker_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayOutboxEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayOutboxEventsResponse) ProtoMessage() {}
+
+func (x *ReplayOutboxEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayOutboxEventsResponse.ProtoReflect.Descriptor instead.
+func (*ReplayOutboxEventsResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReplayOutboxEventsResponse) GetReplayed() int64 {
+	if x != nil {
+		return x.Replayed
+	}
+	return 0
+}
+
+type Webhook struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url   string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// Only returned by CreateWebhook. Deliveries carry the header
+	// X-Tracker-Signature: sha256=hex(HMAC-SHA256(secret, timestamp + "." + body))
+	// where timestamp is the X-Tracker-Timestamp header.
+	Secret string `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Webhooks are disabled after repeated delivery failures.
+	Active        bool                   `protobuf:"varint,4,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_price_tracker_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Webhook) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Webhook) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Webhook) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookRequest) Reset() {
+	*x = CreateWebhookRequest{}
+	mi := &file_price_tracker_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookRequest) ProtoMessage() {}
+
+func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateWebhookRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type CreateWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookResponse) Reset() {
+	*x = CreateWebhookResponse{}
+	mi := &file_price_tracker_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookResponse) ProtoMessage() {}
+
+func (x *CreateWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookResponse.ProtoReflect.Descriptor instead.
+func (*CreateWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateWebhookResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+type ListWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_price_tracker_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksRequest) ProtoMessage() {}
+
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListWebhooksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhooks      []*Webhook             `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksResponse) Reset() {
+	*x = ListWebhooksResponse{}
+	mi := &file_price_tracker_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksResponse) ProtoMessage() {}
+
+func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListWebhooksResponse) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+type DeleteWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookRequest) Reset() {
+	*x = DeleteWebhookRequest{}
+	mi := &file_price_tracker_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeleteWebhookRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteWebhookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteWebhookResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	StartPrice    float32                `protobuf:"fixed32,2,opt,name=start_price,json=startPrice,proto3" json:"start_price,omitempty"`
-	CurrentPrice  float32                `protobuf:"fixed32,3,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
-	DiffPrice     float32                `protobuf:"fixed32,4,opt,name=diff_price,json=diffPrice,proto3" json:"diff_price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ItemResponse) Reset() {
-	*x = ItemResponse{}
-	mi := &file_price_tracker_proto_msgTypes[0]
+func (x *DeleteWebhookResponse) Reset() {
+	*x = DeleteWebhookResponse{}
+	mi := &file_price_tracker_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ItemResponse) String() string {
+func (x *DeleteWebhookResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ItemResponse) ProtoMessage() {}
+func (*DeleteWebhookResponse) ProtoMessage() {}
 
-func (x *ItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_price_tracker_proto_msgTypes[0]
+func (x *DeleteWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -56,62 +2441,146 @@ func (x *ItemResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ItemResponse.ProtoReflect.Descriptor instead.
-func (*ItemResponse) Descriptor() ([]byte, []int) {
-	return file_price_tracker_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use DeleteWebhookResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *ItemResponse) GetName() string {
+type WebhookDelivery struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EventId string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Outbox topic of the event: "alert" or "price_changed".
+	Event    string         `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	Status   DeliveryStatus `protobuf:"varint,4,opt,name=status,proto3,enum=price_tracker.DeliveryStatus" json:"status,omitempty"`
+	Attempts int32          `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// HTTP status of the last attempt, zero if there was no response.
+	ResponseCode  int32                  `protobuf:"varint,6,opt,name=response_code,json=responseCode,proto3" json:"response_code,omitempty"`
+	LastError     string                 `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeliveredAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_price_tracker_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[35]
 	if x != nil {
-		return x.Name
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *WebhookDelivery) GetId() string {
+	if x != nil {
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ItemResponse) GetStartPrice() float32 {
+func (x *WebhookDelivery) GetEventId() string {
 	if x != nil {
-		return x.StartPrice
+		return x.EventId
 	}
-	return 0
+	return ""
 }
 
-func (x *ItemResponse) GetCurrentPrice() float32 {
+func (x *WebhookDelivery) GetEvent() string {
 	if x != nil {
-		return x.CurrentPrice
+		return x.Event
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetStatus() DeliveryStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DeliveryStatus_DELIVERY_STATUS_PENDING
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
 	}
 	return 0
 }
 
-func (x *ItemResponse) GetDiffPrice() float32 {
+func (x *WebhookDelivery) GetResponseCode() int32 {
 	if x != nil {
-		return x.DiffPrice
+		return x.ResponseCode
 	}
 	return 0
 }
 
-type GetItemRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Link          string                 `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+type ListWebhookDeliveriesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WebhookId string                 `protobuf:"bytes,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	// Maximum number of deliveries to return, 50 if not set.
+	Limit         uint32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetItemRequest) Reset() {
-	*x = GetItemRequest{}
-	mi := &file_price_tracker_proto_msgTypes[1]
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_price_tracker_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetItemRequest) String() string {
+func (x *ListWebhookDeliveriesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetItemRequest) ProtoMessage() {}
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
 
-func (x *GetItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_price_tracker_proto_msgTypes[1]
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -122,47 +2591,55 @@ func (x *GetItemRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetItemRequest.ProtoReflect.Descriptor instead.
-func (*GetItemRequest) Descriptor() ([]byte, []int) {
-	return file_price_tracker_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *GetItemRequest) GetLink() string {
+func (x *ListWebhookDeliveriesRequest) GetUserId() string {
 	if x != nil {
-		return x.Link
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *GetItemRequest) GetUserId() string {
+func (x *ListWebhookDeliveriesRequest) GetWebhookId() string {
 	if x != nil {
-		return x.UserId
+		return x.WebhookId
 	}
 	return ""
 }
 
-type GetItemResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Item          *ItemResponse          `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+func (x *ListWebhookDeliveriesRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListWebhookDeliveriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first.
+	Deliveries    []*WebhookDelivery `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetItemResponse) Reset() {
-	*x = GetItemResponse{}
-	mi := &file_price_tracker_proto_msgTypes[2]
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_price_tracker_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetItemResponse) String() string {
+func (x *ListWebhookDeliveriesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetItemResponse) ProtoMessage() {}
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
 
-func (x *GetItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_price_tracker_proto_msgTypes[2]
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -173,40 +2650,109 @@ func (x *GetItemResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetItemResponse.ProtoReflect.Descriptor instead.
-func (*GetItemResponse) Descriptor() ([]byte, []int) {
-	return file_price_tracker_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *GetItemResponse) GetItem() *ItemResponse {
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
 	if x != nil {
-		return x.Item
+		return x.Deliveries
 	}
 	return nil
 }
 
-type GetAllItemsRequest struct {
+type UpstreamHealth struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Marketplace name, or the host for shops without configured limits.
+	Name                string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	State               BreakerState `protobuf:"varint,2,opt,name=state,proto3,enum=price_tracker.BreakerState" json:"state,omitempty"`
+	ConsecutiveFailures int32        `protobuf:"varint,3,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	// Not set while the breaker is closed.
+	OpenedAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=opened_at,json=openedAt,proto3" json:"opened_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpstreamHealth) Reset() {
+	*x = UpstreamHealth{}
+	mi := &file_price_tracker_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpstreamHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpstreamHealth) ProtoMessage() {}
+
+func (x *UpstreamHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpstreamHealth.ProtoReflect.Descriptor instead.
+func (*UpstreamHealth) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UpstreamHealth) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpstreamHealth) GetState() BreakerState {
+	if x != nil {
+		return x.State
+	}
+	return BreakerState_BREAKER_STATE_CLOSED
+}
+
+func (x *UpstreamHealth) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *UpstreamHealth) GetOpenedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OpenedAt
+	}
+	return nil
+}
+
+type HealthRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAllItemsRequest) Reset() {
-	*x = GetAllItemsRequest{}
-	mi := &file_price_tracker_proto_msgTypes[3]
+func (x *HealthRequest) Reset() {
+	*x = HealthRequest{}
+	mi := &file_price_tracker_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllItemsRequest) String() string {
+func (x *HealthRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllItemsRequest) ProtoMessage() {}
+func (*HealthRequest) ProtoMessage() {}
 
-func (x *GetAllItemsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_price_tracker_proto_msgTypes[3]
+func (x *HealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -217,40 +2763,131 @@ func (x *GetAllItemsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllItemsRequest.ProtoReflect.Descriptor instead.
-func (*GetAllItemsRequest) Descriptor() ([]byte, []int) {
-	return file_price_tracker_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *GetAllItemsRequest) GetUserId() string {
+type HealthResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Upstreams contacted since the tracker started, by name.
+	Upstreams     []*UpstreamHealth `protobuf:"bytes,1,rep,name=upstreams,proto3" json:"upstreams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthResponse) Reset() {
+	*x = HealthResponse{}
+	mi := &file_price_tracker_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthResponse) ProtoMessage() {}
+
+func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *HealthResponse) GetUpstreams() []*UpstreamHealth {
+	if x != nil {
+		return x.Upstreams
+	}
+	return nil
+}
+
+type SetDisplayCurrencyRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// ISO 4217 code, e.g. "USD".
+	Currency      string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDisplayCurrencyRequest) Reset() {
+	*x = SetDisplayCurrencyRequest{}
+	mi := &file_price_tracker_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDisplayCurrencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDisplayCurrencyRequest) ProtoMessage() {}
+
+func (x *SetDisplayCurrencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDisplayCurrencyRequest.ProtoReflect.Descriptor instead.
+func (*SetDisplayCurrencyRequest) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SetDisplayCurrencyRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-type GetAllItemsResponse struct {
+func (x *SetDisplayCurrencyRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type SetDisplayCurrencyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Items         []*ItemResponse        `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAllItemsResponse) Reset() {
-	*x = GetAllItemsResponse{}
-	mi := &file_price_tracker_proto_msgTypes[4]
+func (x *SetDisplayCurrencyResponse) Reset() {
+	*x = SetDisplayCurrencyResponse{}
+	mi := &file_price_tracker_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAllItemsResponse) String() string {
+func (x *SetDisplayCurrencyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllItemsResponse) ProtoMessage() {}
+func (*SetDisplayCurrencyResponse) ProtoMessage() {}
 
-func (x *GetAllItemsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_price_tracker_proto_msgTypes[4]
+func (x *SetDisplayCurrencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_price_tracker_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -261,66 +2898,254 @@ func (x *GetAllItemsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllItemsResponse.ProtoReflect.Descriptor instead.
-func (*GetAllItemsResponse) Descriptor() ([]byte, []int) {
-	return file_price_tracker_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use SetDisplayCurrencyResponse.ProtoReflect.Descriptor instead.
+func (*SetDisplayCurrencyResponse) Descriptor() ([]byte, []int) {
+	return file_price_tracker_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *GetAllItemsResponse) GetItems() []*ItemResponse {
+func (x *SetDisplayCurrencyResponse) GetCurrency() string {
 	if x != nil {
-		return x.Items
+		return x.Currency
 	}
-	return nil
+	return ""
 }
 
 var File_price_tracker_proto protoreflect.FileDescriptor
 
-var file_price_tracker_proto_rawDesc = string([]byte{
-	0x0a, 0x13, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x72, 0x61,
-	0x63, 0x6b, 0x65, 0x72, 0x22, 0x87, 0x01, 0x0a, 0x0c, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61,
-	0x72, 0x74, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0a,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x75,
-	0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x02, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12,
-	0x1d, 0x0a, 0x0a, 0x64, 0x69, 0x66, 0x66, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x02, 0x52, 0x09, 0x64, 0x69, 0x66, 0x66, 0x50, 0x72, 0x69, 0x63, 0x65, 0x22, 0x3d,
-	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x42, 0x0a,
-	0x0f, 0x47, 0x65, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x2f, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
-	0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x49,
-	0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x04, 0x69, 0x74, 0x65,
-	0x6d, 0x22, 0x2d, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x49, 0x74, 0x65, 0x6d, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
-	0x22, 0x48, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x74,
-	0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x32, 0xa9, 0x01, 0x0a, 0x07, 0x53,
-	0x63, 0x72, 0x61, 0x70, 0x65, 0x72, 0x12, 0x48, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x49, 0x74, 0x65,
-	0x6d, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65,
-	0x72, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72,
-	0x2e, 0x47, 0x65, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x54, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12,
-	0x21, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e,
-	0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b,
-	0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x58, 0x5a, 0x56, 0x67, 0x69, 0x74, 0x6c, 0x61, 0x62,
-	0x2e, 0x63, 0x72, 0x6a, 0x61, 0x37, 0x32, 0x2e, 0x72, 0x75, 0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e,
-	0x67, 0x2f, 0x32, 0x30, 0x32, 0x35, 0x2f, 0x73, 0x70, 0x72, 0x69, 0x6e, 0x67, 0x2f, 0x63, 0x6f,
-	0x75, 0x72, 0x73, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2f, 0x67, 0x6f,
-	0x32, 0x2f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x2d, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2f,
-	0x70, 0x72, 0x69, 0x63, 0x65, 0x2d, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-})
+const file_price_tracker_proto_rawDesc = "" +
+	"\n" +
+	"\x13price_tracker.proto\x12\rprice_tracker\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\xe1\a\n" +
+	"\fItemResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x122\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1a.price_tracker.StockStatusR\x06status\x12\x0e\n" +
+	"\x02id\x18\x06 \x01(\tR\x02id\x12\x16\n" +
+	"\x06paused\x18\a \x01(\bR\x06paused\x122\n" +
+	"\x06source\x18\b \x01(\x0e2\x1a.price_tracker.PriceSourceR\x06source\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\x125\n" +
+	"\vstart_price\x18\n" +
+	" \x01(\v2\x14.price_tracker.MoneyR\n" +
+	"startPrice\x129\n" +
+	"\rcurrent_price\x18\v \x01(\v2\x14.price_tracker.MoneyR\fcurrentPrice\x123\n" +
+	"\n" +
+	"diff_price\x18\f \x01(\v2\x14.price_tracker.MoneyR\tdiffPrice\x12;\n" +
+	"\x0eoriginal_price\x18\r \x01(\v2\x14.price_tracker.MoneyR\roriginalPrice\x12)\n" +
+	"\x10discount_percent\x18\x0e \x01(\x05R\x0fdiscountPercent\x12\x14\n" +
+	"\x05brand\x18\x0f \x01(\tR\x05brand\x12\x16\n" +
+	"\x06seller\x18\x10 \x01(\tR\x06seller\x12\x16\n" +
+	"\x06rating\x18\x11 \x01(\x02R\x06rating\x12!\n" +
+	"\freview_count\x18\x12 \x01(\x05R\vreviewCount\x12\x1a\n" +
+	"\bquantity\x18\x13 \x01(\x05R\bquantity\x12\x1b\n" +
+	"\timage_url\x18\x14 \x01(\tR\bimageUrl\x12H\n" +
+	"\x15converted_start_price\x18\x15 \x01(\v2\x14.price_tracker.MoneyR\x13convertedStartPrice\x12L\n" +
+	"\x17converted_current_price\x18\x16 \x01(\v2\x14.price_tracker.MoneyR\x15convertedCurrentPrice\x12F\n" +
+	"\x14converted_diff_price\x18\x17 \x01(\v2\x14.price_tracker.MoneyR\x12convertedDiffPrice\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x18 \x01(\tR\tvariantId\x12!\n" +
+	"\fvariant_name\x18\x19 \x01(\tR\vvariantName\x122\n" +
+	"\bvariants\x18\x1a \x03(\v2\x16.price_tracker.VariantR\bvariantsJ\x04\b\x02\x10\x03J\x04\b\x03\x10\x04J\x04\b\x04\x10\x05\"\xe6\x01\n" +
+	"\aVariant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12*\n" +
+	"\x05price\x18\x03 \x01(\v2\x14.price_tracker.MoneyR\x05price\x12;\n" +
+	"\x0eoriginal_price\x18\x04 \x01(\v2\x14.price_tracker.MoneyR\roriginalPrice\x122\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1a.price_tracker.StockStatusR\x06status\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x05R\bquantity\"\\\n" +
+	"\x0eGetItemRequest\x12\x12\n" +
+	"\x04link\x18\x01 \x01(\tR\x04link\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x03 \x01(\tR\tvariantId\"B\n" +
+	"\x0fGetItemResponse\x12/\n" +
+	"\x04item\x18\x01 \x01(\v2\x1b.price_tracker.ItemResponseR\x04item\"\x81\x01\n" +
+	"\x12GetAllItemsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06cached\x18\x02 \x01(\bR\x06cached\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"p\n" +
+	"\x13GetAllItemsResponse\x121\n" +
+	"\x05items\x18\x01 \x03(\v2\x1b.price_tracker.ItemResponseR\x05items\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xad\x02\n" +
+	"\x16GetPriceHistoryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04link\x18\x02 \x01(\tR\x04link\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12-\n" +
+	"\x04step\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x04step\x12\x1d\n" +
+	"\n" +
+	"max_points\x18\x06 \x01(\rR\tmaxPoints\x12<\n" +
+	"\vaggregation\x18\a \x01(\x0e2\x1a.price_tracker.AggregationR\vaggregation\"\xa2\x01\n" +
+	"\n" +
+	"PricePoint\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x122\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1a.price_tracker.StockStatusR\x06status\x12*\n" +
+	"\x05price\x18\x04 \x01(\v2\x14.price_tracker.MoneyR\x05priceJ\x04\b\x02\x10\x03\"L\n" +
+	"\x17GetPriceHistoryResponse\x121\n" +
+	"\x06points\x18\x01 \x03(\v2\x19.price_tracker.PricePointR\x06points\"<\n" +
+	"\x11RemoveItemRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\x14\n" +
+	"\x12RemoveItemResponse\"\xba\x01\n" +
+	"\x19UpdateSubscriptionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x1b\n" +
+	"\x06paused\x18\x04 \x01(\bH\x01R\x06paused\x88\x01\x01\x12*\n" +
+	"\x11reset_start_price\x18\x05 \x01(\bR\x0fresetStartPriceB\a\n" +
+	"\x05_nameB\t\n" +
+	"\a_paused\"M\n" +
+	"\x1aUpdateSubscriptionResponse\x12/\n" +
+	"\x04item\x18\x01 \x01(\v2\x1b.price_tracker.ItemResponseR\x04item\"\xff\x01\n" +
+	"\tAlertRule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12,\n" +
+	"\x04kind\x18\x03 \x01(\x0e2\x18.price_tracker.AlertKindR\x04kind\x12!\n" +
+	"\fdrop_percent\x18\x05 \x01(\x02R\vdropPercent\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
+	"\ftarget_price\x18\a \x01(\v2\x14.price_tracker.MoneyR\vtargetPriceJ\x04\b\x04\x10\x05\"\xda\x01\n" +
+	"\x16CreateAlertRuleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\x12,\n" +
+	"\x04kind\x18\x03 \x01(\x0e2\x18.price_tracker.AlertKindR\x04kind\x12!\n" +
+	"\fdrop_percent\x18\x05 \x01(\x02R\vdropPercent\x127\n" +
+	"\ftarget_price\x18\x06 \x01(\v2\x14.price_tracker.MoneyR\vtargetPriceJ\x04\b\x04\x10\x05\"G\n" +
+	"\x17CreateAlertRuleResponse\x12,\n" +
+	"\x04rule\x18\x01 \x01(\v2\x18.price_tracker.AlertRuleR\x04rule\"I\n" +
+	"\x15ListAlertRulesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\"H\n" +
+	"\x16ListAlertRulesResponse\x12.\n" +
+	"\x05rules\x18\x01 \x03(\v2\x18.price_tracker.AlertRuleR\x05rules\"A\n" +
+	"\x16DeleteAlertRuleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\x19\n" +
+	"\x17DeleteAlertRuleResponse\"\xbb\x02\n" +
+	"\n" +
+	"AlertEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04link\x18\x03 \x01(\tR\x04link\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12,\n" +
+	"\x04kind\x18\x05 \x01(\x0e2\x18.price_tracker.AlertKindR\x04kind\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12*\n" +
+	"\x05price\x18\t \x01(\v2\x14.price_tracker.MoneyR\x05price\x12;\n" +
+	"\x0eprevious_price\x18\n" +
+	" \x01(\v2\x14.price_tracker.MoneyR\rpreviousPriceJ\x04\b\x06\x10\aJ\x04\b\a\x10\b\",\n" +
+	"\x14PendingAlertsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\rR\x05limit\"J\n" +
+	"\x15PendingAlertsResponse\x121\n" +
+	"\x06events\x18\x01 \x03(\v2\x19.price_tracker.AlertEventR\x06events\"$\n" +
+	"\x10AckAlertsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"\x13\n" +
+	"\x11AckAlertsResponse\"-\n" +
+	"\x19ReplayOutboxEventsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"8\n" +
+	"\x1aReplayOutboxEventsResponse\x12\x1a\n" +
+	"\breplayed\x18\x01 \x01(\x03R\breplayed\"\x96\x01\n" +
+	"\aWebhook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x03 \x01(\tR\x06secret\x12\x16\n" +
+	"\x06active\x18\x04 \x01(\bR\x06active\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"A\n" +
+	"\x14CreateWebhookRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"I\n" +
+	"\x15CreateWebhookResponse\x120\n" +
+	"\awebhook\x18\x01 \x01(\v2\x16.price_tracker.WebhookR\awebhook\".\n" +
+	"\x13ListWebhooksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"J\n" +
+	"\x14ListWebhooksResponse\x122\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\x16.price_tracker.WebhookR\bwebhooks\"?\n" +
+	"\x14DeleteWebhookRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\x17\n" +
+	"\x15DeleteWebhookResponse\"\xe3\x02\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12\x14\n" +
+	"\x05event\x18\x03 \x01(\tR\x05event\x125\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1d.price_tracker.DeliveryStatusR\x06status\x12\x1a\n" +
+	"\battempts\x18\x05 \x01(\x05R\battempts\x12#\n" +
+	"\rresponse_code\x18\x06 \x01(\x05R\fresponseCode\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\a \x01(\tR\tlastError\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fdelivered_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vdeliveredAt\"l\n" +
+	"\x1cListWebhookDeliveriesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\tR\twebhookId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\rR\x05limit\"_\n" +
+	"\x1dListWebhookDeliveriesResponse\x12>\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x1e.price_tracker.WebhookDeliveryR\n" +
+	"deliveries\"\xc3\x01\n" +
+	"\x0eUpstreamHealth\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x121\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x1b.price_tracker.BreakerStateR\x05state\x121\n" +
+	"\x14consecutive_failures\x18\x03 \x01(\x05R\x13consecutiveFailures\x127\n" +
+	"\topened_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bopenedAt\"\x0f\n" +
+	"\rHealthRequest\"M\n" +
+	"\x0eHealthResponse\x12;\n" +
+	"\tupstreams\x18\x01 \x03(\v2\x1d.price_tracker.UpstreamHealthR\tupstreams\"P\n" +
+	"\x19SetDisplayCurrencyRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"8\n" +
+	"\x1aSetDisplayCurrencyResponse\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency*a\n" +
+	"\vStockStatus\x12\x18\n" +
+	"\x14STOCK_STATUS_UNKNOWN\x10\x00\x12\x19\n" +
+	"\x15STOCK_STATUS_IN_STOCK\x10\x01\x12\x1d\n" +
+	"\x19STOCK_STATUS_OUT_OF_STOCK\x10\x02*=\n" +
+	"\vPriceSource\x12\x15\n" +
+	"\x11PRICE_SOURCE_LIVE\x10\x00\x12\x17\n" +
+	"\x13PRICE_SOURCE_CACHED\x10\x01*b\n" +
+	"\vAggregation\x12\x14\n" +
+	"\x10AGGREGATION_LAST\x10\x00\x12\x13\n" +
+	"\x0fAGGREGATION_MIN\x10\x01\x12\x13\n" +
+	"\x0fAGGREGATION_MAX\x10\x02\x12\x13\n" +
+	"\x0fAGGREGATION_AVG\x10\x03*\x98\x01\n" +
+	"\tAlertKind\x12\x1a\n" +
+	"\x16ALERT_KIND_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17ALERT_KIND_TARGET_PRICE\x10\x01\x12\x1b\n" +
+	"\x17ALERT_KIND_PERCENT_DROP\x10\x02\x12\x17\n" +
+	"\x13ALERT_KIND_ANY_DROP\x10\x03\x12\x1c\n" +
+	"\x18ALERT_KIND_BACK_IN_STOCK\x10\x04*h\n" +
+	"\x0eDeliveryStatus\x12\x1b\n" +
+	"\x17DELIVERY_STATUS_PENDING\x10\x00\x12\x1d\n" +
+	"\x19DELIVERY_STATUS_DELIVERED\x10\x01\x12\x1a\n" +
+	"\x16DELIVERY_STATUS_FAILED\x10\x02*]\n" +
+	"\fBreakerState\x12\x18\n" +
+	"\x14BREAKER_STATE_CLOSED\x10\x00\x12\x16\n" +
+	"\x12BREAKER_STATE_OPEN\x10\x01\x12\x1b\n" +
+	"\x17BREAKER_STATE_HALF_OPEN\x10\x022\xba\f\n" +
+	"\aScraper\x12H\n" +
+	"\aGetItem\x12\x1d.price_tracker.GetItemRequest\x1a\x1e.price_tracker.GetItemResponse\x12T\n" +
+	"\vGetAllItems\x12!.price_tracker.GetAllItemsRequest\x1a\".price_tracker.GetAllItemsResponse\x12`\n" +
+	"\x0fGetPriceHistory\x12%.price_tracker.GetPriceHistoryRequest\x1a&.price_tracker.GetPriceHistoryResponse\x12Q\n" +
+	"\n" +
+	"RemoveItem\x12 .price_tracker.RemoveItemRequest\x1a!.price_tracker.RemoveItemResponse\x12i\n" +
+	"\x12UpdateSubscription\x12(.price_tracker.UpdateSubscriptionRequest\x1a).price_tracker.UpdateSubscriptionResponse\x12`\n" +
+	"\x0fCreateAlertRule\x12%.price_tracker.CreateAlertRuleRequest\x1a&.price_tracker.CreateAlertRuleResponse\x12]\n" +
+	"\x0eListAlertRules\x12$.price_tracker.ListAlertRulesRequest\x1a%.price_tracker.ListAlertRulesResponse\x12`\n" +
+	"\x0fDeleteAlertRule\x12%.price_tracker.DeleteAlertRuleRequest\x1a&.price_tracker.DeleteAlertRuleResponse\x12Z\n" +
+	"\rPendingAlerts\x12#.price_tracker.PendingAlertsRequest\x1a$.price_tracker.PendingAlertsResponse\x12N\n" +
+	"\tAckAlerts\x12\x1f.price_tracker.AckAlertsRequest\x1a .price_tracker.AckAlertsResponse\x12i\n" +
+	"\x12ReplayOutboxEvents\x12(.price_tracker.ReplayOutboxEventsRequest\x1a).price_tracker.ReplayOutboxEventsResponse\x12Z\n" +
+	"\rCreateWebhook\x12#.price_tracker.CreateWebhookRequest\x1a$.price_tracker.CreateWebhookResponse\x12W\n" +
+	"\fListWebhooks\x12\".price_tracker.ListWebhooksRequest\x1a#.price_tracker.ListWebhooksResponse\x12Z\n" +
+	"\rDeleteWebhook\x12#.price_tracker.DeleteWebhookRequest\x1a$.price_tracker.DeleteWebhookResponse\x12r\n" +
+	"\x15ListWebhookDeliveries\x12+.price_tracker.ListWebhookDeliveriesRequest\x1a,.price_tracker.ListWebhookDeliveriesResponse\x12E\n" +
+	"\x06Health\x12\x1c.price_tracker.HealthRequest\x1a\x1d.price_tracker.HealthResponse\x12i\n" +
+	"\x12SetDisplayCurrency\x12(.price_tracker.SetDisplayCurrencyRequest\x1a).price_tracker.SetDisplayCurrencyResponseBXZVgitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price-monitoringb\x06proto3"
 
 var (
 	file_price_tracker_proto_rawDescOnce sync.Once
@@ -334,26 +3159,147 @@ func file_price_tracker_proto_rawDescGZIP() []byte {
 	return file_price_tracker_proto_rawDescData
 }
 
-var file_price_tracker_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_price_tracker_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_price_tracker_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_price_tracker_proto_goTypes = []any{
-	(*ItemResponse)(nil),        // 0: price_tracker.ItemResponse
-	(*GetItemRequest)(nil),      // 1: price_tracker.GetItemRequest
-	(*GetItemResponse)(nil),     // 2: price_tracker.GetItemResponse
-	(*GetAllItemsRequest)(nil),  // 3: price_tracker.GetAllItemsRequest
-	(*GetAllItemsResponse)(nil), // 4: price_tracker.GetAllItemsResponse
+	(StockStatus)(0),                      // 0: price_tracker.StockStatus
+	(PriceSource)(0),                      // 1: price_tracker.PriceSource
+	(Aggregation)(0),                      // 2: price_tracker.Aggregation
+	(AlertKind)(0),                        // 3: price_tracker.AlertKind
+	(DeliveryStatus)(0),                   // 4: price_tracker.DeliveryStatus
+	(BreakerState)(0),                     // 5: price_tracker.BreakerState
+	(*Money)(nil),                         // 6: price_tracker.Money
+	(*ItemResponse)(nil),                  // 7: price_tracker.ItemResponse
+	(*Variant)(nil),                       // 8: price_tracker.Variant
+	(*GetItemRequest)(nil),                // 9: price_tracker.GetItemRequest
+	(*GetItemResponse)(nil),               // 10: price_tracker.GetItemResponse
+	(*GetAllItemsRequest)(nil),            // 11: price_tracker.GetAllItemsRequest
+	(*GetAllItemsResponse)(nil),           // 12: price_tracker.GetAllItemsResponse
+	(*GetPriceHistoryRequest)(nil),        // 13: price_tracker.GetPriceHistoryRequest
+	(*PricePoint)(nil),                    // 14: price_tracker.PricePoint
+	(*GetPriceHistoryResponse)(nil),       // 15: price_tracker.GetPriceHistoryResponse
+	(*RemoveItemRequest)(nil),             // 16: price_tracker.RemoveItemRequest
+	(*RemoveItemResponse)(nil),            // 17: price_tracker.RemoveItemResponse
+	(*UpdateSubscriptionRequest)(nil),     // 18: price_tracker.UpdateSubscriptionRequest
+	(*UpdateSubscriptionResponse)(nil),    // 19: price_tracker.UpdateSubscriptionResponse
+	(*AlertRule)(nil),                     // 20: price_tracker.AlertRule
+	(*CreateAlertRuleRequest)(nil),        // 21: price_tracker.CreateAlertRuleRequest
+	(*CreateAlertRuleResponse)(nil),       // 22: price_tracker.CreateAlertRuleResponse
+	(*ListAlertRulesRequest)(nil),         // 23: price_tracker.ListAlertRulesRequest
+	(*ListAlertRulesResponse)(nil),        // 24: price_tracker.ListAlertRulesResponse
+	(*DeleteAlertRuleRequest)(nil),        // 25: price_tracker.DeleteAlertRuleRequest
+	(*DeleteAlertRuleResponse)(nil),       // 26: price_tracker.DeleteAlertRuleResponse
+	(*AlertEvent)(nil),                    // 27: price_tracker.AlertEvent
+	(*PendingAlertsRequest)(nil),          // 28: price_tracker.PendingAlertsRequest
+	(*PendingAlertsResponse)(nil),         // 29: price_tracker.PendingAlertsResponse
+	(*AckAlertsRequest)(nil),              // 30: price_tracker.AckAlertsRequest
+	(*AckAlertsResponse)(nil),             // 31: price_tracker.AckAlertsResponse
+	(*ReplayOutboxEventsRequest)(nil),     // 32: price_tracker.ReplayOutboxEventsRequest
+	(*ReplayOutboxEventsResponse)(nil),    // 33: price_tracker.ReplayOutboxEventsResponse
+	(*Webhook)(nil),                       // 34: price_tracker.Webhook
+	(*CreateWebhookRequest)(nil),          // 35: price_tracker.CreateWebhookRequest
+	(*CreateWebhookResponse)(nil),         // 36: price_tracker.CreateWebhookResponse
+	(*ListWebhooksRequest)(nil),           // 37: price_tracker.ListWebhooksRequest
+	(*ListWebhooksResponse)(nil),          // 38: price_tracker.ListWebhooksResponse
+	(*DeleteWebhookRequest)(nil),          // 39: price_tracker.DeleteWebhookRequest
+	(*DeleteWebhookResponse)(nil),         // 40: price_tracker.DeleteWebhookResponse
+	(*WebhookDelivery)(nil),               // 41: price_tracker.WebhookDelivery
+	(*ListWebhookDeliveriesRequest)(nil),  // 42: price_tracker.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil), // 43: price_tracker.ListWebhookDeliveriesResponse
+	(*UpstreamHealth)(nil),                // 44: price_tracker.UpstreamHealth
+	(*HealthRequest)(nil),                 // 45: price_tracker.HealthRequest
+	(*HealthResponse)(nil),                // 46: price_tracker.HealthResponse
+	(*SetDisplayCurrencyRequest)(nil),     // 47: price_tracker.SetDisplayCurrencyRequest
+	(*SetDisplayCurrencyResponse)(nil),    // 48: price_tracker.SetDisplayCurrencyResponse
+	(*timestamppb.Timestamp)(nil),         // 49: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),           // 50: google.protobuf.Duration
 }
 var file_price_tracker_proto_depIdxs = []int32{
-	0, // 0: price_tracker.GetItemResponse.item:type_name -> price_tracker.ItemResponse
-	0, // 1: price_tracker.GetAllItemsResponse.items:type_name -> price_tracker.ItemResponse
-	1, // 2: price_tracker.Scraper.GetItem:input_type -> price_tracker.GetItemRequest
-	3, // 3: price_tracker.Scraper.GetAllItems:input_type -> price_tracker.GetAllItemsRequest
-	2, // 4: price_tracker.Scraper.GetItem:output_type -> price_tracker.GetItemResponse
-	4, // 5: price_tracker.Scraper.GetAllItems:output_type -> price_tracker.GetAllItemsResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: price_tracker.ItemResponse.status:type_name -> price_tracker.StockStatus
+	1,  // 1: price_tracker.ItemResponse.source:type_name -> price_tracker.PriceSource
+	6,  // 2: price_tracker.ItemResponse.start_price:type_name -> price_tracker.Money
+	6,  // 3: price_tracker.ItemResponse.current_price:type_name -> price_tracker.Money
+	6,  // 4: price_tracker.ItemResponse.diff_price:type_name -> price_tracker.Money
+	6,  // 5: price_tracker.ItemResponse.original_price:type_name -> price_tracker.Money
+	6,  // 6: price_tracker.ItemResponse.converted_start_price:type_name -> price_tracker.Money
+	6,  // 7: price_tracker.ItemResponse.converted_current_price:type_name -> price_tracker.Money
+	6,  // 8: price_tracker.ItemResponse.converted_diff_price:type_name -> price_tracker.Money
+	8,  // 9: price_tracker.ItemResponse.variants:type_name -> price_tracker.Variant
+	6,  // 10: price_tracker.Variant.price:type_name -> price_tracker.Money
+	6,  // 11: price_tracker.Variant.original_price:type_name -> price_tracker.Money
+	0,  // 12: price_tracker.Variant.status:type_name -> price_tracker.StockStatus
+	7,  // 13: price_tracker.GetItemResponse.item:type_name -> price_tracker.ItemResponse
+	7,  // 14: price_tracker.GetAllItemsResponse.items:type_name -> price_tracker.ItemResponse
+	49, // 15: price_tracker.GetPriceHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	49, // 16: price_tracker.GetPriceHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	50, // 17: price_tracker.GetPriceHistoryRequest.step:type_name -> google.protobuf.Duration
+	2,  // 18: price_tracker.GetPriceHistoryRequest.aggregation:type_name -> price_tracker.Aggregation
+	49, // 19: price_tracker.PricePoint.time:type_name -> google.protobuf.Timestamp
+	0,  // 20: price_tracker.PricePoint.status:type_name -> price_tracker.StockStatus
+	6,  // 21: price_tracker.PricePoint.price:type_name -> price_tracker.Money
+	14, // 22: price_tracker.GetPriceHistoryResponse.points:type_name -> price_tracker.PricePoint
+	7,  // 23: price_tracker.UpdateSubscriptionResponse.item:type_name -> price_tracker.ItemResponse
+	3,  // 24: price_tracker.AlertRule.kind:type_name -> price_tracker.AlertKind
+	49, // 25: price_tracker.AlertRule.created_at:type_name -> google.protobuf.Timestamp
+	6,  // 26: price_tracker.AlertRule.target_price:type_name -> price_tracker.Money
+	3,  // 27: price_tracker.CreateAlertRuleRequest.kind:type_name -> price_tracker.AlertKind
+	6,  // 28: price_tracker.CreateAlertRuleRequest.target_price:type_name -> price_tracker.Money
+	20, // 29: price_tracker.CreateAlertRuleResponse.rule:type_name -> price_tracker.AlertRule
+	20, // 30: price_tracker.ListAlertRulesResponse.rules:type_name -> price_tracker.AlertRule
+	3,  // 31: price_tracker.AlertEvent.kind:type_name -> price_tracker.AlertKind
+	49, // 32: price_tracker.AlertEvent.created_at:type_name -> google.protobuf.Timestamp
+	6,  // 33: price_tracker.AlertEvent.price:type_name -> price_tracker.Money
+	6,  // 34: price_tracker.AlertEvent.previous_price:type_name -> price_tracker.Money
+	27, // 35: price_tracker.PendingAlertsResponse.events:type_name -> price_tracker.AlertEvent
+	49, // 36: price_tracker.Webhook.created_at:type_name -> google.protobuf.Timestamp
+	34, // 37: price_tracker.CreateWebhookResponse.webhook:type_name -> price_tracker.Webhook
+	34, // 38: price_tracker.ListWebhooksResponse.webhooks:type_name -> price_tracker.Webhook
+	4,  // 39: price_tracker.WebhookDelivery.status:type_name -> price_tracker.DeliveryStatus
+	49, // 40: price_tracker.WebhookDelivery.created_at:type_name -> google.protobuf.Timestamp
+	49, // 41: price_tracker.WebhookDelivery.delivered_at:type_name -> google.protobuf.Timestamp
+	41, // 42: price_tracker.ListWebhookDeliveriesResponse.deliveries:type_name -> price_tracker.WebhookDelivery
+	5,  // 43: price_tracker.UpstreamHealth.state:type_name -> price_tracker.BreakerState
+	49, // 44: price_tracker.UpstreamHealth.opened_at:type_name -> google.protobuf.Timestamp
+	44, // 45: price_tracker.HealthResponse.upstreams:type_name -> price_tracker.UpstreamHealth
+	9,  // 46: price_tracker.Scraper.GetItem:input_type -> price_tracker.GetItemRequest
+	11, // 47: price_tracker.Scraper.GetAllItems:input_type -> price_tracker.GetAllItemsRequest
+	13, // 48: price_tracker.Scraper.GetPriceHistory:input_type -> price_tracker.GetPriceHistoryRequest
+	16, // 49: price_tracker.Scraper.RemoveItem:input_type -> price_tracker.RemoveItemRequest
+	18, // 50: price_tracker.Scraper.UpdateSubscription:input_type -> price_tracker.UpdateSubscriptionRequest
+	21, // 51: price_tracker.Scraper.CreateAlertRule:input_type -> price_tracker.CreateAlertRuleRequest
+	23, // 52: price_tracker.Scraper.ListAlertRules:input_type -> price_tracker.ListAlertRulesRequest
+	25, // 53: price_tracker.Scraper.DeleteAlertRule:input_type -> price_tracker.DeleteAlertRuleRequest
+	28, // 54: price_tracker.Scraper.PendingAlerts:input_type -> price_tracker.PendingAlertsRequest
+	30, // 55: price_tracker.Scraper.AckAlerts:input_type -> price_tracker.AckAlertsRequest
+	32, // 56: price_tracker.Scraper.ReplayOutboxEvents:input_type -> price_tracker.ReplayOutboxEventsRequest
+	35, // 57: price_tracker.Scraper.CreateWebhook:input_type -> price_tracker.CreateWebhookRequest
+	37, // 58: price_tracker.Scraper.ListWebhooks:input_type -> price_tracker.ListWebhooksRequest
+	39, // 59: price_tracker.Scraper.DeleteWebhook:input_type -> price_tracker.DeleteWebhookRequest
+	42, // 60: price_tracker.Scraper.ListWebhookDeliveries:input_type -> price_tracker.ListWebhookDeliveriesRequest
+	45, // 61: price_tracker.Scraper.Health:input_type -> price_tracker.HealthRequest
+	47, // 62: price_tracker.Scraper.SetDisplayCurrency:input_type -> price_tracker.SetDisplayCurrencyRequest
+	10, // 63: price_tracker.Scraper.GetItem:output_type -> price_tracker.GetItemResponse
+	12, // 64: price_tracker.Scraper.GetAllItems:output_type -> price_tracker.GetAllItemsResponse
+	15, // 65: price_tracker.Scraper.GetPriceHistory:output_type -> price_tracker.GetPriceHistoryResponse
+	17, // 66: price_tracker.Scraper.RemoveItem:output_type -> price_tracker.RemoveItemResponse
+	19, // 67: price_tracker.Scraper.UpdateSubscription:output_type -> price_tracker.UpdateSubscriptionResponse
+	22, // 68: price_tracker.Scraper.CreateAlertRule:output_type -> price_tracker.CreateAlertRuleResponse
+	24, // 69: price_tracker.Scraper.ListAlertRules:output_type -> price_tracker.ListAlertRulesResponse
+	26, // 70: price_tracker.Scraper.DeleteAlertRule:output_type -> price_tracker.DeleteAlertRuleResponse
+	29, // 71: price_tracker.Scraper.PendingAlerts:output_type -> price_tracker.PendingAlertsResponse
+	31, // 72: price_tracker.Scraper.AckAlerts:output_type -> price_tracker.AckAlertsResponse
+	33, // 73: price_tracker.Scraper.ReplayOutboxEvents:output_type -> price_tracker.ReplayOutboxEventsResponse
+	36, // 74: price_tracker.Scraper.CreateWebhook:output_type -> price_tracker.CreateWebhookResponse
+	38, // 75: price_tracker.Scraper.ListWebhooks:output_type -> price_tracker.ListWebhooksResponse
+	40, // 76: price_tracker.Scraper.DeleteWebhook:output_type -> price_tracker.DeleteWebhookResponse
+	43, // 77: price_tracker.Scraper.ListWebhookDeliveries:output_type -> price_tracker.ListWebhookDeliveriesResponse
+	46, // 78: price_tracker.Scraper.Health:output_type -> price_tracker.HealthResponse
+	48, // 79: price_tracker.Scraper.SetDisplayCurrency:output_type -> price_tracker.SetDisplayCurrencyResponse
+	63, // [63:80] is the sub-list for method output_type
+	46, // [46:63] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_price_tracker_proto_init() }
@@ -361,18 +3307,20 @@ func file_price_tracker_proto_init() {
 	if File_price_tracker_proto != nil {
 		return
 	}
+	file_price_tracker_proto_msgTypes[12].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_price_tracker_proto_rawDesc), len(file_price_tracker_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      6,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_price_tracker_proto_goTypes,
 		DependencyIndexes: file_price_tracker_proto_depIdxs,
+		EnumInfos:         file_price_tracker_proto_enumTypes,
 		MessageInfos:      file_price_tracker_proto_msgTypes,
 	}.Build()
 	File_price_tracker_proto = out.File
@@ -58,13 +58,14 @@ func InterceptorLogger(l *slog.Logger) grpclog.Logger {
 	})
 }
 
-func (c *Client) Register(ctx context.Context, telegramLogin, login, password string) (string, error) {
+func (c *Client) Register(ctx context.Context, telegramLogin, login, password string, chatID int64) (string, error) {
 	const op = "grpc.auth.Register"
 
 	resp, err := c.api.Register(ctx, &authpb.RegisterRequest{
 		TelegramLogin: telegramLogin,
 		Login:         login,
 		Password:      password,
+		ChatId:        chatID,
 	})
 
 	if err != nil {
@@ -74,13 +75,14 @@ func (c *Client) Register(ctx context.Context, telegramLogin, login, password st
 	return resp.GetUserId(), nil
 }
 
-func (c *Client) Login(ctx context.Context, telegramLogin, login, password string) (string, error) {
+func (c *Client) Login(ctx context.Context, telegramLogin, login, password string, chatID int64) (string, error) {
 	const op = "grpc.auth.Login"
 
 	resp, err := c.api.Login(ctx, &authpb.LoginRequest{
 		TelegramLogin: telegramLogin,
 		Login:         login,
 		Password:      password,
+		ChatId:        chatID,
 	})
 
 	if err != nil {
@@ -117,3 +119,17 @@ func (c *Client) Logout(ctx context.Context, telegramLogin string) error {
 
 	return nil
 }
+
+func (c *Client) ChatID(ctx context.Context, userID string) (int64, error) {
+	const op = "grpc.auth.ChatID"
+
+	resp, err := c.api.ChatID(ctx, &authpb.ChatIDRequest{
+		UserId: userID,
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.GetChatId(), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	grpclog "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
@@ -13,14 +14,23 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// adminTokenHeader is the metadata key of the tracker admin token.
+const adminTokenHeader = "x-admin-token"
+
 type Client struct {
-	api trackerpb.ScraperClient
-	log *slog.Logger
+	api        trackerpb.ScraperClient
+	log        *slog.Logger
+	adminToken string
 }
 
-func New(log *slog.Logger, addr string, timeout time.Duration, retriesCount int) (*Client, error) {
+// New connects to the tracker at addr. adminToken authorizes the admin RPCs
+// of the alert delivery, it may be empty for clients that do not call them.
+func New(log *slog.Logger, addr string, timeout time.Duration, retriesCount int, adminToken string) (*Client, error) {
 	const op = "grpc.tracker.New"
 
 	retryOpts := []grpcretry.CallOption{
@@ -47,44 +57,49 @@ func New(log *slog.Logger, addr string, timeout time.Duration, retriesCount int)
 	grpcClient := trackerpb.NewScraperClient(cc)
 
 	return &Client{
-		api: grpcClient,
-		log: log,
+		api:        grpcClient,
+		log:        log,
+		adminToken: adminToken,
 	}, nil
 }
 
+// admin adds the admin token to the metadata of an admin RPC.
+func (c *Client) admin(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, adminTokenHeader, c.adminToken)
+}
+
 func InterceptorLogger(l *slog.Logger) grpclog.Logger {
 	return grpclog.LoggerFunc(func(ctx context.Context, lvl grpclog.Level, msg string, fields ...any) {
 		l.Log(ctx, slog.Level(lvl), msg, fields...)
 	})
 }
 
-func (c *Client) GetItem(ctx context.Context, userID, link string) (models.Item, error) {
+// GetItem scrapes link and starts tracking it. A non-empty variantID pins
+// the item to that variant of the product.
+func (c *Client) GetItem(ctx context.Context, userID, link, variantID string) (models.Item, error) {
 	const op = "grpc.tracker.GetItem"
 
 	resp, err := c.api.GetItem(ctx, &trackerpb.GetItemRequest{
-		Link:   link,
-		UserId: userID,
+		Link:      link,
+		UserId:    userID,
+		VariantId: variantID,
 	})
 
 	if err != nil {
 		return models.Item{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	item := models.Item{
-		Name:            resp.GetItem().GetName(),
-		StartPrice:      resp.GetItem().GetStartPrice(),
-		CurrentPrice:    resp.GetItem().GetCurrentPrice(),
-		DifferencePrice: resp.GetItem().GetDiffPrice(),
-	}
-
-	return item, nil
+	return itemFromProto(resp.GetItem()), nil
 }
 
-func (c *Client) GetAllItems(ctx context.Context, userID string) ([]*models.Item, error) {
+// GetAllItems returns the items of the user. With cached the tracker returns
+// the last observed prices instead of scraping the links.
+func (c *Client) GetAllItems(ctx context.Context, userID string, cached bool) ([]*models.Item, error) {
 	const op = "grpc.tracker.GetAllItems"
 
 	resp, err := c.api.GetAllItems(ctx, &trackerpb.GetAllItemsRequest{
 		UserId: userID,
+		Cached: cached,
 	})
 
 	if err != nil {
@@ -92,14 +107,328 @@ func (c *Client) GetAllItems(ctx context.Context, userID string) ([]*models.Item
 	}
 
 	items := make([]*models.Item, len(resp.GetItems()))
-	for i, item := range resp.GetItems() {
-		items[i] = &models.Item{
-			Name:            item.GetName(),
-			StartPrice:      item.GetStartPrice(),
-			CurrentPrice:    item.GetCurrentPrice(),
-			DifferencePrice: item.GetDiffPrice(),
-		}
+	for i, resp := range resp.GetItems() {
+		item := itemFromProto(resp)
+		items[i] = &item
 	}
 
 	return items, nil
 }
+
+func (c *Client) GetPriceHistory(ctx context.Context, userID, link string, query models.PriceHistoryQuery) ([]models.PricePoint, error) {
+	const op = "grpc.tracker.GetPriceHistory"
+
+	req := &trackerpb.GetPriceHistoryRequest{
+		UserId:      userID,
+		Link:        link,
+		MaxPoints:   query.MaxPoints,
+		Aggregation: trackerpb.Aggregation(trackerpb.Aggregation_value["AGGREGATION_"+strings.ToUpper(query.Aggregation)]),
+	}
+	if !query.From.IsZero() {
+		req.From = timestamppb.New(query.From)
+	}
+	if !query.To.IsZero() {
+		req.To = timestamppb.New(query.To)
+	}
+	if query.Step > 0 {
+		req.Step = durationpb.New(query.Step)
+	}
+
+	resp, err := c.api.GetPriceHistory(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	points := make([]models.PricePoint, len(resp.GetPoints()))
+	for i, point := range resp.GetPoints() {
+		points[i] = models.PricePoint{
+			Time:   point.GetTime().AsTime(),
+			Price:  moneyFromProto(point.GetPrice()),
+			Status: stockStatus(point.GetStatus()),
+		}
+	}
+
+	return points, nil
+}
+
+func (c *Client) RemoveItem(ctx context.Context, userID, id string) error {
+	const op = "grpc.tracker.RemoveItem"
+
+	_, err := c.api.RemoveItem(ctx, &trackerpb.RemoveItemRequest{
+		UserId: userID,
+		Id:     id,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *Client) UpdateSubscription(ctx context.Context, userID, id string, update models.SubscriptionUpdate) (models.Item, error) {
+	const op = "grpc.tracker.UpdateSubscription"
+
+	resp, err := c.api.UpdateSubscription(ctx, &trackerpb.UpdateSubscriptionRequest{
+		UserId:          userID,
+		Id:              id,
+		Name:            update.Name,
+		Paused:          update.Paused,
+		ResetStartPrice: update.ResetStartPrice,
+	})
+	if err != nil {
+		return models.Item{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return itemFromProto(resp.GetItem()), nil
+}
+
+func (c *Client) PendingAlerts(ctx context.Context, limit uint32) ([]models.Alert, error) {
+	const op = "grpc.tracker.PendingAlerts"
+
+	resp, err := c.api.PendingAlerts(c.admin(ctx), &trackerpb.PendingAlertsRequest{
+		Limit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	alerts := make([]models.Alert, len(resp.GetEvents()))
+	for i, event := range resp.GetEvents() {
+		alerts[i] = models.Alert{
+			ID:            event.GetId(),
+			UserID:        event.GetUserId(),
+			Link:          event.GetLink(),
+			Name:          event.GetName(),
+			Kind:          alertKind(event.GetKind()),
+			Price:         moneyFromProto(event.GetPrice()),
+			PreviousPrice: optionalMoney(event.GetPreviousPrice()),
+			CreatedAt:     event.GetCreatedAt().AsTime(),
+		}
+	}
+
+	return alerts, nil
+}
+
+func (c *Client) AckAlerts(ctx context.Context, ids []string) error {
+	const op = "grpc.tracker.AckAlerts"
+
+	_, err := c.api.AckAlerts(c.admin(ctx), &trackerpb.AckAlertsRequest{
+		Ids: ids,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *Client) CreateWebhook(ctx context.Context, userID, url string) (models.Webhook, error) {
+	const op = "grpc.tracker.CreateWebhook"
+
+	resp, err := c.api.CreateWebhook(ctx, &trackerpb.CreateWebhookRequest{
+		UserId: userID,
+		Url:    url,
+	})
+	if err != nil {
+		return models.Webhook{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhookFromProto(resp.GetWebhook()), nil
+}
+
+func (c *Client) ListWebhooks(ctx context.Context, userID string) ([]models.Webhook, error) {
+	const op = "grpc.tracker.ListWebhooks"
+
+	resp, err := c.api.ListWebhooks(ctx, &trackerpb.ListWebhooksRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	webhooks := make([]models.Webhook, len(resp.GetWebhooks()))
+	for i, webhook := range resp.GetWebhooks() {
+		webhooks[i] = webhookFromProto(webhook)
+	}
+
+	return webhooks, nil
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, userID, id string) error {
+	const op = "grpc.tracker.DeleteWebhook"
+
+	_, err := c.api.DeleteWebhook(ctx, &trackerpb.DeleteWebhookRequest{
+		UserId: userID,
+		Id:     id,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (c *Client) ListWebhookDeliveries(ctx context.Context, userID, webhookID string, limit uint32) ([]models.WebhookDelivery, error) {
+	const op = "grpc.tracker.ListWebhookDeliveries"
+
+	resp, err := c.api.ListWebhookDeliveries(ctx, &trackerpb.ListWebhookDeliveriesRequest{
+		UserId:    userID,
+		WebhookId: webhookID,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	deliveries := make([]models.WebhookDelivery, len(resp.GetDeliveries()))
+	for i, delivery := range resp.GetDeliveries() {
+		deliveries[i] = models.WebhookDelivery{
+			ID:           delivery.GetId(),
+			EventID:      delivery.GetEventId(),
+			Event:        delivery.GetEvent(),
+			Status:       deliveryStatus(delivery.GetStatus()),
+			Attempts:     delivery.GetAttempts(),
+			ResponseCode: delivery.GetResponseCode(),
+			LastError:    delivery.GetLastError(),
+			CreatedAt:    delivery.GetCreatedAt().AsTime(),
+		}
+		if delivery.DeliveredAt != nil {
+			deliveredAt := delivery.GetDeliveredAt().AsTime()
+			deliveries[i].DeliveredAt = &deliveredAt
+		}
+	}
+
+	return deliveries, nil
+}
+
+// SetDisplayCurrency sets the currency the user sees prices in and returns
+// it normalized.
+func (c *Client) SetDisplayCurrency(ctx context.Context, userID, currency string) (string, error) {
+	const op = "grpc.tracker.SetDisplayCurrency"
+
+	resp, err := c.api.SetDisplayCurrency(ctx, &trackerpb.SetDisplayCurrencyRequest{
+		UserId:   userID,
+		Currency: currency,
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp.GetCurrency(), nil
+}
+
+func itemFromProto(resp *trackerpb.ItemResponse) models.Item {
+	return models.Item{
+		ID:              resp.GetId(),
+		Name:            resp.GetName(),
+		StartPrice:      moneyFromProto(resp.GetStartPrice()),
+		CurrentPrice:    moneyFromProto(resp.GetCurrentPrice()),
+		DifferencePrice: optionalMoney(resp.GetDiffPrice()),
+		Status:          stockStatus(resp.GetStatus()),
+		Paused:          resp.GetPaused(),
+		Source:          priceSource(resp.GetSource()),
+		Error:           resp.GetError(),
+		OriginalPrice:   optionalMoney(resp.GetOriginalPrice()),
+		DiscountPercent: resp.GetDiscountPercent(),
+		Brand:           resp.GetBrand(),
+		Seller:          resp.GetSeller(),
+		Rating:          resp.GetRating(),
+		ReviewCount:     resp.GetReviewCount(),
+		Quantity:        resp.GetQuantity(),
+		ImageURL:        resp.GetImageUrl(),
+
+		ConvertedStartPrice:   optionalMoney(resp.GetConvertedStartPrice()),
+		ConvertedCurrentPrice: optionalMoney(resp.GetConvertedCurrentPrice()),
+		ConvertedDiffPrice:    optionalMoney(resp.GetConvertedDiffPrice()),
+
+		VariantID:   resp.GetVariantId(),
+		VariantName: resp.GetVariantName(),
+		Variants:    variantsFromProto(resp.GetVariants()),
+	}
+}
+
+func variantsFromProto(variants []*trackerpb.Variant) []models.Variant {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	result := make([]models.Variant, len(variants))
+	for i, variant := range variants {
+		result[i] = models.Variant{
+			ID:            variant.GetId(),
+			Name:          variant.GetName(),
+			Price:         optionalMoney(variant.GetPrice()),
+			OriginalPrice: optionalMoney(variant.GetOriginalPrice()),
+			Status:        stockStatus(variant.GetStatus()),
+			Quantity:      variant.GetQuantity(),
+		}
+	}
+	return result
+}
+
+func moneyFromProto(m *trackerpb.Money) models.Money {
+	return models.Money{Amount: m.GetAmount(), Currency: m.GetCurrency()}
+}
+
+// optionalMoney converts m, nil if it is not set.
+func optionalMoney(m *trackerpb.Money) *models.Money {
+	if m == nil {
+		return nil
+	}
+	money := moneyFromProto(m)
+	return &money
+}
+
+func priceSource(source trackerpb.PriceSource) string {
+	if source == trackerpb.PriceSource_PRICE_SOURCE_CACHED {
+		return models.SourceCached
+	}
+	return models.SourceLive
+}
+
+func webhookFromProto(resp *trackerpb.Webhook) models.Webhook {
+	return models.Webhook{
+		ID:        resp.GetId(),
+		URL:       resp.GetUrl(),
+		Secret:    resp.GetSecret(),
+		Active:    resp.GetActive(),
+		CreatedAt: resp.GetCreatedAt().AsTime(),
+	}
+}
+
+func stockStatus(status trackerpb.StockStatus) string {
+	switch status {
+	case trackerpb.StockStatus_STOCK_STATUS_IN_STOCK:
+		return models.StatusInStock
+	case trackerpb.StockStatus_STOCK_STATUS_OUT_OF_STOCK:
+		return models.StatusOutOfStock
+	default:
+		return models.StatusUnknown
+	}
+}
+
+func alertKind(kind trackerpb.AlertKind) string {
+	switch kind {
+	case trackerpb.AlertKind_ALERT_KIND_TARGET_PRICE:
+		return models.AlertTargetPrice
+	case trackerpb.AlertKind_ALERT_KIND_PERCENT_DROP:
+		return models.AlertPercentDrop
+	case trackerpb.AlertKind_ALERT_KIND_ANY_DROP:
+		return models.AlertAnyDrop
+	case trackerpb.AlertKind_ALERT_KIND_BACK_IN_STOCK:
+		return models.AlertBackInStock
+	default:
+		return ""
+	}
+}
+
+func deliveryStatus(status trackerpb.DeliveryStatus) string {
+	switch status {
+	case trackerpb.DeliveryStatus_DELIVERY_STATUS_DELIVERED:
+		return models.DeliveryDelivered
+	case trackerpb.DeliveryStatus_DELIVERY_STATUS_FAILED:
+		return models.DeliveryFailed
+	default:
+		return models.DeliveryPending
+	}
+}
@@ -1,8 +1,126 @@
 package models
 
+import "time"
+
+const (
+	StatusUnknown    = "unknown"
+	StatusInStock    = "in_stock"
+	StatusOutOfStock = "out_of_stock"
+)
+
+const (
+	SourceLive   = "live"
+	SourceCached = "cached"
+)
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+const (
+	AlertTargetPrice = "target_price"
+	AlertPercentDrop = "percent_drop"
+	AlertAnyDrop     = "any_drop"
+	AlertBackInStock = "back_in_stock"
+)
+
 type Item struct {
-	Name            string  `json:"name"`
-	StartPrice      float32 `json:"start_price"`
-	CurrentPrice    float32 `json:"current_price"`
-	DifferencePrice float32 `json:"difference_price"`
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	StartPrice   Money  `json:"start_price"`
+	CurrentPrice Money  `json:"current_price"`
+	// DifferencePrice is not set when the prices are in different currencies.
+	DifferencePrice *Money `json:"difference_price,omitempty"`
+	Status          string `json:"status"`
+	Paused          bool   `json:"paused"`
+	// Source tells whether CurrentPrice was scraped just now or is the last observed price.
+	Source string `json:"source"`
+	// Error explains why the item could not be scraped.
+	Error string `json:"error,omitempty"`
+	// OriginalPrice is the price before the discount, not set if there is none.
+	OriginalPrice   *Money  `json:"original_price,omitempty"`
+	DiscountPercent int32   `json:"discount_percent,omitempty"`
+	Brand           string  `json:"brand,omitempty"`
+	Seller          string  `json:"seller,omitempty"`
+	Rating          float32 `json:"rating,omitempty"`
+	ReviewCount     int32   `json:"review_count,omitempty"`
+	Quantity        int32   `json:"quantity,omitempty"`
+	ImageURL        string  `json:"image_url,omitempty"`
+	// The prices in the user's display currency, not set if there is no
+	// exchange rate.
+	ConvertedStartPrice   *Money `json:"converted_start_price,omitempty"`
+	ConvertedCurrentPrice *Money `json:"converted_current_price,omitempty"`
+	ConvertedDiffPrice    *Money `json:"converted_difference_price,omitempty"`
+	// VariantID is the variant the item is pinned to, the prices, status and
+	// quantity are those of the variant then.
+	VariantID   string `json:"variant_id,omitempty"`
+	VariantName string `json:"variant_name,omitempty"`
+	// Variants are the sizes or colors the product comes in, only set when
+	// the item was just scraped.
+	Variants []Variant `json:"variants,omitempty"`
+}
+
+// Variant is one size or color of a product.
+type Variant struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Price is not set when the variant is out of stock.
+	Price         *Money `json:"price,omitempty"`
+	OriginalPrice *Money `json:"original_price,omitempty"`
+	Status        string `json:"status"`
+	Quantity      int32  `json:"quantity,omitempty"`
+}
+
+// SubscriptionUpdate lists the changes to a tracked item, nil fields are left as they are.
+type SubscriptionUpdate struct {
+	Name            *string `json:"name"`
+	Paused          *bool   `json:"paused"`
+	ResetStartPrice bool    `json:"reset_start_price"`
+}
+
+type PricePoint struct {
+	Time   time.Time `json:"time"`
+	Price  Money     `json:"price"`
+	Status string    `json:"status"`
+}
+
+type PriceHistoryQuery struct {
+	From        time.Time
+	To          time.Time
+	Step        time.Duration
+	MaxPoints   uint32
+	Aggregation string
+}
+
+type Alert struct {
+	ID            string
+	UserID        string
+	Link          string
+	Name          string
+	Kind          string
+	Price         Money
+	PreviousPrice *Money
+	CreatedAt     time.Time
+}
+
+type Webhook struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	ID           string     `json:"id"`
+	EventID      string     `json:"event_id"`
+	Event        string     `json:"event"`
+	Status       string     `json:"status"`
+	Attempts     int32      `json:"attempts"`
+	ResponseCode int32      `json:"response_code,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	DeliveredAt  *time.Time `json:"delivered_at,omitempty"`
 }
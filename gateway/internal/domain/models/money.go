@@ -0,0 +1,44 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Money is an exact amount in the minor units of an ISO 4217 currency, e.g.
+// kopecks for RUB.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// String formats m for people: "1999.50 RUB".
+func (m Money) String() string {
+	exp := minorDigits(m.Currency)
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if exp > 0 {
+		if len(digits) <= exp {
+			digits = strings.Repeat("0", exp-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+	}
+	return sign + digits + " " + m.Currency
+}
+
+// minorDigits returns the number of minor unit digits of currency.
+func minorDigits(currency string) int {
+	switch currency {
+	case "JPY", "KRW", "VND", "CLP", "ISK", "UZS":
+		return 0
+	case "KWD", "BHD", "OMR", "JOD", "TND":
+		return 3
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,243 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/gateway/internal/domain/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Source provides the alert events that have to be delivered.
+type Source interface {
+	PendingAlerts(ctx context.Context, limit uint32) ([]models.Alert, error)
+	AckAlerts(ctx context.Context, ids []string) error
+}
+
+// Chats resolves the Telegram chat of a user.
+type Chats interface {
+	ChatID(ctx context.Context, userID string) (int64, error)
+}
+
+// Sender is the part of the bot API the notifier needs.
+type Sender interface {
+	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+}
+
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    uint32
+	// MaxAttempts is the number of times a message is sent before the
+	// notifier gives up until the next poll.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled after every
+	// failed attempt up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// GlobalRate and ChatInterval keep the bot within the Telegram limits:
+	// about 30 messages per second overall and one per second to a chat.
+	GlobalRate   int
+	ChatInterval time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		PollInterval: 10 * time.Second,
+		BatchSize:    100,
+		MaxAttempts:  5,
+		Backoff:      time.Second,
+		MaxBackoff:   30 * time.Second,
+		GlobalRate:   30,
+		ChatInterval: time.Second,
+	}
+}
+
+// errUndeliverable marks alerts that will never be delivered, e.g. because
+// the user has not logged in from Telegram or blocked the bot.
+var errUndeliverable = errors.New("alert cannot be delivered")
+
+// Notifier pushes price alerts to the Telegram chats of their users.
+type Notifier struct {
+	log    *slog.Logger
+	source Source
+	chats  Chats
+	sender Sender
+	cfg    Config
+
+	next     time.Time
+	lastSent map[int64]time.Time
+}
+
+func New(log *slog.Logger, source Source, chats Chats, sender Sender, cfg Config) *Notifier {
+	return &Notifier{
+		log:      log,
+		source:   source,
+		chats:    chats,
+		sender:   sender,
+		cfg:      cfg,
+		lastSent: make(map[int64]time.Time),
+	}
+}
+
+// Run delivers pending alerts every PollInterval until ctx is done.
+func (n *Notifier) Run(ctx context.Context) {
+	ticker := time.NewTicker(n.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		n.Deliver(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Deliver sends one batch of pending alerts in order. Alerts are acknowledged
+// once sent or found undeliverable. A transient failure stops the batch so
+// that the remaining alerts are retried on the next poll.
+func (n *Notifier) Deliver(ctx context.Context) {
+	const op = "notifier.Deliver"
+
+	log := n.log.With(slog.String("op", op))
+
+	alerts, err := n.source.PendingAlerts(ctx, n.cfg.BatchSize)
+	if err != nil {
+		log.Error("failed to get pending alerts", slog.String("error", err.Error()))
+		return
+	}
+
+	var done []string
+	for _, alert := range alerts {
+		err := n.deliver(ctx, alert)
+		if errors.Is(err, errUndeliverable) {
+			log.Warn("dropping alert", slog.String("id", alert.ID), slog.String("error", err.Error()))
+		} else if err != nil {
+			log.Error("failed to deliver alert", slog.String("id", alert.ID), slog.String("error", err.Error()))
+			break
+		}
+		done = append(done, alert.ID)
+	}
+
+	if len(done) > 0 {
+		if err := n.source.AckAlerts(ctx, done); err != nil {
+			log.Error("failed to acknowledge alerts", slog.String("error", err.Error()))
+		}
+	}
+
+	for chatID, sent := range n.lastSent {
+		if time.Since(sent) > n.cfg.ChatInterval {
+			delete(n.lastSent, chatID)
+		}
+	}
+}
+
+func (n *Notifier) deliver(ctx context.Context, alert models.Alert) error {
+	chatID, err := n.chats.ChatID(ctx, alert.UserID)
+	if status.Code(err) == codes.NotFound {
+		return fmt.Errorf("%w: no chat for user %s", errUndeliverable, alert.UserID)
+	}
+	if err != nil {
+		return err
+	}
+
+	msg := tgbotapi.NewMessage(chatID, Format(alert))
+	msg.DisableWebPagePreview = true
+
+	backoff := n.cfg.Backoff
+	for attempt := 1; ; attempt++ {
+		if err := n.wait(ctx, chatID); err != nil {
+			return err
+		}
+
+		_, err := n.sender.Send(msg)
+		if err == nil {
+			return nil
+		}
+
+		delay := backoff
+		var tgErr *tgbotapi.Error
+		if errors.As(err, &tgErr) {
+			switch {
+			case tgErr.RetryAfter > 0:
+				// Flood control applies to the whole bot, wait() holds back
+				// every message until it is lifted.
+				n.next = time.Now().Add(time.Duration(tgErr.RetryAfter) * time.Second)
+				delay = 0
+			case tgErr.Code == http.StatusBadRequest || tgErr.Code == http.StatusForbidden:
+				return fmt.Errorf("%w: %v", errUndeliverable, err)
+			}
+		}
+
+		if attempt >= n.cfg.MaxAttempts {
+			return err
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+		backoff = min(backoff*2, n.cfg.MaxBackoff)
+	}
+}
+
+// wait blocks until a message may be sent to chatID without exceeding the
+// global and per chat rate limits.
+func (n *Notifier) wait(ctx context.Context, chatID int64) error {
+	at := n.next
+	if last, ok := n.lastSent[chatID]; ok && last.Add(n.cfg.ChatInterval).After(at) {
+		at = last.Add(n.cfg.ChatInterval)
+	}
+
+	if err := sleep(ctx, time.Until(at)); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	n.next = now.Add(time.Second / time.Duration(n.cfg.GlobalRate))
+	n.lastSent[chatID] = now
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Format renders the Telegram message for alert.
+func Format(alert models.Alert) string {
+	var text string
+	switch alert.Kind {
+	case models.AlertTargetPrice:
+		text = fmt.Sprintf("Target price reached: %s now costs %s", alert.Name, alert.Price)
+	case models.AlertPercentDrop, models.AlertAnyDrop:
+		text = fmt.Sprintf("Price drop: %s now costs %s", alert.Name, alert.Price)
+		if alert.PreviousPrice != nil {
+			text += fmt.Sprintf(" (was %s)", *alert.PreviousPrice)
+		}
+	case models.AlertBackInStock:
+		text = fmt.Sprintf("Back in stock: %s is available for %s", alert.Name, alert.Price)
+	default:
+		text = fmt.Sprintf("Price alert: %s now costs %s", alert.Name, alert.Price)
+	}
+
+	return text + "\n" + alert.Link
+}
@@ -24,6 +24,7 @@ const (
 	Auth_V1_Login_FullMethodName    = "/auth.Auth_V1/Login"
 	Auth_V1_IsLogged_FullMethodName = "/auth.Auth_V1/IsLogged"
 	Auth_V1_Logout_FullMethodName   = "/auth.Auth_V1/Logout"
+	Auth_V1_ChatID_FullMethodName   = "/auth.Auth_V1/ChatID"
 )
 
 // Auth_V1Client is the client API for Auth_V1 service.
@@ -34,6 +35,7 @@ type Auth_V1Client interface {
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	IsLogged(ctx context.Context, in *IsLoggedRequest, opts ...grpc.CallOption) (*IsLoggedResponse, error)
 	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ChatID(ctx context.Context, in *ChatIDRequest, opts ...grpc.CallOption) (*ChatIDResponse, error)
 }
 
 type auth_V1Client struct {
@@ -84,6 +86,16 @@ func (c *auth_V1Client) Logout(ctx context.Context, in *LogoutRequest, opts ...g
 	return out, nil
 }
 
+func (c *auth_V1Client) ChatID(ctx context.Context, in *ChatIDRequest, opts ...grpc.CallOption) (*ChatIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChatIDResponse)
+	err := c.cc.Invoke(ctx, Auth_V1_ChatID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Auth_V1Server is the server API for Auth_V1 service.
 // All implementations must embed UnimplementedAuth_V1Server
 // for forward compatibility.
@@ -92,6 +104,7 @@ type Auth_V1Server interface {
 	Login(context.Context, *LoginRequest) (*LoginResponse, error)
 	IsLogged(context.Context, *IsLoggedRequest) (*IsLoggedResponse, error)
 	Logout(context.Context, *LogoutRequest) (*emptypb.Empty, error)
+	ChatID(context.Context, *ChatIDRequest) (*ChatIDResponse, error)
 	mustEmbedUnimplementedAuth_V1Server()
 }
 
@@ -114,6 +127,9 @@ func (UnimplementedAuth_V1Server) IsLogged(context.Context, *IsLoggedRequest) (*
 func (UnimplementedAuth_V1Server) Logout(context.Context, *LogoutRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
 }
+func (UnimplementedAuth_V1Server) ChatID(context.Context, *ChatIDRequest) (*ChatIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChatID not implemented")
+}
 func (UnimplementedAuth_V1Server) mustEmbedUnimplementedAuth_V1Server() {}
 func (UnimplementedAuth_V1Server) testEmbeddedByValue()                 {}
 
@@ -207,6 +223,24 @@ func _Auth_V1_Logout_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Auth_V1_ChatID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChatIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Auth_V1Server).ChatID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_V1_ChatID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Auth_V1Server).ChatID(ctx, req.(*ChatIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Auth_V1_ServiceDesc is the grpc.ServiceDesc for Auth_V1 service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -230,6 +264,10 @@ var Auth_V1_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Logout",
 			Handler:    _Auth_V1_Logout_Handler,
 		},
+		{
+			MethodName: "ChatID",
+			Handler:    _Auth_V1_ChatID_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth.proto",
@@ -1,13 +1,29 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/config"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/cache"
 	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
 	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/handlers"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/outbox"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/parser"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/rates"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/scheduler"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/scraper"
 	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/service"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/webhook"
 	proto "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/proto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -20,16 +36,90 @@ func main() {
 		log.Fatalf("cannot init config err:%v", err)
 	}
 
-	PG_conn, err := postgres_db.InitDB(config.Postgres.DB_HOST, config.Postgres.DB_PORT, config.Postgres.DB_USER, config.Postgres.DB_PASSWORD, config.Postgres.DB_NAME)
+	PG_conn, err := postgres_db.InitDB(context.Background(), poolConfig(config))
 	if err != nil {
 		log.Fatalf("cannot connect to Postgres %v", err)
 	}
 
-	service := service.NewService(PG_conn)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(config, os.Args[2:])
+		PG_conn.Close()
+		return
+	}
+
+	if config.Postgres.Migrate {
+		runMigrate(config, []string{"up"})
+	}
+
+	limits := make(map[string]scraper.Limit, len(config.Scraper.Limits))
+	for name, limit := range config.Scraper.Limits {
+		limits[name] = scraper.Limit(limit)
+	}
+	var proxies *scraper.ProxyPool
+	if len(config.Scraper.Proxies.URLs) > 0 {
+		proxies, err = scraper.NewProxyPool(scraper.ProxyConfig{
+			URLs:          config.Scraper.Proxies.URLs,
+			Mode:          scraper.ProxyMode(config.Scraper.Proxies.Mode),
+			MaxFailures:   config.Scraper.Proxies.MaxFailures,
+			Cooldown:      config.Scraper.Proxies.Cooldown,
+			CheckURL:      config.Scraper.Proxies.CheckURL,
+			CheckInterval: config.Scraper.Proxies.CheckInterval,
+		}, config.Scraper.Timeout)
+		if err != nil {
+			log.Fatalf("cannot create proxy pool %v", err)
+		}
+	}
+
+	var profiles []scraper.HeaderProfile
+	if config.Scraper.RotateHeaders {
+		profiles = scraper.BrowserProfiles
+	}
+
+	client := scraper.New(scraper.Config{
+		Timeout:   config.Scraper.Timeout,
+		UserAgent: config.Scraper.UserAgent,
+		Limiter:   scraper.NewLimiter(limits),
+		Retry: scraper.RetryPolicy{
+			MaxAttempts: config.Scraper.Retry.MaxAttempts,
+			Backoff:     config.Scraper.Retry.Backoff,
+			MaxBackoff:  config.Scraper.Retry.MaxBackoff,
+		},
+		Breaker: scraper.BreakerConfig{
+			Threshold: config.Scraper.Breaker.Threshold,
+			Cooldown:  config.Scraper.Breaker.Cooldown,
+		},
+		Proxies:  proxies,
+		Profiles: profiles,
+	})
+
+	registry := parser.NewRegistry(
+		parser.NewWildberries(client),
+	)
+
+	rules, err := parser.LoadRules(client, config.Rules.Path)
+	if err != nil {
+		log.Fatalf("cannot load site rules %v", err)
+	}
+
+	scrapeParser := parser.New(registry, rules, parser.NewGeneric(client))
+
+	var productParser service.ProductParser = scrapeParser
+	if backend := cacheBackend(config); backend != nil {
+		productParser = cache.New(scrapeParser, backend, config.Cache.TTL)
+	}
+
+	service := service.NewService(PG_conn, productParser, parser.NewLinks(registry, client), client, rateProvider(config))
 
 	handler := handlers.NewHandler(service)
+	handler.FanOut = handlers.FanOut{
+		Concurrency: config.FanOut.Concurrency,
+		ItemTimeout: config.FanOut.ItemTimeout,
+	}
 
-	GrpcServer := grpc.NewServer()
+	if config.Server.AdminToken == "" {
+		log.Println("TRACKER_ADMIN_TOKEN is not set, the admin rpcs are disabled")
+	}
+	GrpcServer := grpc.NewServer(grpc.UnaryInterceptor(handlers.AdminAuth(config.Server.AdminToken)))
 	reflection.Register(GrpcServer)
 
 	proto.RegisterScraperServer(GrpcServer, handler)
@@ -39,6 +129,94 @@ func main() {
 		log.Fatalf("failed to listen %v", err)
 	}
 
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var wg sync.WaitGroup
+
+	if proxies != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			proxies.Run(ctx)
+		}()
+	}
+
+	if config.Scheduler.Enabled {
+		poller := scheduler.New(service, scheduler.Config{
+			Interval:    config.Scheduler.Interval,
+			Jitter:      config.Scheduler.Jitter,
+			Concurrency: config.Scheduler.Concurrency,
+		})
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			poller.Run(ctx)
+		}()
+	}
+
+	if config.Outbox.Enabled {
+		relay := outbox.New(PG_conn, outboxSinks(config, PG_conn), outbox.Config{
+			PollInterval: config.Outbox.PollInterval,
+			BatchSize:    config.Outbox.BatchSize,
+			MaxAttempts:  config.Outbox.MaxAttempts,
+			Backoff:      config.Outbox.Backoff,
+			MaxBackoff:   config.Outbox.MaxBackoff,
+			Lease:        config.Outbox.Lease,
+		})
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			relay.Run(ctx)
+		}()
+	}
+
+	if config.Webhooks.Enabled {
+		dispatcher := webhook.NewDispatcher(PG_conn, webhook.Config{
+			PollInterval: config.Webhooks.PollInterval,
+			BatchSize:    config.Webhooks.BatchSize,
+			Concurrency:  config.Webhooks.Concurrency,
+			Timeout:      config.Webhooks.Timeout,
+			MaxAttempts:  config.Webhooks.MaxAttempts,
+			Backoff:      config.Webhooks.Backoff,
+			MaxBackoff:   config.Webhooks.MaxBackoff,
+			DisableAfter: config.Webhooks.DisableAfter,
+			Lease:        config.Webhooks.Lease,
+		})
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dispatcher.Run(ctx)
+		}()
+	}
+
+	if config.Metrics.Addr != "" {
+		prometheus.MustRegister(postgres_db.NewPoolCollector(PG_conn.Pool))
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		metricsServer := &http.Server{Addr: config.Metrics.Addr, Handler: mux}
+
+		go func() {
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Printf("metrics server failed err: %v", err)
+			}
+		}()
+		go func() {
+			<-ctx.Done()
+			metricsServer.Close()
+		}()
+	}
+
+	go func() {
+		<-ctx.Done()
+		log.Println("stopping WebScraper")
+		GrpcServer.GracefulStop()
+	}()
+
 	log.Println("WebScraper is running")
 
 	err = GrpcServer.Serve(lis)
@@ -46,4 +224,95 @@ func main() {
 		log.Fatalf("failed to serve: %v", err)
 	}
 
+	wg.Wait()
+	PG_conn.Close()
+
+	log.Println("WebScraper stopped")
+}
+
+// poolConfig returns the settings of the tracker database.
+func poolConfig(cfg *config.Config) postgres_db.PoolConfig {
+	return postgres_db.PoolConfig{
+		Host:                   cfg.Postgres.DB_HOST,
+		Port:                   cfg.Postgres.DB_PORT,
+		User:                   cfg.Postgres.DB_USER,
+		Password:               cfg.Postgres.DB_PASSWORD,
+		Name:                   cfg.Postgres.DB_NAME,
+		SSLMode:                cfg.Postgres.SSLMode,
+		SSLRootCert:            cfg.Postgres.SSLRootCert,
+		SSLCert:                cfg.Postgres.SSLCert,
+		SSLKey:                 cfg.Postgres.SSLKey,
+		MaxConns:               cfg.Postgres.Pool.MaxConns,
+		MinConns:               cfg.Postgres.Pool.MinConns,
+		MaxConnLifetime:        cfg.Postgres.Pool.MaxConnLifetime,
+		MaxConnIdleTime:        cfg.Postgres.Pool.MaxConnIdleTime,
+		HealthCheckPeriod:      cfg.Postgres.Pool.HealthCheckPeriod,
+		StatementCacheMode:     cfg.Postgres.StatementCache.Mode,
+		StatementCacheCapacity: cfg.Postgres.StatementCache.Capacity,
+		ConnectTimeout:         cfg.Postgres.ConnectTimeout,
+		ConnectRetry:           cfg.Postgres.ConnectRetry,
+	}
+}
+
+// cacheBackend returns the configured scrape cache backend, nil if caching is off.
+func cacheBackend(cfg *config.Config) cache.Backend {
+	switch cfg.Cache.Backend {
+	case "memory":
+		return cache.NewMemory()
+	case "redis":
+		client := redis.NewClient(&redis.Options{
+			Addr:     cfg.Cache.Redis.Addr,
+			Password: cfg.Cache.Redis.Password,
+			DB:       cfg.Cache.Redis.DB,
+		})
+		return cache.NewRedis(client, cfg.Cache.Redis.Prefix)
+	case "":
+		return nil
+	default:
+		log.Fatalf("unknown cache backend %q", cfg.Cache.Backend)
+		return nil
+	}
+}
+
+// rateProvider returns the configured exchange rate provider, nil if prices
+// are not converted.
+func rateProvider(cfg *config.Config) rates.Provider {
+	switch cfg.Rates.Backend {
+	case "static":
+		static, err := rates.LoadFile(cfg.Rates.File)
+		if err != nil {
+			log.Fatalf("cannot load exchange rates %v", err)
+		}
+		return static
+	case "http":
+		return rates.NewHTTP(&http.Client{Timeout: cfg.Rates.Timeout}, cfg.Rates.URL, cfg.Rates.TTL)
+	case "":
+		return nil
+	default:
+		log.Fatalf("unknown exchange rate backend %q", cfg.Rates.Backend)
+		return nil
+	}
+}
+
+func outboxSinks(cfg *config.Config, db *postgres_db.DBConn) []outbox.Sink {
+	var sinks []outbox.Sink
+
+	if cfg.Outbox.Telegram {
+		sinks = append(sinks, outbox.NewTelegramSink(db))
+	}
+	if cfg.Webhooks.Enabled {
+		sinks = append(sinks, webhook.NewSink(db))
+	}
+	if cfg.Outbox.Webhook.URL != "" {
+		sinks = append(sinks, outbox.NewWebhookSink(cfg.Outbox.Webhook.URL, cfg.Outbox.Webhook.Timeout))
+	}
+	if cfg.Outbox.Redis.Addr != "" {
+		client := redis.NewClient(&redis.Options{
+			Addr:     cfg.Outbox.Redis.Addr,
+			Password: cfg.Outbox.Redis.Password,
+		})
+		sinks = append(sinks, outbox.NewRedisSink(client, cfg.Outbox.Redis.Stream, cfg.Outbox.Redis.MaxLen))
+	}
+
+	return sinks
 }
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/config"
+	postgres_db "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db/migrator"
+)
+
+const migrateUsage = "usage: price_tracker migrate up | down [steps] | version"
+
+// runMigrate runs the migrate subcommand: up applies the pending migrations
+// of the tracker schema, down reverts the last steps of them or all of them
+// without steps, version prints the applied version.
+func runMigrate(cfg *config.Config, args []string) {
+	if len(args) == 0 {
+		log.Fatal(migrateUsage)
+	}
+
+	m, err := migrator.New(postgres_db.DSN(poolConfig(cfg)))
+	if err != nil {
+		log.Fatalf("cannot prepare migrations err: %v", err)
+	}
+	defer m.Close()
+
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		err = m.Up()
+	case args[0] == "down" && len(args) <= 2:
+		steps := 0
+		if len(args) == 2 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				log.Fatal(migrateUsage)
+			}
+		}
+		err = m.Down(steps)
+	case args[0] == "version" && len(args) == 1:
+	default:
+		log.Fatal(migrateUsage)
+	}
+	if err != nil {
+		log.Printf("migrate %s failed err: %v", args[0], err)
+		m.Close()
+		os.Exit(1)
+	}
+
+	version, dirty, err := m.Version()
+	if err != nil {
+		log.Fatalf("cannot get migration version err: %v", err)
+	}
+	log.Printf("tracker schema at version %d, dirty: %t", version, dirty)
+}
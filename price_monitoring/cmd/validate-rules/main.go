@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"net/url"
+	"os"
+
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/parser"
+)
+
+// validate-rules runs a site rule against a saved HTML page and prints the
+// product it extracts, so a rule can be checked without deploying it.
+func main() {
+	rulesPath := flag.String("rules", "config/rules.yaml", "path to the rules file")
+	site := flag.String("site", "", "site name of the rule to run")
+	link := flag.String("url", "", "product link, used to pick the rule by host instead of -site")
+	fixture := flag.String("fixture", "", "saved HTML page")
+	flag.Parse()
+
+	if *fixture == "" || (*site == "" && *link == "") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	rules, err := parser.LoadRules(nil, *rulesPath)
+	if err != nil {
+		log.Fatalf("cannot load rules err: %v", err)
+	}
+
+	var (
+		rule *parser.Rule
+		ok   bool
+	)
+	if *site != "" {
+		rule, ok = rules.Site(*site)
+	} else {
+		u, err := url.Parse(*link)
+		if err != nil {
+			log.Fatalf("invalid url err: %v", err)
+		}
+		rule, ok = rules.Lookup(u.Hostname())
+	}
+	if !ok {
+		log.Fatalf("no matching rule in %s", *rulesPath)
+	}
+
+	page, err := os.ReadFile(*fixture)
+	if err != nil {
+		log.Fatalf("cannot read fixture err: %v", err)
+	}
+
+	product, err := rule.Apply(page)
+	if err != nil {
+		log.Fatalf("rule %s failed: %v", rule.Site, err)
+	}
+	product.Marketplace = rule.Site
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(product)
+}
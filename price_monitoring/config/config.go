@@ -2,6 +2,9 @@ package config
 
 import (
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -9,6 +12,11 @@ import (
 type Config struct {
 	Server struct {
 		Port string
+		// AdminToken is required in the x-admin-token metadata of the
+		// alert delivery, outbox replay and health RPCs, disabled if empty.
+		// It is a secret, set it with TRACKER_ADMIN_TOKEN or in the file of
+		// TRACKER_ADMIN_TOKEN_FILE rather than in the config file.
+		AdminToken string
 	}
 	Postgres struct {
 		DB_HOST     string
@@ -16,7 +24,141 @@ type Config struct {
 		DB_USER     string
 		DB_PASSWORD string
 		DB_NAME     string
+		// Migrate applies the pending migrations of the tracker schema on
+		// start, otherwise they are applied with the migrate subcommand.
+		Migrate bool
+		// SSLMode is a libpq sslmode, SSLRootCert, SSLCert and SSLKey are
+		// paths of PEM files.
+		SSLMode     string
+		SSLRootCert string
+		SSLCert     string
+		SSLKey      string
+		Pool        struct {
+			MaxConns          int32
+			MinConns          int32
+			MaxConnLifetime   time.Duration
+			MaxConnIdleTime   time.Duration
+			HealthCheckPeriod time.Duration
+		}
+		StatementCache struct {
+			// Mode is cache_statement, cache_describe, describe_exec, exec
+			// or simple_protocol.
+			Mode     string
+			Capacity int
+		}
+		// Postgres is waited for on start for ConnectTimeout, checking
+		// every ConnectRetry.
+		ConnectTimeout time.Duration
+		ConnectRetry   time.Duration
 	}
+	Scraper struct {
+		Timeout   time.Duration
+		UserAgent string
+		// Limits are keyed by marketplace name, "default" applies to the
+		// hosts of no marketplace.
+		Limits map[string]ScraperLimit
+		Retry  struct {
+			MaxAttempts int
+			Backoff     time.Duration
+			MaxBackoff  time.Duration
+		}
+		Breaker struct {
+			Threshold int
+			Cooldown  time.Duration
+		}
+		// RotateHeaders sends realistic browser headers instead of UserAgent.
+		RotateHeaders bool
+		Proxies       struct {
+			// URLs of http, https or socks5 proxies, requests go out
+			// directly if empty.
+			URLs []string
+			// Mode is "rotate" or "sticky".
+			Mode          string
+			MaxFailures   int
+			Cooldown      time.Duration
+			CheckURL      string
+			CheckInterval time.Duration
+		}
+	}
+	Metrics struct {
+		// Addr of the Prometheus endpoint, disabled if empty.
+		Addr string
+	}
+	Cache struct {
+		// Backend is "memory", "redis" or empty to scrape on every request.
+		Backend string
+		TTL     time.Duration
+		Redis   struct {
+			Addr     string
+			Password string
+			DB       int
+			Prefix   string
+		}
+	}
+	FanOut struct {
+		Concurrency int
+		ItemTimeout time.Duration
+	}
+	Rules struct {
+		Path string
+	}
+	Rates struct {
+		// Backend is "static", "http" or empty to show prices only in
+		// the currency they were observed in.
+		Backend string
+		// File of the static backend.
+		File string
+		// URL of the http backend and how long its rates are kept.
+		URL     string
+		TTL     time.Duration
+		Timeout time.Duration
+	}
+	Scheduler struct {
+		Enabled     bool
+		Interval    time.Duration
+		Jitter      time.Duration
+		Concurrency int
+	}
+	Outbox struct {
+		Enabled      bool
+		PollInterval time.Duration
+		BatchSize    int
+		MaxAttempts  int
+		Backoff      time.Duration
+		MaxBackoff   time.Duration
+		Lease        time.Duration
+		Telegram     bool
+		Webhook      struct {
+			URL     string
+			Timeout time.Duration
+		}
+		Redis struct {
+			Addr     string
+			Password string
+			Stream   string
+			MaxLen   int64
+		}
+	}
+	Webhooks struct {
+		Enabled      bool
+		PollInterval time.Duration
+		BatchSize    int
+		Concurrency  int
+		Timeout      time.Duration
+		MaxAttempts  int
+		Backoff      time.Duration
+		MaxBackoff   time.Duration
+		DisableAfter int
+		Lease        time.Duration
+	}
+}
+
+type ScraperLimit struct {
+	Hosts       []string
+	Rate        float64
+	Burst       int
+	MaxInFlight int
+	MinDelay    time.Duration
 }
 
 func InitConfig() (*Config, error) {
@@ -35,5 +177,16 @@ func InitConfig() (*Config, error) {
 		return nil, fmt.Errorf("problems with unmarshall config err: %v", err)
 	}
 
+	if token := os.Getenv("TRACKER_ADMIN_TOKEN"); token != "" {
+		cfg.Server.AdminToken = token
+	}
+	if path := os.Getenv("TRACKER_ADMIN_TOKEN_FILE"); path != "" {
+		token, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("problems with read admin token err: %v", err)
+		}
+		cfg.Server.AdminToken = strings.TrimSpace(string(token))
+	}
+
 	return &cfg, nil
 }
@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/parser"
+	"golang.org/x/sync/singleflight"
+)
+
+// Backend stores serialized products until they expire.
+type Backend interface {
+	// Get returns the value stored under key, ok is false if there is none.
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// Source is the parser the cache sits in front of.
+type Source interface {
+	Parse(ctx context.Context, link string) (*parser.Product, error)
+	Key(link string) (string, error)
+}
+
+// Parser serves scrape results from a Backend for TTL after they were
+// fetched. Concurrent requests for the same product share a single fetch.
+// Only successful results, including out of stock products, are cached.
+type Parser struct {
+	source  Source
+	backend Backend
+	ttl     time.Duration
+	group   singleflight.Group
+}
+
+func New(source Source, backend Backend, ttl time.Duration) *Parser {
+	return &Parser{
+		source:  source,
+		backend: backend,
+		ttl:     ttl,
+	}
+}
+
+func (p *Parser) Parse(ctx context.Context, link string) (*parser.Product, error) {
+	key, err := p.source.Key(link)
+	if err != nil {
+		return nil, err
+	}
+
+	if product, ok := p.get(ctx, key); ok {
+		product.Link = link
+		product.FromCache = true
+		return product, product.Err()
+	}
+
+	// The fetch is shared, so it must not be cancelled with the request
+	// that happened to start it. The scraper client bounds its duration.
+	// Only the caller whose function ran reports a fresh result, the others
+	// got it from the cache in effect.
+	var fetched bool
+	ch := p.group.DoChan(key, func() (any, error) {
+		fetched = true
+		product, err := p.source.Parse(context.WithoutCancel(ctx), link)
+		if product != nil {
+			p.set(context.WithoutCancel(ctx), key, product)
+		}
+		return product, err
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		product, _ := res.Val.(*parser.Product)
+		if product == nil {
+			return nil, res.Err
+		}
+
+		shared := *product
+		shared.Link = link
+		shared.FromCache = !fetched
+		return &shared, res.Err
+	}
+}
+
+func (p *Parser) get(ctx context.Context, key string) (*parser.Product, bool) {
+	data, ok, err := p.backend.Get(ctx, key)
+	if err != nil {
+		log.Printf("cache: cannot get %s err: %v", key, err)
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+
+	var product parser.Product
+	if err := json.Unmarshal(data, &product); err != nil {
+		log.Printf("cache: invalid entry %s err: %v", key, err)
+		return nil, false
+	}
+	return &product, true
+}
+
+func (p *Parser) set(ctx context.Context, key string, product *parser.Product) {
+	data, err := json.Marshal(product)
+	if err != nil {
+		log.Printf("cache: cannot encode %s err: %v", key, err)
+		return
+	}
+
+	if err := p.backend.Set(ctx, key, data, p.ttl); err != nil {
+		log.Printf("cache: cannot set %s err: %v", key, err)
+	}
+}
@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/parser"
+)
+
+type fakeSource struct {
+	calls   atomic.Int32
+	release chan struct{}
+	parse   func(link string) (*parser.Product, error)
+}
+
+func (f *fakeSource) Key(link string) (string, error) {
+	return link, nil
+}
+
+func (f *fakeSource) Parse(ctx context.Context, link string) (*parser.Product, error) {
+	f.calls.Add(1)
+	if f.release != nil {
+		<-f.release
+	}
+	return f.parse(link)
+}
+
+func TestParse(t *testing.T) {
+	t.Run("serves repeated requests from the cache", func(t *testing.T) {
+		source := &fakeSource{parse: func(link string) (*parser.Product, error) {
+			return &parser.Product{Name: "item", Price: money.New(1000, "RUB"), Stock: parser.StockInStock}, nil
+		}}
+		p := New(source, NewMemory(), time.Minute)
+
+		product, err := p.Parse(context.Background(), "link")
+		assert.NoError(t, err)
+		assert.False(t, product.FromCache)
+
+		product, err = p.Parse(context.Background(), "link")
+		assert.NoError(t, err)
+		assert.True(t, product.FromCache)
+		assert.Equal(t, money.New(1000, "RUB"), product.Price)
+		assert.Equal(t, int32(1), source.calls.Load())
+	})
+
+	t.Run("keeps out of stock products", func(t *testing.T) {
+		source := &fakeSource{parse: func(link string) (*parser.Product, error) {
+			return &parser.Product{Name: "item", Stock: parser.StockOutOfStock}, parser.ErrOutOfStock
+		}}
+		p := New(source, NewMemory(), time.Minute)
+
+		_, err := p.Parse(context.Background(), "link")
+		assert.ErrorIs(t, err, parser.ErrOutOfStock)
+
+		product, err := p.Parse(context.Background(), "link")
+		assert.ErrorIs(t, err, parser.ErrOutOfStock)
+		assert.True(t, product.FromCache)
+		assert.Equal(t, int32(1), source.calls.Load())
+	})
+
+	t.Run("does not keep errors", func(t *testing.T) {
+		source := &fakeSource{parse: func(link string) (*parser.Product, error) {
+			return nil, parser.ErrUpstreamUnavailable
+		}}
+		p := New(source, NewMemory(), time.Minute)
+
+		for range 2 {
+			_, err := p.Parse(context.Background(), "link")
+			assert.ErrorIs(t, err, parser.ErrUpstreamUnavailable)
+		}
+		assert.Equal(t, int32(2), source.calls.Load())
+	})
+
+	t.Run("collapses concurrent requests", func(t *testing.T) {
+		source := &fakeSource{
+			release: make(chan struct{}),
+			parse: func(link string) (*parser.Product, error) {
+				return &parser.Product{Name: "item", Price: money.New(1000, "RUB"), Stock: parser.StockInStock}, nil
+			},
+		}
+		p := New(source, NewMemory(), time.Minute)
+
+		const callers = 5
+		var (
+			wg    sync.WaitGroup
+			fresh atomic.Int32
+		)
+		for range callers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				product, err := p.Parse(context.Background(), "link")
+				assert.NoError(t, err)
+				if !product.FromCache {
+					fresh.Add(1)
+				}
+			}()
+		}
+
+		assert.Eventually(t, func() bool { return source.calls.Load() == 1 }, time.Second, time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
+		close(source.release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), source.calls.Load())
+		assert.Equal(t, int32(1), fresh.Load())
+	})
+
+	t.Run("cancelled waiter does not cancel the fetch", func(t *testing.T) {
+		source := &fakeSource{
+			release: make(chan struct{}),
+			parse: func(link string) (*parser.Product, error) {
+				return &parser.Product{Name: "item", Stock: parser.StockInStock}, nil
+			},
+		}
+		p := New(source, NewMemory(), time.Minute)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := p.Parse(ctx, "link")
+		assert.True(t, errors.Is(err, context.Canceled))
+
+		close(source.release)
+		assert.Eventually(t, func() bool {
+			product, err := p.Parse(context.Background(), "link")
+			return err == nil && product.FromCache
+		}, time.Second, time.Millisecond)
+		assert.Equal(t, int32(1), source.calls.Load())
+	})
+}
+
+func TestMemory(t *testing.T) {
+	m := NewMemory()
+	ctx := context.Background()
+
+	assert.NoError(t, m.Set(ctx, "fresh", []byte("1"), time.Minute))
+	assert.NoError(t, m.Set(ctx, "stale", []byte("2"), -time.Second))
+
+	value, ok, err := m.Get(ctx, "fresh")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+
+	_, ok, _ = m.Get(ctx, "stale")
+	assert.False(t, ok)
+
+	_, ok, _ = m.Get(ctx, "missing")
+	assert.False(t, ok)
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	value   []byte
+	expires time.Time
+}
+
+// Memory is a Backend local to the process.
+type Memory struct {
+	mu      sync.Mutex
+	entries map[string]entry
+	// sweepAt is the size at which expired entries are removed.
+	sweepAt int
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		entries: make(map[string]entry),
+		sweepAt: 1024,
+	}
+}
+
+func (m *Memory) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if time.Now().After(e.expires) {
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+	return e.value, true, nil
+}
+
+func (m *Memory) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if len(m.entries) >= m.sweepAt {
+		for k, e := range m.entries {
+			if now.After(e.expires) {
+				delete(m.entries, k)
+			}
+		}
+		m.sweepAt = max(1024, 2*len(m.entries))
+	}
+
+	m.entries[key] = entry{value: value, expires: now.Add(ttl)}
+	return nil
+}
@@ -0,0 +1,224 @@
+package postgres_db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+)
+
+type AlertRule struct {
+	Id             string
+	SubscriptionId string
+	Kind           int16
+	TargetPrice    money.Money
+	DropPercent    float32
+	CreatedAt      time.Time
+}
+
+// WatchedRule is an alert rule together with the subscription it belongs to,
+// as needed to check it against a new observation.
+type WatchedRule struct {
+	AlertRule
+	UserId     string
+	StartPrice money.Money
+	// VariantId is the variant the subscription is pinned to, if any.
+	VariantId string
+}
+
+// AlertEvent is the payload of TopicAlert events.
+type AlertEvent struct {
+	Id            string       `json:"id"`
+	RuleId        string       `json:"rule_id"`
+	UserId        string       `json:"user_id"`
+	Link          string       `json:"link"`
+	Name          string       `json:"name"`
+	Kind          int16        `json:"kind"`
+	Price         money.Money  `json:"price"`
+	PreviousPrice *money.Money `json:"previous_price,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+// AlertMatcher reports whether rule fires for the transition from previous to current.
+type AlertMatcher func(rule WatchedRule, previous *PricePoint, current PricePoint) bool
+
+// InsertAlertRuleFromDB adds a rule to one of the user's subscriptions.
+// sql.ErrNoRows means the user has no such subscription.
+func (db *DBConn) InsertAlertRuleFromDB(ctx context.Context, userId string, rule AlertRule) (AlertRule, error) {
+
+	rule.Id = uuid.New().String()
+	rule.CreatedAt = time.Now()
+
+	currency := rule.TargetPrice.Currency
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+
+	var subscriptionId string
+	err := db.Conn.QueryRowContext(ctx,
+		`INSERT INTO tracker.alert_rules (id, subscription_id, kind, target_price, target_currency, drop_percent, created_at)
+		SELECT $1, s.id, $4, $5, $6, $7, $8 FROM tracker.subscriptions s WHERE s.id = $2 AND s.user_id = $3
+		RETURNING subscription_id`,
+		rule.Id, rule.SubscriptionId, userId, rule.Kind, rule.TargetPrice.Amount, currency, rule.DropPercent,
+		rule.CreatedAt).Scan(&subscriptionId)
+
+	return rule, err
+}
+
+// SelectAlertRulesFromDB lists the user's rules, only those of one
+// subscription if subscriptionId is not empty.
+func (db *DBConn) SelectAlertRulesFromDB(ctx context.Context, userId, subscriptionId string) ([]AlertRule, error) {
+
+	rows, err := db.Conn.QueryContext(ctx,
+		`SELECT r.id, r.subscription_id, r.kind, r.target_price, r.target_currency, r.drop_percent, r.created_at
+		FROM tracker.alert_rules r JOIN tracker.subscriptions s ON s.id = r.subscription_id
+		WHERE s.user_id = $1 AND ($2 = '' OR s.id::text = $2) ORDER BY r.created_at`,
+		userId, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []AlertRule
+	for rows.Next() {
+		var rule AlertRule
+		if err := rows.Scan(&rule.Id, &rule.SubscriptionId, &rule.Kind, &rule.TargetPrice.Amount, &rule.TargetPrice.Currency,
+			&rule.DropPercent, &rule.CreatedAt); err != nil {
+			return nil, err
+		}
+		rule.TargetPrice = targetPrice(rule.TargetPrice)
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// DeleteAlertRuleFromDB removes one of the user's rules.
+// sql.ErrNoRows means the user has no such rule.
+func (db *DBConn) DeleteAlertRuleFromDB(ctx context.Context, userId, id string) error {
+
+	res, err := db.Conn.ExecContext(ctx,
+		`DELETE FROM tracker.alert_rules r USING tracker.subscriptions s
+		WHERE s.id = r.subscription_id AND r.id = $1 AND s.user_id = $2`, id, userId)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// selectWatchedRules returns the rules of all active subscriptions to link.
+func selectWatchedRules(ctx context.Context, tx *sql.Tx, link string) ([]WatchedRule, error) {
+
+	rows, err := tx.QueryContext(ctx,
+		`SELECT r.id, r.subscription_id, r.kind, r.target_price, r.target_currency, r.drop_percent, r.created_at,
+			s.user_id, s.start_price, s.currency, s.variant_id
+		FROM tracker.alert_rules r
+		JOIN tracker.subscriptions s ON s.id = r.subscription_id
+		JOIN tracker.products p ON p.id = s.product_id
+		WHERE p.link = $1 AND NOT s.paused`, link)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []WatchedRule
+	for rows.Next() {
+		var rule WatchedRule
+		if err := rows.Scan(&rule.Id, &rule.SubscriptionId, &rule.Kind, &rule.TargetPrice.Amount, &rule.TargetPrice.Currency,
+			&rule.DropPercent, &rule.CreatedAt, &rule.UserId, &rule.StartPrice.Amount, &rule.StartPrice.Currency,
+			&rule.VariantId); err != nil {
+			return nil, err
+		}
+		rule.TargetPrice = targetPrice(rule.TargetPrice)
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+// InsertAlertEventsFromDB queues events for delivery to Telegram. Events that
+// are already queued are skipped, so publishing an event again is harmless.
+func (db *DBConn) InsertAlertEventsFromDB(ctx context.Context, events []AlertEvent) error {
+
+	tx, err := db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, event := range events {
+		var previous sql.NullInt64
+		if event.PreviousPrice != nil {
+			previous = sql.NullInt64{Int64: event.PreviousPrice.Amount, Valid: true}
+		}
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO tracker.alert_events (id, rule_id, user_id, link, product_name, kind, price, currency, previous_price, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT (id) DO NOTHING`,
+			event.Id, event.RuleId, event.UserId, event.Link, event.Name, event.Kind,
+			event.Price.Amount, event.Price.Currency, previous, event.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SelectPendingAlertEventsFromDB returns up to limit undelivered events, oldest first.
+func (db *DBConn) SelectPendingAlertEventsFromDB(ctx context.Context, limit int) ([]AlertEvent, error) {
+
+	rows, err := db.Conn.QueryContext(ctx,
+		`SELECT id, rule_id, user_id, link, product_name, kind, price, currency, previous_price, created_at
+		FROM tracker.alert_events WHERE delivered_at IS NULL ORDER BY created_at LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []AlertEvent
+	for rows.Next() {
+		var event AlertEvent
+		var previous sql.NullInt64
+		if err := rows.Scan(&event.Id, &event.RuleId, &event.UserId, &event.Link, &event.Name, &event.Kind,
+			&event.Price.Amount, &event.Price.Currency, &previous, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		// The previous price of an event is always in the currency of its price.
+		if previous.Valid {
+			price := money.New(previous.Int64, event.Price.Currency)
+			event.PreviousPrice = &price
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+func (db *DBConn) MarkAlertEventsDeliveredFromDB(ctx context.Context, ids []string) error {
+
+	_, err := db.Conn.ExecContext(ctx,
+		"UPDATE tracker.alert_events SET delivered_at = $2 WHERE id = ANY($1::uuid[]) AND delivered_at IS NULL",
+		ids, time.Now())
+
+	return err
+}
+
+// targetPrice drops the currency of an unset target, so rules without one
+// have a zero TargetPrice.
+func targetPrice(m money.Money) money.Money {
+	if m.Amount == 0 {
+		return money.Money{}
+	}
+	return m
+}
@@ -0,0 +1,61 @@
+package postgres_db
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolCollector exports the statistics of a connection pool to Prometheus.
+type PoolCollector struct {
+	pool *pgxpool.Pool
+}
+
+func NewPoolCollector(pool *pgxpool.Pool) *PoolCollector {
+	return &PoolCollector{pool: pool}
+}
+
+var (
+	poolConns = prometheus.NewDesc("postgres_pool_conns",
+		"Connections of the pool by state: acquired, idle or constructing.", []string{"state"}, nil)
+	poolMaxConns = prometheus.NewDesc("postgres_pool_max_conns",
+		"Maximum size of the pool.", nil, nil)
+	poolAcquires = prometheus.NewDesc("postgres_pool_acquires_total",
+		"Connections acquired from the pool.", nil, nil)
+	poolEmptyAcquires = prometheus.NewDesc("postgres_pool_empty_acquires_total",
+		"Acquires that had to wait for a connection because none was idle.", nil, nil)
+	poolCanceledAcquires = prometheus.NewDesc("postgres_pool_canceled_acquires_total",
+		"Acquires cancelled by their context while waiting.", nil, nil)
+	poolAcquireWait = prometheus.NewDesc("postgres_pool_acquire_wait_seconds_total",
+		"Time spent acquiring connections.", nil, nil)
+	poolNewConns = prometheus.NewDesc("postgres_pool_new_conns_total",
+		"Connections opened by the pool.", nil, nil)
+	poolDestroyedConns = prometheus.NewDesc("postgres_pool_destroyed_conns_total",
+		"Connections closed by the pool for exceeding a limit: lifetime or idle_time.", []string{"reason"}, nil)
+)
+
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolConns
+	ch <- poolMaxConns
+	ch <- poolAcquires
+	ch <- poolEmptyAcquires
+	ch <- poolCanceledAcquires
+	ch <- poolAcquireWait
+	ch <- poolNewConns
+	ch <- poolDestroyedConns
+}
+
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(poolConns, prometheus.GaugeValue, float64(stat.AcquiredConns()), "acquired")
+	ch <- prometheus.MustNewConstMetric(poolConns, prometheus.GaugeValue, float64(stat.IdleConns()), "idle")
+	ch <- prometheus.MustNewConstMetric(poolConns, prometheus.GaugeValue, float64(stat.ConstructingConns()), "constructing")
+	ch <- prometheus.MustNewConstMetric(poolMaxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(poolAcquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolEmptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolCanceledAcquires, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolAcquireWait, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(poolNewConns, prometheus.CounterValue, float64(stat.NewConnsCount()))
+	ch <- prometheus.MustNewConstMetric(poolDestroyedConns, prometheus.CounterValue, float64(stat.MaxLifetimeDestroyCount()), "lifetime")
+	ch <- prometheus.MustNewConstMetric(poolDestroyedConns, prometheus.CounterValue, float64(stat.MaxIdleDestroyCount()), "idle_time")
+}
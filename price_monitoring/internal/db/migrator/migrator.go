@@ -0,0 +1,93 @@
+// Package migrator applies the migrations of the tracker schema.
+package migrator
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/migrations"
+)
+
+// Schema is the schema the tracker keeps its tables in. The applied version
+// is recorded in its tracker_migrations table.
+const Schema = "tracker"
+
+type Migrator struct {
+	m *migrate.Migrate
+}
+
+// New prepares the migrations of the database at dsn, a keyword/value
+// connection string, and creates the tracker schema if it does not exist yet.
+func New(dsn string) (*Migrator, error) {
+	db, err := sql.Open("pgx", dsn+" search_path="+Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.Exec("CREATE SCHEMA IF NOT EXISTS " + Schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("cannot create schema %s: %w", Schema, err)
+	}
+
+	driver, err := pgx.WithInstance(db, &pgx.Config{MigrationsTable: Schema + "_migrations"})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		driver.Close()
+		return nil, err
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, "pgx", driver)
+	if err != nil {
+		driver.Close()
+		return nil, err
+	}
+
+	return &Migrator{m: m}, nil
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up() error {
+	if err := m.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Down reverts the last steps migrations, all of them if steps is not positive.
+func (m *Migrator) Down(steps int) error {
+	var err error
+	if steps > 0 {
+		err = m.m.Steps(-steps)
+	} else {
+		err = m.m.Down()
+	}
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Version returns the applied version, 0 if none is. dirty reports a
+// migration that failed halfway and has to be fixed by hand.
+func (m *Migrator) Version() (version uint, dirty bool, err error) {
+	version, dirty, err = m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+func (m *Migrator) Close() error {
+	sourceErr, dbErr := m.m.Close()
+	return errors.Join(sourceErr, dbErr)
+}
@@ -0,0 +1,117 @@
+package migrator
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The tests run against the database of TRACKER_TEST_POSTGRES_DSN, a
+// keyword/value connection string. They drop the auth and tracker schemas,
+// never point it at a database you want to keep.
+func testDSN(t *testing.T) string {
+	dsn := os.Getenv("TRACKER_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TRACKER_TEST_POSTGRES_DSN is not set")
+	}
+
+	db, err := sql.Open("pgx", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.Exec("DROP SCHEMA IF EXISTS tracker CASCADE; DROP SCHEMA IF EXISTS auth CASCADE")
+	require.NoError(t, err)
+
+	return dsn
+}
+
+func tableExists(t *testing.T, dsn, table string) bool {
+	db, err := sql.Open("pgx", dsn)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var name sql.NullString
+	require.NoError(t, db.QueryRow("SELECT to_regclass($1)::text", table).Scan(&name))
+	return name.Valid
+}
+
+func TestUpDown(t *testing.T) {
+	dsn := testDSN(t)
+
+	m, err := New(dsn)
+	require.NoError(t, err)
+	defer m.Close()
+
+	require.NoError(t, m.Up())
+	version, dirty, err := m.Version()
+	require.NoError(t, err)
+	assert.NotZero(t, version)
+	assert.False(t, dirty)
+	assert.True(t, tableExists(t, dsn, "tracker.subscriptions"))
+
+	require.NoError(t, m.Down(0))
+	version, _, err = m.Version()
+	require.NoError(t, err)
+	assert.Zero(t, version)
+	assert.False(t, tableExists(t, dsn, "tracker.products"))
+
+	require.NoError(t, m.Up(), "the down migrations leave nothing behind that blocks up")
+}
+
+func TestConvertsAuthItems(t *testing.T) {
+	dsn := testDSN(t)
+
+	db, err := sql.Open("pgx", dsn)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec(`CREATE SCHEMA auth;
+		CREATE TABLE auth.items(Id UUID PRIMARY KEY, user_id VARCHAR(100), link VARCHAR(100), product_name VARCHAR(100),
+			start_price REAL, current_price REAL, creation_date TIMESTAMP);
+		INSERT INTO auth.items VALUES
+			('00000000-0000-0000-0000-000000000001', 'user1', 'https://shop.ru/item?utm_source=tg', 'Item', 1499.99, 1299.5, '2025-01-01'),
+			('00000000-0000-0000-0000-000000000002', 'user1', 'https://SHOP.ru/item', 'Item', 1000, 1100, '2025-02-01'),
+			('00000000-0000-0000-0000-000000000003', 'user2', 'https://shop.ru/item', 'Item', 1100, 1100, '2025-03-01')`)
+	require.NoError(t, err)
+
+	m, err := New(dsn)
+	require.NoError(t, err)
+	defer m.Close()
+	require.NoError(t, m.Up())
+
+	var products int
+	require.NoError(t, db.QueryRow("SELECT count(*) FROM tracker.products WHERE link = 'https://shop.ru/item'").Scan(&products))
+	assert.Equal(t, 1, products, "the spellings of a link are one product")
+
+	var startPrice int64
+	require.NoError(t, db.QueryRow("SELECT start_price FROM tracker.subscriptions WHERE user_id = 'user1'").Scan(&startPrice))
+	assert.Equal(t, int64(149999), startPrice, "the first subscription of the user in kopecks")
+
+	var subscriptions int
+	require.NoError(t, db.QueryRow("SELECT count(*) FROM tracker.subscriptions").Scan(&subscriptions))
+	assert.Equal(t, 2, subscriptions)
+	assert.False(t, tableExists(t, dsn, "auth.items"))
+
+	require.NoError(t, m.Down(0))
+
+	var restored float32
+	require.NoError(t, db.QueryRow("SELECT start_price FROM auth.items WHERE user_id = 'user1'").Scan(&restored))
+	assert.Equal(t, float32(1499.99), restored, "the down migration gives the items back in rubles")
+}
+
+func TestAlertEventsOutliveRules(t *testing.T) {
+	dsn := testDSN(t)
+
+	m, err := New(dsn)
+	require.NoError(t, err)
+	defer m.Close()
+	require.NoError(t, m.Up())
+
+	db, err := sql.Open("pgx", dsn)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec(`INSERT INTO tracker.alert_events (id, rule_id, user_id, link, product_name, kind, price, created_at)
+		VALUES ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000002', 'user', 'https://shop.ru/item', 'Item', 1, 100, now())`)
+	assert.NoError(t, err, "the rule of an event may be deleted before the event is delivered")
+}
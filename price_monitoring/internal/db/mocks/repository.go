@@ -0,0 +1,1260 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	postgres_db "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
+	money "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+
+	time "time"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+type Repository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Repository) EXPECT() *Repository_Expecter {
+	return &Repository_Expecter{mock: &_m.Mock}
+}
+
+// DeleteAlertRuleFromDB provides a mock function with given fields: ctx, userId, id
+func (_m *Repository) DeleteAlertRuleFromDB(ctx context.Context, userId string, id string) error {
+	ret := _m.Called(ctx, userId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAlertRuleFromDB")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_DeleteAlertRuleFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAlertRuleFromDB'
+type Repository_DeleteAlertRuleFromDB_Call struct {
+	*mock.Call
+}
+
+// DeleteAlertRuleFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - id string
+func (_e *Repository_Expecter) DeleteAlertRuleFromDB(ctx interface{}, userId interface{}, id interface{}) *Repository_DeleteAlertRuleFromDB_Call {
+	return &Repository_DeleteAlertRuleFromDB_Call{Call: _e.mock.On("DeleteAlertRuleFromDB", ctx, userId, id)}
+}
+
+func (_c *Repository_DeleteAlertRuleFromDB_Call) Run(run func(ctx context.Context, userId string, id string)) *Repository_DeleteAlertRuleFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteAlertRuleFromDB_Call) Return(_a0 error) *Repository_DeleteAlertRuleFromDB_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_DeleteAlertRuleFromDB_Call) RunAndReturn(run func(context.Context, string, string) error) *Repository_DeleteAlertRuleFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSubscriptionFromDB provides a mock function with given fields: ctx, userId, id
+func (_m *Repository) DeleteSubscriptionFromDB(ctx context.Context, userId string, id string) error {
+	ret := _m.Called(ctx, userId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSubscriptionFromDB")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_DeleteSubscriptionFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSubscriptionFromDB'
+type Repository_DeleteSubscriptionFromDB_Call struct {
+	*mock.Call
+}
+
+// DeleteSubscriptionFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - id string
+func (_e *Repository_Expecter) DeleteSubscriptionFromDB(ctx interface{}, userId interface{}, id interface{}) *Repository_DeleteSubscriptionFromDB_Call {
+	return &Repository_DeleteSubscriptionFromDB_Call{Call: _e.mock.On("DeleteSubscriptionFromDB", ctx, userId, id)}
+}
+
+func (_c *Repository_DeleteSubscriptionFromDB_Call) Run(run func(ctx context.Context, userId string, id string)) *Repository_DeleteSubscriptionFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteSubscriptionFromDB_Call) Return(_a0 error) *Repository_DeleteSubscriptionFromDB_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_DeleteSubscriptionFromDB_Call) RunAndReturn(run func(context.Context, string, string) error) *Repository_DeleteSubscriptionFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhookFromDB provides a mock function with given fields: ctx, userId, id
+func (_m *Repository) DeleteWebhookFromDB(ctx context.Context, userId string, id string) error {
+	ret := _m.Called(ctx, userId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhookFromDB")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_DeleteWebhookFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhookFromDB'
+type Repository_DeleteWebhookFromDB_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhookFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - id string
+func (_e *Repository_Expecter) DeleteWebhookFromDB(ctx interface{}, userId interface{}, id interface{}) *Repository_DeleteWebhookFromDB_Call {
+	return &Repository_DeleteWebhookFromDB_Call{Call: _e.mock.On("DeleteWebhookFromDB", ctx, userId, id)}
+}
+
+func (_c *Repository_DeleteWebhookFromDB_Call) Run(run func(ctx context.Context, userId string, id string)) *Repository_DeleteWebhookFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteWebhookFromDB_Call) Return(_a0 error) *Repository_DeleteWebhookFromDB_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_DeleteWebhookFromDB_Call) RunAndReturn(run func(context.Context, string, string) error) *Repository_DeleteWebhookFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertAlertEventsFromDB provides a mock function with given fields: ctx, events
+func (_m *Repository) InsertAlertEventsFromDB(ctx context.Context, events []postgres_db.AlertEvent) error {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertAlertEventsFromDB")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []postgres_db.AlertEvent) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_InsertAlertEventsFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertAlertEventsFromDB'
+type Repository_InsertAlertEventsFromDB_Call struct {
+	*mock.Call
+}
+
+// InsertAlertEventsFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - events []postgres_db.AlertEvent
+func (_e *Repository_Expecter) InsertAlertEventsFromDB(ctx interface{}, events interface{}) *Repository_InsertAlertEventsFromDB_Call {
+	return &Repository_InsertAlertEventsFromDB_Call{Call: _e.mock.On("InsertAlertEventsFromDB", ctx, events)}
+}
+
+func (_c *Repository_InsertAlertEventsFromDB_Call) Run(run func(ctx context.Context, events []postgres_db.AlertEvent)) *Repository_InsertAlertEventsFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]postgres_db.AlertEvent))
+	})
+	return _c
+}
+
+func (_c *Repository_InsertAlertEventsFromDB_Call) Return(_a0 error) *Repository_InsertAlertEventsFromDB_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_InsertAlertEventsFromDB_Call) RunAndReturn(run func(context.Context, []postgres_db.AlertEvent) error) *Repository_InsertAlertEventsFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertAlertRuleFromDB provides a mock function with given fields: ctx, userId, rule
+func (_m *Repository) InsertAlertRuleFromDB(ctx context.Context, userId string, rule postgres_db.AlertRule) (postgres_db.AlertRule, error) {
+	ret := _m.Called(ctx, userId, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertAlertRuleFromDB")
+	}
+
+	var r0 postgres_db.AlertRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, postgres_db.AlertRule) (postgres_db.AlertRule, error)); ok {
+		return rf(ctx, userId, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, postgres_db.AlertRule) postgres_db.AlertRule); ok {
+		r0 = rf(ctx, userId, rule)
+	} else {
+		r0 = ret.Get(0).(postgres_db.AlertRule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, postgres_db.AlertRule) error); ok {
+		r1 = rf(ctx, userId, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_InsertAlertRuleFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertAlertRuleFromDB'
+type Repository_InsertAlertRuleFromDB_Call struct {
+	*mock.Call
+}
+
+// InsertAlertRuleFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - rule postgres_db.AlertRule
+func (_e *Repository_Expecter) InsertAlertRuleFromDB(ctx interface{}, userId interface{}, rule interface{}) *Repository_InsertAlertRuleFromDB_Call {
+	return &Repository_InsertAlertRuleFromDB_Call{Call: _e.mock.On("InsertAlertRuleFromDB", ctx, userId, rule)}
+}
+
+func (_c *Repository_InsertAlertRuleFromDB_Call) Run(run func(ctx context.Context, userId string, rule postgres_db.AlertRule)) *Repository_InsertAlertRuleFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(postgres_db.AlertRule))
+	})
+	return _c
+}
+
+func (_c *Repository_InsertAlertRuleFromDB_Call) Return(_a0 postgres_db.AlertRule, _a1 error) *Repository_InsertAlertRuleFromDB_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_InsertAlertRuleFromDB_Call) RunAndReturn(run func(context.Context, string, postgres_db.AlertRule) (postgres_db.AlertRule, error)) *Repository_InsertAlertRuleFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertSubscriptionFromDB provides a mock function with given fields: ctx, userId, link, variantId, name, price
+func (_m *Repository) InsertSubscriptionFromDB(ctx context.Context, userId string, link string, variantId string, name string, price money.Money) (string, error) {
+	ret := _m.Called(ctx, userId, link, variantId, name, price)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertSubscriptionFromDB")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, money.Money) (string, error)); ok {
+		return rf(ctx, userId, link, variantId, name, price)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, money.Money) string); ok {
+		r0 = rf(ctx, userId, link, variantId, name, price)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, money.Money) error); ok {
+		r1 = rf(ctx, userId, link, variantId, name, price)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_InsertSubscriptionFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertSubscriptionFromDB'
+type Repository_InsertSubscriptionFromDB_Call struct {
+	*mock.Call
+}
+
+// InsertSubscriptionFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - link string
+//   - variantId string
+//   - name string
+//   - price money.Money
+func (_e *Repository_Expecter) InsertSubscriptionFromDB(ctx interface{}, userId interface{}, link interface{}, variantId interface{}, name interface{}, price interface{}) *Repository_InsertSubscriptionFromDB_Call {
+	return &Repository_InsertSubscriptionFromDB_Call{Call: _e.mock.On("InsertSubscriptionFromDB", ctx, userId, link, variantId, name, price)}
+}
+
+func (_c *Repository_InsertSubscriptionFromDB_Call) Run(run func(ctx context.Context, userId string, link string, variantId string, name string, price money.Money)) *Repository_InsertSubscriptionFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string), args[5].(money.Money))
+	})
+	return _c
+}
+
+func (_c *Repository_InsertSubscriptionFromDB_Call) Return(_a0 string, _a1 error) *Repository_InsertSubscriptionFromDB_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_InsertSubscriptionFromDB_Call) RunAndReturn(run func(context.Context, string, string, string, string, money.Money) (string, error)) *Repository_InsertSubscriptionFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertWebhookFromDB provides a mock function with given fields: ctx, webhook
+func (_m *Repository) InsertWebhookFromDB(ctx context.Context, webhook postgres_db.Webhook) (postgres_db.Webhook, error) {
+	ret := _m.Called(ctx, webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertWebhookFromDB")
+	}
+
+	var r0 postgres_db.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, postgres_db.Webhook) (postgres_db.Webhook, error)); ok {
+		return rf(ctx, webhook)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, postgres_db.Webhook) postgres_db.Webhook); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Get(0).(postgres_db.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, postgres_db.Webhook) error); ok {
+		r1 = rf(ctx, webhook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_InsertWebhookFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertWebhookFromDB'
+type Repository_InsertWebhookFromDB_Call struct {
+	*mock.Call
+}
+
+// InsertWebhookFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhook postgres_db.Webhook
+func (_e *Repository_Expecter) InsertWebhookFromDB(ctx interface{}, webhook interface{}) *Repository_InsertWebhookFromDB_Call {
+	return &Repository_InsertWebhookFromDB_Call{Call: _e.mock.On("InsertWebhookFromDB", ctx, webhook)}
+}
+
+func (_c *Repository_InsertWebhookFromDB_Call) Run(run func(ctx context.Context, webhook postgres_db.Webhook)) *Repository_InsertWebhookFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(postgres_db.Webhook))
+	})
+	return _c
+}
+
+func (_c *Repository_InsertWebhookFromDB_Call) Return(_a0 postgres_db.Webhook, _a1 error) *Repository_InsertWebhookFromDB_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_InsertWebhookFromDB_Call) RunAndReturn(run func(context.Context, postgres_db.Webhook) (postgres_db.Webhook, error)) *Repository_InsertWebhookFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkAlertEventsDeliveredFromDB provides a mock function with given fields: ctx, ids
+func (_m *Repository) MarkAlertEventsDeliveredFromDB(ctx context.Context, ids []string) error {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkAlertEventsDeliveredFromDB")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_MarkAlertEventsDeliveredFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkAlertEventsDeliveredFromDB'
+type Repository_MarkAlertEventsDeliveredFromDB_Call struct {
+	*mock.Call
+}
+
+// MarkAlertEventsDeliveredFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+func (_e *Repository_Expecter) MarkAlertEventsDeliveredFromDB(ctx interface{}, ids interface{}) *Repository_MarkAlertEventsDeliveredFromDB_Call {
+	return &Repository_MarkAlertEventsDeliveredFromDB_Call{Call: _e.mock.On("MarkAlertEventsDeliveredFromDB", ctx, ids)}
+}
+
+func (_c *Repository_MarkAlertEventsDeliveredFromDB_Call) Run(run func(ctx context.Context, ids []string)) *Repository_MarkAlertEventsDeliveredFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *Repository_MarkAlertEventsDeliveredFromDB_Call) Return(_a0 error) *Repository_MarkAlertEventsDeliveredFromDB_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_MarkAlertEventsDeliveredFromDB_Call) RunAndReturn(run func(context.Context, []string) error) *Repository_MarkAlertEventsDeliveredFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordObservationFromDB provides a mock function with given fields: ctx, link, name, details, point, match
+func (_m *Repository) RecordObservationFromDB(ctx context.Context, link string, name string, details postgres_db.ProductDetails, point postgres_db.PricePoint, match postgres_db.AlertMatcher) error {
+	ret := _m.Called(ctx, link, name, details, point, match)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordObservationFromDB")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, postgres_db.ProductDetails, postgres_db.PricePoint, postgres_db.AlertMatcher) error); ok {
+		r0 = rf(ctx, link, name, details, point, match)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_RecordObservationFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordObservationFromDB'
+type Repository_RecordObservationFromDB_Call struct {
+	*mock.Call
+}
+
+// RecordObservationFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - link string
+//   - name string
+//   - details postgres_db.ProductDetails
+//   - point postgres_db.PricePoint
+//   - match postgres_db.AlertMatcher
+func (_e *Repository_Expecter) RecordObservationFromDB(ctx interface{}, link interface{}, name interface{}, details interface{}, point interface{}, match interface{}) *Repository_RecordObservationFromDB_Call {
+	return &Repository_RecordObservationFromDB_Call{Call: _e.mock.On("RecordObservationFromDB", ctx, link, name, details, point, match)}
+}
+
+func (_c *Repository_RecordObservationFromDB_Call) Run(run func(ctx context.Context, link string, name string, details postgres_db.ProductDetails, point postgres_db.PricePoint, match postgres_db.AlertMatcher)) *Repository_RecordObservationFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(postgres_db.ProductDetails), args[4].(postgres_db.PricePoint), args[5].(postgres_db.AlertMatcher))
+	})
+	return _c
+}
+
+func (_c *Repository_RecordObservationFromDB_Call) Return(_a0 error) *Repository_RecordObservationFromDB_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_RecordObservationFromDB_Call) RunAndReturn(run func(context.Context, string, string, postgres_db.ProductDetails, postgres_db.PricePoint, postgres_db.AlertMatcher) error) *Repository_RecordObservationFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplayOutboxEventsFromDB provides a mock function with given fields: ctx, ids
+func (_m *Repository) ReplayOutboxEventsFromDB(ctx context.Context, ids []string) (int64, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayOutboxEventsFromDB")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (int64, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) int64); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ReplayOutboxEventsFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplayOutboxEventsFromDB'
+type Repository_ReplayOutboxEventsFromDB_Call struct {
+	*mock.Call
+}
+
+// ReplayOutboxEventsFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+func (_e *Repository_Expecter) ReplayOutboxEventsFromDB(ctx interface{}, ids interface{}) *Repository_ReplayOutboxEventsFromDB_Call {
+	return &Repository_ReplayOutboxEventsFromDB_Call{Call: _e.mock.On("ReplayOutboxEventsFromDB", ctx, ids)}
+}
+
+func (_c *Repository_ReplayOutboxEventsFromDB_Call) Run(run func(ctx context.Context, ids []string)) *Repository_ReplayOutboxEventsFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *Repository_ReplayOutboxEventsFromDB_Call) Return(_a0 int64, _a1 error) *Repository_ReplayOutboxEventsFromDB_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ReplayOutboxEventsFromDB_Call) RunAndReturn(run func(context.Context, []string) (int64, error)) *Repository_ReplayOutboxEventsFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectAlertRulesFromDB provides a mock function with given fields: ctx, userId, subscriptionId
+func (_m *Repository) SelectAlertRulesFromDB(ctx context.Context, userId string, subscriptionId string) ([]postgres_db.AlertRule, error) {
+	ret := _m.Called(ctx, userId, subscriptionId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectAlertRulesFromDB")
+	}
+
+	var r0 []postgres_db.AlertRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]postgres_db.AlertRule, error)); ok {
+		return rf(ctx, userId, subscriptionId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []postgres_db.AlertRule); ok {
+		r0 = rf(ctx, userId, subscriptionId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]postgres_db.AlertRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, subscriptionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_SelectAlertRulesFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectAlertRulesFromDB'
+type Repository_SelectAlertRulesFromDB_Call struct {
+	*mock.Call
+}
+
+// SelectAlertRulesFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - subscriptionId string
+func (_e *Repository_Expecter) SelectAlertRulesFromDB(ctx interface{}, userId interface{}, subscriptionId interface{}) *Repository_SelectAlertRulesFromDB_Call {
+	return &Repository_SelectAlertRulesFromDB_Call{Call: _e.mock.On("SelectAlertRulesFromDB", ctx, userId, subscriptionId)}
+}
+
+func (_c *Repository_SelectAlertRulesFromDB_Call) Run(run func(ctx context.Context, userId string, subscriptionId string)) *Repository_SelectAlertRulesFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_SelectAlertRulesFromDB_Call) Return(_a0 []postgres_db.AlertRule, _a1 error) *Repository_SelectAlertRulesFromDB_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_SelectAlertRulesFromDB_Call) RunAndReturn(run func(context.Context, string, string) ([]postgres_db.AlertRule, error)) *Repository_SelectAlertRulesFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectDisplayCurrencyFromDB provides a mock function with given fields: ctx, userId
+func (_m *Repository) SelectDisplayCurrencyFromDB(ctx context.Context, userId string) (string, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectDisplayCurrencyFromDB")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_SelectDisplayCurrencyFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectDisplayCurrencyFromDB'
+type Repository_SelectDisplayCurrencyFromDB_Call struct {
+	*mock.Call
+}
+
+// SelectDisplayCurrencyFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+func (_e *Repository_Expecter) SelectDisplayCurrencyFromDB(ctx interface{}, userId interface{}) *Repository_SelectDisplayCurrencyFromDB_Call {
+	return &Repository_SelectDisplayCurrencyFromDB_Call{Call: _e.mock.On("SelectDisplayCurrencyFromDB", ctx, userId)}
+}
+
+func (_c *Repository_SelectDisplayCurrencyFromDB_Call) Run(run func(ctx context.Context, userId string)) *Repository_SelectDisplayCurrencyFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_SelectDisplayCurrencyFromDB_Call) Return(_a0 string, _a1 error) *Repository_SelectDisplayCurrencyFromDB_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_SelectDisplayCurrencyFromDB_Call) RunAndReturn(run func(context.Context, string) (string, error)) *Repository_SelectDisplayCurrencyFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectPendingAlertEventsFromDB provides a mock function with given fields: ctx, limit
+func (_m *Repository) SelectPendingAlertEventsFromDB(ctx context.Context, limit int) ([]postgres_db.AlertEvent, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectPendingAlertEventsFromDB")
+	}
+
+	var r0 []postgres_db.AlertEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]postgres_db.AlertEvent, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []postgres_db.AlertEvent); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]postgres_db.AlertEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_SelectPendingAlertEventsFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectPendingAlertEventsFromDB'
+type Repository_SelectPendingAlertEventsFromDB_Call struct {
+	*mock.Call
+}
+
+// SelectPendingAlertEventsFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *Repository_Expecter) SelectPendingAlertEventsFromDB(ctx interface{}, limit interface{}) *Repository_SelectPendingAlertEventsFromDB_Call {
+	return &Repository_SelectPendingAlertEventsFromDB_Call{Call: _e.mock.On("SelectPendingAlertEventsFromDB", ctx, limit)}
+}
+
+func (_c *Repository_SelectPendingAlertEventsFromDB_Call) Run(run func(ctx context.Context, limit int)) *Repository_SelectPendingAlertEventsFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Repository_SelectPendingAlertEventsFromDB_Call) Return(_a0 []postgres_db.AlertEvent, _a1 error) *Repository_SelectPendingAlertEventsFromDB_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_SelectPendingAlertEventsFromDB_Call) RunAndReturn(run func(context.Context, int) ([]postgres_db.AlertEvent, error)) *Repository_SelectPendingAlertEventsFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectPriceHistoryFromDB provides a mock function with given fields: ctx, link, from, to
+func (_m *Repository) SelectPriceHistoryFromDB(ctx context.Context, link string, from time.Time, to time.Time) ([]postgres_db.PricePoint, error) {
+	ret := _m.Called(ctx, link, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectPriceHistoryFromDB")
+	}
+
+	var r0 []postgres_db.PricePoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) ([]postgres_db.PricePoint, error)); ok {
+		return rf(ctx, link, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) []postgres_db.PricePoint); ok {
+		r0 = rf(ctx, link, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]postgres_db.PricePoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, link, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_SelectPriceHistoryFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectPriceHistoryFromDB'
+type Repository_SelectPriceHistoryFromDB_Call struct {
+	*mock.Call
+}
+
+// SelectPriceHistoryFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - link string
+//   - from time.Time
+//   - to time.Time
+func (_e *Repository_Expecter) SelectPriceHistoryFromDB(ctx interface{}, link interface{}, from interface{}, to interface{}) *Repository_SelectPriceHistoryFromDB_Call {
+	return &Repository_SelectPriceHistoryFromDB_Call{Call: _e.mock.On("SelectPriceHistoryFromDB", ctx, link, from, to)}
+}
+
+func (_c *Repository_SelectPriceHistoryFromDB_Call) Run(run func(ctx context.Context, link string, from time.Time, to time.Time)) *Repository_SelectPriceHistoryFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Repository_SelectPriceHistoryFromDB_Call) Return(_a0 []postgres_db.PricePoint, _a1 error) *Repository_SelectPriceHistoryFromDB_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_SelectPriceHistoryFromDB_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Time) ([]postgres_db.PricePoint, error)) *Repository_SelectPriceHistoryFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectSubscriptionFromDB provides a mock function with given fields: ctx, userId, link
+func (_m *Repository) SelectSubscriptionFromDB(ctx context.Context, userId string, link string) (postgres_db.Subscription, error) {
+	ret := _m.Called(ctx, userId, link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectSubscriptionFromDB")
+	}
+
+	var r0 postgres_db.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (postgres_db.Subscription, error)); ok {
+		return rf(ctx, userId, link)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) postgres_db.Subscription); ok {
+		r0 = rf(ctx, userId, link)
+	} else {
+		r0 = ret.Get(0).(postgres_db.Subscription)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_SelectSubscriptionFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectSubscriptionFromDB'
+type Repository_SelectSubscriptionFromDB_Call struct {
+	*mock.Call
+}
+
+// SelectSubscriptionFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - link string
+func (_e *Repository_Expecter) SelectSubscriptionFromDB(ctx interface{}, userId interface{}, link interface{}) *Repository_SelectSubscriptionFromDB_Call {
+	return &Repository_SelectSubscriptionFromDB_Call{Call: _e.mock.On("SelectSubscriptionFromDB", ctx, userId, link)}
+}
+
+func (_c *Repository_SelectSubscriptionFromDB_Call) Run(run func(ctx context.Context, userId string, link string)) *Repository_SelectSubscriptionFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_SelectSubscriptionFromDB_Call) Return(_a0 postgres_db.Subscription, _a1 error) *Repository_SelectSubscriptionFromDB_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_SelectSubscriptionFromDB_Call) RunAndReturn(run func(context.Context, string, string) (postgres_db.Subscription, error)) *Repository_SelectSubscriptionFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectSubscriptionsFromDB provides a mock function with given fields: ctx, userId, page
+func (_m *Repository) SelectSubscriptionsFromDB(ctx context.Context, userId string, page postgres_db.Page) ([]postgres_db.Subscription, error) {
+	ret := _m.Called(ctx, userId, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectSubscriptionsFromDB")
+	}
+
+	var r0 []postgres_db.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, postgres_db.Page) ([]postgres_db.Subscription, error)); ok {
+		return rf(ctx, userId, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, postgres_db.Page) []postgres_db.Subscription); ok {
+		r0 = rf(ctx, userId, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]postgres_db.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, postgres_db.Page) error); ok {
+		r1 = rf(ctx, userId, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_SelectSubscriptionsFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectSubscriptionsFromDB'
+type Repository_SelectSubscriptionsFromDB_Call struct {
+	*mock.Call
+}
+
+// SelectSubscriptionsFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - page postgres_db.Page
+func (_e *Repository_Expecter) SelectSubscriptionsFromDB(ctx interface{}, userId interface{}, page interface{}) *Repository_SelectSubscriptionsFromDB_Call {
+	return &Repository_SelectSubscriptionsFromDB_Call{Call: _e.mock.On("SelectSubscriptionsFromDB", ctx, userId, page)}
+}
+
+func (_c *Repository_SelectSubscriptionsFromDB_Call) Run(run func(ctx context.Context, userId string, page postgres_db.Page)) *Repository_SelectSubscriptionsFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(postgres_db.Page))
+	})
+	return _c
+}
+
+func (_c *Repository_SelectSubscriptionsFromDB_Call) Return(_a0 []postgres_db.Subscription, _a1 error) *Repository_SelectSubscriptionsFromDB_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_SelectSubscriptionsFromDB_Call) RunAndReturn(run func(context.Context, string, postgres_db.Page) ([]postgres_db.Subscription, error)) *Repository_SelectSubscriptionsFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectTrackedLinksFromDB provides a mock function with given fields: ctx
+func (_m *Repository) SelectTrackedLinksFromDB(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectTrackedLinksFromDB")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_SelectTrackedLinksFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectTrackedLinksFromDB'
+type Repository_SelectTrackedLinksFromDB_Call struct {
+	*mock.Call
+}
+
+// SelectTrackedLinksFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) SelectTrackedLinksFromDB(ctx interface{}) *Repository_SelectTrackedLinksFromDB_Call {
+	return &Repository_SelectTrackedLinksFromDB_Call{Call: _e.mock.On("SelectTrackedLinksFromDB", ctx)}
+}
+
+func (_c *Repository_SelectTrackedLinksFromDB_Call) Run(run func(ctx context.Context)) *Repository_SelectTrackedLinksFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_SelectTrackedLinksFromDB_Call) Return(_a0 []string, _a1 error) *Repository_SelectTrackedLinksFromDB_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_SelectTrackedLinksFromDB_Call) RunAndReturn(run func(context.Context) ([]string, error)) *Repository_SelectTrackedLinksFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectWebhookDeliveriesFromDB provides a mock function with given fields: ctx, userId, webhookId, limit
+func (_m *Repository) SelectWebhookDeliveriesFromDB(ctx context.Context, userId string, webhookId string, limit int) ([]postgres_db.WebhookDelivery, error) {
+	ret := _m.Called(ctx, userId, webhookId, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectWebhookDeliveriesFromDB")
+	}
+
+	var r0 []postgres_db.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) ([]postgres_db.WebhookDelivery, error)); ok {
+		return rf(ctx, userId, webhookId, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) []postgres_db.WebhookDelivery); ok {
+		r0 = rf(ctx, userId, webhookId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]postgres_db.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, userId, webhookId, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_SelectWebhookDeliveriesFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectWebhookDeliveriesFromDB'
+type Repository_SelectWebhookDeliveriesFromDB_Call struct {
+	*mock.Call
+}
+
+// SelectWebhookDeliveriesFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - webhookId string
+//   - limit int
+func (_e *Repository_Expecter) SelectWebhookDeliveriesFromDB(ctx interface{}, userId interface{}, webhookId interface{}, limit interface{}) *Repository_SelectWebhookDeliveriesFromDB_Call {
+	return &Repository_SelectWebhookDeliveriesFromDB_Call{Call: _e.mock.On("SelectWebhookDeliveriesFromDB", ctx, userId, webhookId, limit)}
+}
+
+func (_c *Repository_SelectWebhookDeliveriesFromDB_Call) Run(run func(ctx context.Context, userId string, webhookId string, limit int)) *Repository_SelectWebhookDeliveriesFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *Repository_SelectWebhookDeliveriesFromDB_Call) Return(_a0 []postgres_db.WebhookDelivery, _a1 error) *Repository_SelectWebhookDeliveriesFromDB_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_SelectWebhookDeliveriesFromDB_Call) RunAndReturn(run func(context.Context, string, string, int) ([]postgres_db.WebhookDelivery, error)) *Repository_SelectWebhookDeliveriesFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectWebhooksFromDB provides a mock function with given fields: ctx, userId
+func (_m *Repository) SelectWebhooksFromDB(ctx context.Context, userId string) ([]postgres_db.Webhook, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectWebhooksFromDB")
+	}
+
+	var r0 []postgres_db.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]postgres_db.Webhook, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []postgres_db.Webhook); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]postgres_db.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_SelectWebhooksFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectWebhooksFromDB'
+type Repository_SelectWebhooksFromDB_Call struct {
+	*mock.Call
+}
+
+// SelectWebhooksFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+func (_e *Repository_Expecter) SelectWebhooksFromDB(ctx interface{}, userId interface{}) *Repository_SelectWebhooksFromDB_Call {
+	return &Repository_SelectWebhooksFromDB_Call{Call: _e.mock.On("SelectWebhooksFromDB", ctx, userId)}
+}
+
+func (_c *Repository_SelectWebhooksFromDB_Call) Run(run func(ctx context.Context, userId string)) *Repository_SelectWebhooksFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_SelectWebhooksFromDB_Call) Return(_a0 []postgres_db.Webhook, _a1 error) *Repository_SelectWebhooksFromDB_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_SelectWebhooksFromDB_Call) RunAndReturn(run func(context.Context, string) ([]postgres_db.Webhook, error)) *Repository_SelectWebhooksFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubscribedFromDB provides a mock function with given fields: ctx, userId, link
+func (_m *Repository) SubscribedFromDB(ctx context.Context, userId string, link string) (bool, error) {
+	ret := _m.Called(ctx, userId, link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribedFromDB")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, userId, link)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userId, link)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_SubscribedFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribedFromDB'
+type Repository_SubscribedFromDB_Call struct {
+	*mock.Call
+}
+
+// SubscribedFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - link string
+func (_e *Repository_Expecter) SubscribedFromDB(ctx interface{}, userId interface{}, link interface{}) *Repository_SubscribedFromDB_Call {
+	return &Repository_SubscribedFromDB_Call{Call: _e.mock.On("SubscribedFromDB", ctx, userId, link)}
+}
+
+func (_c *Repository_SubscribedFromDB_Call) Run(run func(ctx context.Context, userId string, link string)) *Repository_SubscribedFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_SubscribedFromDB_Call) Return(_a0 bool, _a1 error) *Repository_SubscribedFromDB_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_SubscribedFromDB_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *Repository_SubscribedFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSubscriptionFromDB provides a mock function with given fields: ctx, userId, id, update
+func (_m *Repository) UpdateSubscriptionFromDB(ctx context.Context, userId string, id string, update postgres_db.SubscriptionUpdate) (postgres_db.Subscription, error) {
+	ret := _m.Called(ctx, userId, id, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSubscriptionFromDB")
+	}
+
+	var r0 postgres_db.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, postgres_db.SubscriptionUpdate) (postgres_db.Subscription, error)); ok {
+		return rf(ctx, userId, id, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, postgres_db.SubscriptionUpdate) postgres_db.Subscription); ok {
+		r0 = rf(ctx, userId, id, update)
+	} else {
+		r0 = ret.Get(0).(postgres_db.Subscription)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, postgres_db.SubscriptionUpdate) error); ok {
+		r1 = rf(ctx, userId, id, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_UpdateSubscriptionFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSubscriptionFromDB'
+type Repository_UpdateSubscriptionFromDB_Call struct {
+	*mock.Call
+}
+
+// UpdateSubscriptionFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - id string
+//   - update postgres_db.SubscriptionUpdate
+func (_e *Repository_Expecter) UpdateSubscriptionFromDB(ctx interface{}, userId interface{}, id interface{}, update interface{}) *Repository_UpdateSubscriptionFromDB_Call {
+	return &Repository_UpdateSubscriptionFromDB_Call{Call: _e.mock.On("UpdateSubscriptionFromDB", ctx, userId, id, update)}
+}
+
+func (_c *Repository_UpdateSubscriptionFromDB_Call) Run(run func(ctx context.Context, userId string, id string, update postgres_db.SubscriptionUpdate)) *Repository_UpdateSubscriptionFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(postgres_db.SubscriptionUpdate))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateSubscriptionFromDB_Call) Return(_a0 postgres_db.Subscription, _a1 error) *Repository_UpdateSubscriptionFromDB_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_UpdateSubscriptionFromDB_Call) RunAndReturn(run func(context.Context, string, string, postgres_db.SubscriptionUpdate) (postgres_db.Subscription, error)) *Repository_UpdateSubscriptionFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertDisplayCurrencyFromDB provides a mock function with given fields: ctx, userId, currency
+func (_m *Repository) UpsertDisplayCurrencyFromDB(ctx context.Context, userId string, currency string) error {
+	ret := _m.Called(ctx, userId, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertDisplayCurrencyFromDB")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, currency)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_UpsertDisplayCurrencyFromDB_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertDisplayCurrencyFromDB'
+type Repository_UpsertDisplayCurrencyFromDB_Call struct {
+	*mock.Call
+}
+
+// UpsertDisplayCurrencyFromDB is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - currency string
+func (_e *Repository_Expecter) UpsertDisplayCurrencyFromDB(ctx interface{}, userId interface{}, currency interface{}) *Repository_UpsertDisplayCurrencyFromDB_Call {
+	return &Repository_UpsertDisplayCurrencyFromDB_Call{Call: _e.mock.On("UpsertDisplayCurrencyFromDB", ctx, userId, currency)}
+}
+
+func (_c *Repository_UpsertDisplayCurrencyFromDB_Call) Run(run func(ctx context.Context, userId string, currency string)) *Repository_UpsertDisplayCurrencyFromDB_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_UpsertDisplayCurrencyFromDB_Call) Return(_a0 error) *Repository_UpsertDisplayCurrencyFromDB_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_UpsertDisplayCurrencyFromDB_Call) RunAndReturn(run func(context.Context, string, string) error) *Repository_UpsertDisplayCurrencyFromDB_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,147 @@
+package postgres_db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+)
+
+const (
+	TopicAlert        = "alert"
+	TopicPriceChanged = "price_changed"
+)
+
+const (
+	OutboxPending int16 = iota
+	OutboxSent
+	OutboxDead
+)
+
+type OutboxEvent struct {
+	Id        string
+	Topic     string
+	Payload   []byte
+	Attempts  int
+	CreatedAt time.Time
+	// DeliveredSinks are the names of the sinks that accepted the event in
+	// earlier attempts.
+	DeliveredSinks []string
+}
+
+// PriceChange is the payload of TopicPriceChanged events.
+type PriceChange struct {
+	Link          string       `json:"link"`
+	Name          string       `json:"name"`
+	Price         money.Money  `json:"price"`
+	PreviousPrice *money.Money `json:"previous_price,omitempty"`
+	Stock         int16        `json:"stock"`
+	PreviousStock *int16       `json:"previous_stock,omitempty"`
+	ObservedAt    time.Time    `json:"observed_at"`
+}
+
+func insertOutbox(ctx context.Context, tx *sql.Tx, topic string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO tracker.outbox (id, topic, payload, next_attempt_at, created_at) VALUES ($1, $2, $3, $4, $4)`,
+		uuid.New().String(), topic, data, now)
+
+	return err
+}
+
+// ClaimOutboxEventsFromDB returns up to limit pending events that are due and
+// hides them from other relays for lease, so that an event is processed by one
+// relay at a time and picked up again if that relay dies.
+func (db *DBConn) ClaimOutboxEventsFromDB(ctx context.Context, limit int, lease time.Duration) ([]OutboxEvent, error) {
+
+	rows, err := db.Conn.QueryContext(ctx,
+		`UPDATE tracker.outbox SET next_attempt_at = $3
+		WHERE id IN (
+			SELECT id FROM tracker.outbox WHERE status = $1 AND next_attempt_at <= now()
+			ORDER BY next_attempt_at LIMIT $2 FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, topic, payload, attempts, created_at, to_json(delivered_sinks)`,
+		OutboxPending, limit, time.Now().Add(lease))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []OutboxEvent
+	for rows.Next() {
+		var (
+			event OutboxEvent
+			sinks []byte
+		)
+		if err := rows.Scan(&event.Id, &event.Topic, &event.Payload, &event.Attempts, &event.CreatedAt, &sinks); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(sinks, &event.DeliveredSinks); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+func (db *DBConn) MarkOutboxSentFromDB(ctx context.Context, id string) error {
+
+	_, err := db.Conn.ExecContext(ctx,
+		"UPDATE tracker.outbox SET status = $2, sent_at = $3, last_error = NULL WHERE id = $1",
+		id, OutboxSent, time.Now())
+
+	return err
+}
+
+// MarkOutboxSinkDeliveredFromDB records that sink accepted the event, it is
+// skipped when a failure of another sink makes the event go out again.
+func (db *DBConn) MarkOutboxSinkDeliveredFromDB(ctx context.Context, id string, sink string) error {
+
+	_, err := db.Conn.ExecContext(ctx,
+		`UPDATE tracker.outbox SET delivered_sinks = array_append(delivered_sinks, $2)
+		WHERE id = $1 AND NOT $2 = ANY(delivered_sinks)`,
+		id, sink)
+
+	return err
+}
+
+// MarkOutboxFailedFromDB records a failed attempt. The event is retried at
+// retryAt, or moved to the dead letter state if dead is set.
+func (db *DBConn) MarkOutboxFailedFromDB(ctx context.Context, id string, lastErr string, retryAt time.Time, dead bool) error {
+
+	status := OutboxPending
+	if dead {
+		status = OutboxDead
+	}
+
+	_, err := db.Conn.ExecContext(ctx,
+		`UPDATE tracker.outbox SET status = $2, attempts = attempts + 1, last_error = $3, next_attempt_at = $4
+		WHERE id = $1`,
+		id, status, lastErr, retryAt)
+
+	return err
+}
+
+// ReplayOutboxEventsFromDB moves dead events back to pending with a fresh
+// retry budget. An empty ids replays every dead event.
+func (db *DBConn) ReplayOutboxEventsFromDB(ctx context.Context, ids []string) (int64, error) {
+
+	res, err := db.Conn.ExecContext(ctx,
+		`UPDATE tracker.outbox SET status = $1, attempts = 0, next_attempt_at = now()
+		WHERE status = $2 AND (cardinality($3::uuid[]) = 0 OR id = ANY($3::uuid[]))`,
+		OutboxPending, OutboxDead, append([]string{}, ids...))
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -0,0 +1,183 @@
+package postgres_db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// PoolConfig describes the tracker database and the pool of connections to it.
+// Zero limits keep the pgxpool defaults.
+type PoolConfig struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Name     string
+
+	// SSLMode is a libpq sslmode, prefer if empty. SSLRootCert, SSLCert and
+	// SSLKey are paths of PEM files.
+	SSLMode     string
+	SSLRootCert string
+	SSLCert     string
+	SSLKey      string
+
+	MaxConns          int32
+	MinConns          int32
+	MaxConnLifetime   time.Duration
+	MaxConnIdleTime   time.Duration
+	HealthCheckPeriod time.Duration
+
+	// StatementCacheMode is one of the keys of queryExecModes. Behind a
+	// pooler in transaction mode, such as PgBouncer, it has to be
+	// describe_exec or a later one.
+	StatementCacheMode     string
+	StatementCacheCapacity int
+
+	// ConnectTimeout is how long to wait for Postgres to accept connections
+	// on start, retrying every ConnectRetry. Zero tries once.
+	ConnectTimeout time.Duration
+	ConnectRetry   time.Duration
+}
+
+// queryExecModes name the ways pgx executes queries, from caching prepared
+// statements to not preparing them at all.
+var queryExecModes = map[string]pgx.QueryExecMode{
+	"cache_statement": pgx.QueryExecModeCacheStatement,
+	"cache_describe":  pgx.QueryExecModeCacheDescribe,
+	"describe_exec":   pgx.QueryExecModeDescribeExec,
+	"exec":            pgx.QueryExecModeExec,
+	"simple_protocol": pgx.QueryExecModeSimpleProtocol,
+}
+
+const defaultConnectRetry = time.Second
+
+// DSN returns the connection string of the database, without the pool settings.
+func DSN(cfg PoolConfig) string {
+	params := []string{
+		"host", cfg.Host,
+		"port", cfg.Port,
+		"user", cfg.User,
+		"password", cfg.Password,
+		"dbname", cfg.Name,
+		"sslmode", cfg.SSLMode,
+		"sslrootcert", cfg.SSLRootCert,
+		"sslcert", cfg.SSLCert,
+		"sslkey", cfg.SSLKey,
+	}
+
+	var dsn []string
+	for i := 0; i < len(params); i += 2 {
+		if params[i+1] != "" {
+			dsn = append(dsn, params[i]+"="+quoteDSN(params[i+1]))
+		}
+	}
+	return strings.Join(dsn, " ")
+}
+
+// quoteDSN quotes a value of a keyword/value connection string.
+func quoteDSN(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+func poolConfig(cfg PoolConfig) (*pgxpool.Config, error) {
+	config, err := pgxpool.ParseConfig(DSN(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxConns > 0 {
+		config.MaxConns = cfg.MaxConns
+	}
+	if cfg.MinConns > 0 {
+		config.MinConns = cfg.MinConns
+	}
+	if cfg.MaxConnLifetime > 0 {
+		config.MaxConnLifetime = cfg.MaxConnLifetime
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		config.MaxConnIdleTime = cfg.MaxConnIdleTime
+	}
+	if cfg.HealthCheckPeriod > 0 {
+		config.HealthCheckPeriod = cfg.HealthCheckPeriod
+	}
+	if config.MinConns > config.MaxConns {
+		return nil, fmt.Errorf("min conns %d exceed max conns %d", config.MinConns, config.MaxConns)
+	}
+
+	if cfg.StatementCacheMode != "" {
+		mode, ok := queryExecModes[cfg.StatementCacheMode]
+		if !ok {
+			return nil, fmt.Errorf("unknown statement cache mode %q", cfg.StatementCacheMode)
+		}
+		config.ConnConfig.DefaultQueryExecMode = mode
+	}
+	if cfg.StatementCacheCapacity > 0 {
+		config.ConnConfig.StatementCacheCapacity = cfg.StatementCacheCapacity
+		config.ConnConfig.DescriptionCacheCapacity = cfg.StatementCacheCapacity
+	}
+
+	return config, nil
+}
+
+// InitDB opens the pool of connections to the database and waits for
+// Postgres to accept them.
+func InitDB(ctx context.Context, cfg PoolConfig) (*DBConn, error) {
+
+	config, err := poolConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid postgres config: %w", err)
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ping(ctx, pool, cfg.ConnectTimeout, cfg.ConnectRetry); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	return &DBConn{Conn: stdlib.OpenDBFromPool(pool), Pool: pool}, nil
+
+}
+
+// ping pings the database every retry until it answers or timeout passes.
+func ping(ctx context.Context, pool *pgxpool.Pool, timeout, retry time.Duration) error {
+	if retry <= 0 {
+		retry = defaultConnectRetry
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	for {
+		err := pool.Ping(ctx)
+		if err == nil {
+			return nil
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("postgres is not ready: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("postgres is not ready: %w", err)
+		case <-time.After(retry):
+		}
+	}
+}
+
+// Close closes the connections of the pool.
+func (db *DBConn) Close() {
+	db.Conn.Close()
+	db.Pool.Close()
+}
@@ -0,0 +1,79 @@
+package postgres_db
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDSN(t *testing.T) {
+	t.Run("skips empty settings", func(t *testing.T) {
+		assert.Equal(t, "host='postgres' port='5432' user='tracker' dbname='tracker' sslmode='verify-full' sslrootcert='/certs/ca.pem'",
+			DSN(PoolConfig{Host: "postgres", Port: "5432", User: "tracker", Name: "tracker", SSLMode: "verify-full", SSLRootCert: "/certs/ca.pem"}))
+	})
+
+	t.Run("quotes values", func(t *testing.T) {
+		const password = `it's a \ secret`
+
+		config, err := pgx.ParseConfig(DSN(PoolConfig{Host: "postgres", User: "tracker", Password: password, SSLMode: "disable"}))
+		require.NoError(t, err)
+		assert.Equal(t, password, config.Password)
+		assert.Nil(t, config.TLSConfig)
+	})
+}
+
+func TestPoolConfig(t *testing.T) {
+	t.Run("applies the limits", func(t *testing.T) {
+		config, err := poolConfig(PoolConfig{
+			Host:                   "postgres",
+			MaxConns:               20,
+			MinConns:               2,
+			MaxConnLifetime:        time.Hour,
+			StatementCacheMode:     "describe_exec",
+			StatementCacheCapacity: 128,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(20), config.MaxConns)
+		assert.Equal(t, int32(2), config.MinConns)
+		assert.Equal(t, time.Hour, config.MaxConnLifetime)
+		assert.Equal(t, 30*time.Minute, config.MaxConnIdleTime, "the pgxpool default")
+		assert.Equal(t, pgx.QueryExecModeDescribeExec, config.ConnConfig.DefaultQueryExecMode)
+		assert.Equal(t, 128, config.ConnConfig.StatementCacheCapacity)
+	})
+
+	t.Run("unknown statement cache mode", func(t *testing.T) {
+		_, err := poolConfig(PoolConfig{Host: "postgres", StatementCacheMode: "prepare"})
+		assert.Error(t, err)
+	})
+
+	t.Run("more min than max conns", func(t *testing.T) {
+		_, err := poolConfig(PoolConfig{Host: "postgres", MaxConns: 2, MinConns: 5})
+		assert.Error(t, err)
+	})
+}
+
+func TestPoolCollector(t *testing.T) {
+	config, err := poolConfig(PoolConfig{Host: "postgres", MaxConns: 7})
+	require.NoError(t, err)
+
+	// The pool connects lazily, nothing is dialed without MinConns.
+	pool, err := pgxpool.NewWithConfig(context.Background(), config)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	collector := NewPoolCollector(pool)
+	assert.Equal(t, 11, testutil.CollectAndCount(collector))
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP postgres_pool_max_conns Maximum size of the pool.
+# TYPE postgres_pool_max_conns gauge
+postgres_pool_max_conns 7
+`), "postgres_pool_max_conns"))
+}
@@ -1,77 +1,454 @@
 package postgres_db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
-	_ "github.com/lib/pq"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
 )
 
+// DBConn runs the queries through Conn, a database/sql handle on Pool.
 type DBConn struct {
 	Conn *sql.DB
+	Pool *pgxpool.Pool
 }
 
-type DbService interface {
-	SelectItemFromDB(link string) (string, float32, error)
-	InsertItemFromDB(userId, link, name string, price float32) error
-	UpdateItemFromDB(price float32, link string) error
-	SelectAllItemsFromDB(userId string) (*sql.Rows, error)
+type PricePoint struct {
+	Price      money.Money
+	Stock      int16
+	ObservedAt time.Time
 }
 
-func InitDB(DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME string) (*DBConn, error) {
+// ProductDetails describe a product beyond its name and price. They are
+// refreshed on every observation.
+type ProductDetails struct {
+	// OriginalPrice is the price before the discount, zero if there is none.
+	OriginalPrice money.Money
+	Brand         string
+	Seller        string
+	Rating        float32
+	Reviews       int
+	Quantity      int
+	ImageURL      string
+	// Variants replace the variants stored for the product.
+	Variants []Variant
+}
+
+// Variant is the state of one size or color of a product as last observed.
+type Variant struct {
+	Id            string
+	Name          string
+	Price         money.Money
+	OriginalPrice money.Money
+	Stock         int16
+	Quantity      int
+}
 
-	str_conn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME)
+// Product is a product of the catalog as last observed.
+type Product struct {
+	Link         string
+	Name         string
+	CurrentPrice money.Money
+	Stock        int16
+	Details      ProductDetails
+}
+
+// Subscription is a product tracked by a user. The product fields describe
+// the pinned variant if there is one.
+type Subscription struct {
+	Product
+	Id         string
+	CustomName string
+	StartPrice money.Money
+	Paused     bool
+	// VariantId is the variant the subscription is pinned to, empty for the
+	// whole product. The prices, stock and quantity are those of the variant then.
+	VariantId   string
+	VariantName string
+}
+
+// DisplayName is the name the user gave the item, or the product name.
+func (s Subscription) DisplayName() string {
+	if s.CustomName != "" {
+		return s.CustomName
+	}
+	return s.Name
+}
+
+// SubscriptionUpdate lists the changes to apply, nil fields are left as they are.
+type SubscriptionUpdate struct {
+	CustomName *string
+	Paused     *bool
+	// VariantId pins the subscription to a variant, an empty one unpins it.
+	VariantId       *string
+	ResetStartPrice bool
+}
+
+// Page selects a part of a list ordered by creation time. After is the id
+// of the last element of the previous page, Limit zero means no limit.
+type Page struct {
+	After string
+	Limit int
+}
+
+// Repository is the storage of the products, subscriptions and everything
+// hanging off them.
+type Repository interface {
+	SelectSubscriptionFromDB(ctx context.Context, userId, link string) (Subscription, error)
+	InsertSubscriptionFromDB(ctx context.Context, userId, link, variantId, name string, price money.Money) (string, error)
+	SelectSubscriptionsFromDB(ctx context.Context, userId string, page Page) ([]Subscription, error)
+	SelectTrackedLinksFromDB(ctx context.Context) ([]string, error)
+	SubscribedFromDB(ctx context.Context, userId, link string) (bool, error)
+	RecordObservationFromDB(ctx context.Context, link, name string, details ProductDetails, point PricePoint, match AlertMatcher) error
+	SelectPriceHistoryFromDB(ctx context.Context, link string, from, to time.Time) ([]PricePoint, error)
+	DeleteSubscriptionFromDB(ctx context.Context, userId, id string) error
+	UpdateSubscriptionFromDB(ctx context.Context, userId, id string, update SubscriptionUpdate) (Subscription, error)
+	InsertAlertRuleFromDB(ctx context.Context, userId string, rule AlertRule) (AlertRule, error)
+	SelectAlertRulesFromDB(ctx context.Context, userId, subscriptionId string) ([]AlertRule, error)
+	DeleteAlertRuleFromDB(ctx context.Context, userId, id string) error
+	InsertAlertEventsFromDB(ctx context.Context, events []AlertEvent) error
+	SelectPendingAlertEventsFromDB(ctx context.Context, limit int) ([]AlertEvent, error)
+	MarkAlertEventsDeliveredFromDB(ctx context.Context, ids []string) error
+	ReplayOutboxEventsFromDB(ctx context.Context, ids []string) (int64, error)
+	InsertWebhookFromDB(ctx context.Context, webhook Webhook) (Webhook, error)
+	SelectWebhooksFromDB(ctx context.Context, userId string) ([]Webhook, error)
+	DeleteWebhookFromDB(ctx context.Context, userId, id string) error
+	SelectWebhookDeliveriesFromDB(ctx context.Context, userId, webhookId string, limit int) ([]WebhookDelivery, error)
+	SelectDisplayCurrencyFromDB(ctx context.Context, userId string) (string, error)
+	UpsertDisplayCurrencyFromDB(ctx context.Context, userId, currency string) error
+}
+
+// subscriptionColumns take the price, stock and quantity of the pinned
+// variant if there is one. A variant the shop no longer lists is out of
+// stock, stock status 2.
+const subscriptionColumns = `s.id, p.link, p.product_name, COALESCE(s.custom_name, ''), s.start_price, s.currency,
+	CASE WHEN s.variant_id = '' THEN COALESCE(p.current_price, 0) ELSE COALESCE(v.price, 0) END,
+	COALESCE(v.currency, p.currency),
+	CASE WHEN s.variant_id = '' THEN p.stock_status ELSE COALESCE(v.stock_status, 2) END, s.paused,
+	CASE WHEN s.variant_id = '' THEN p.original_price ELSE COALESCE(v.original_price, 0) END,
+	p.brand, p.seller, p.rating, p.review_count,
+	CASE WHEN s.variant_id = '' THEN p.quantity ELSE COALESCE(v.quantity, 0) END, p.image_url,
+	s.variant_id, COALESCE(v.name, '')`
+
+const subscriptionJoins = `JOIN tracker.products p ON p.id = s.product_id
+	LEFT JOIN tracker.product_variants v ON v.product_id = p.id AND v.variant_id = s.variant_id`
+
+const selectSubscription = `SELECT ` + subscriptionColumns + `
+	FROM tracker.subscriptions s ` + subscriptionJoins
+
+// scanSubscription reads a row of subscriptionColumns.
+func scanSubscription(row interface{ Scan(...any) error }) (Subscription, error) {
+	var sub Subscription
+	err := row.Scan(&sub.Id, &sub.Link, &sub.Name, &sub.CustomName, &sub.StartPrice.Amount, &sub.StartPrice.Currency,
+		&sub.CurrentPrice.Amount, &sub.CurrentPrice.Currency, &sub.Stock, &sub.Paused,
+		&sub.Details.OriginalPrice.Amount, &sub.Details.Brand, &sub.Details.Seller, &sub.Details.Rating,
+		&sub.Details.Reviews, &sub.Details.Quantity, &sub.Details.ImageURL, &sub.VariantId, &sub.VariantName)
+	if sub.Details.OriginalPrice.Amount != 0 {
+		sub.Details.OriginalPrice.Currency = sub.CurrentPrice.Currency
+	}
+	return sub, err
+}
 
-	postg_conn, err := sql.Open("postgres", str_conn)
+// SelectSubscriptionFromDB returns the user's subscription to link.
+// sql.ErrNoRows means the user does not track the link.
+func (db *DBConn) SelectSubscriptionFromDB(ctx context.Context, userId, link string) (Subscription, error) {
 
+	return scanSubscription(db.Conn.QueryRowContext(ctx, selectSubscription+" WHERE s.user_id = $1 AND p.link = $2", userId, link))
+
+}
+
+// InsertSubscriptionFromDB subscribes the user to the product behind link,
+// pinned to variantId unless it is empty, adding the product to the catalog
+// if nobody tracks it yet, and returns the subscription id. A user already
+// subscribed to the product is pinned to variantId again, with price as the
+// new start price.
+func (db *DBConn) InsertSubscriptionFromDB(ctx context.Context, userId, link, variantId, name string, price money.Money) (string, error) {
+
+	tx, err := db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var productId string
+	err = tx.QueryRowContext(ctx, `INSERT INTO tracker.products (id, link, product_name, current_price, currency, last_checked_at, creation_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		ON CONFLICT (link) DO UPDATE SET link = EXCLUDED.link
+		RETURNING id`,
+		uuid.New().String(), link, name, price.Amount, price.Currency, time.Now()).Scan(&productId)
+	if err != nil {
+		return "", err
+	}
+
+	var id string
+	err = tx.QueryRowContext(ctx, `INSERT INTO tracker.subscriptions (id, user_id, product_id, start_price, currency, created_at, variant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, product_id) DO UPDATE SET variant_id = EXCLUDED.variant_id,
+			start_price = EXCLUDED.start_price, currency = EXCLUDED.currency
+		RETURNING id`,
+		uuid.New().String(), userId, productId, price.Amount, price.Currency, time.Now(), variantId).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, tx.Commit()
+}
+
+// SelectSubscriptionsFromDB returns a page of the user's subscriptions in
+// the order they were created.
+func (db *DBConn) SelectSubscriptionsFromDB(ctx context.Context, userId string, page Page) ([]Subscription, error) {
+
+	query := selectSubscription + " WHERE s.user_id = $1"
+	args := []any{userId}
+	if page.After != "" {
+		args = append(args, page.After)
+		query += fmt.Sprintf(` AND (s.created_at, s.id) > (SELECT created_at, id FROM tracker.subscriptions
+			WHERE id = $%d AND user_id = $1)`, len(args))
+	}
+	query += " ORDER BY s.created_at, s.id"
+	if page.Limit > 0 {
+		args = append(args, page.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := db.Conn.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	err = postg_conn.Ping()
+	var subs []Subscription
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+
+	return subs, rows.Err()
+}
+
+func (db *DBConn) SelectTrackedLinksFromDB(ctx context.Context) ([]string, error) {
+
+	rows, err := db.Conn.QueryContext(ctx, `SELECT p.link FROM tracker.products p
+		WHERE EXISTS (SELECT 1 FROM tracker.subscriptions s WHERE s.product_id = p.id AND NOT s.paused)`)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
+
+	var links []string
+	for rows.Next() {
+		var link string
+		if err := rows.Scan(&link); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
 
-	return &DBConn{Conn: postg_conn}, nil
+func (db *DBConn) SubscribedFromDB(ctx context.Context, userId, link string) (bool, error) {
 
+	var subscribed bool
+	err := db.Conn.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM tracker.subscriptions s
+		JOIN tracker.products p ON p.id = s.product_id
+		WHERE s.user_id = $1 AND p.link = $2)`, userId, link).Scan(&subscribed)
+
+	return subscribed, err
 }
 
-func (db *DBConn) SelectItemFromDB(link string) (string, float32, error) {
+// RecordObservationFromDB stores a scraped price in the product catalog and
+// in the price history, and the variants of the product. In the same
+// transaction it writes a price change event to the outbox if the price or
+// stock changed, and an alert event for every rule on the link that match
+// reports as fired. Rules of subscriptions pinned to a variant are matched
+// against the price and stock of that variant.
+func (db *DBConn) RecordObservationFromDB(ctx context.Context, link, name string, details ProductDetails, point PricePoint, match AlertMatcher) error {
+
+	tx, err := db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var productId string
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO tracker.products (id, link, product_name, current_price, currency, stock_status, last_checked_at, creation_date,
+			original_price, brand, seller, rating, review_count, quantity, image_url)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (link) DO UPDATE SET product_name = EXCLUDED.product_name, current_price = EXCLUDED.current_price,
+			currency = EXCLUDED.currency, stock_status = EXCLUDED.stock_status, last_checked_at = EXCLUDED.last_checked_at,
+			original_price = EXCLUDED.original_price, brand = EXCLUDED.brand, seller = EXCLUDED.seller,
+			rating = EXCLUDED.rating, review_count = EXCLUDED.review_count, quantity = EXCLUDED.quantity,
+			image_url = EXCLUDED.image_url
+		RETURNING id`,
+		uuid.New().String(), link, name, point.Price.Amount, point.Price.Currency, point.Stock, point.ObservedAt,
+		details.OriginalPrice.Amount, details.Brand, details.Seller, details.Rating, details.Reviews,
+		details.Quantity, details.ImageURL).Scan(&productId)
+	if err != nil {
+		return err
+	}
+
+	previous := &PricePoint{}
+	err = tx.QueryRowContext(ctx,
+		`SELECT price, currency, stock_status, observed_at FROM tracker.price_history
+		WHERE product_id = $1 ORDER BY observed_at DESC LIMIT 1`,
+		productId).Scan(&previous.Price.Amount, &previous.Price.Currency, &previous.Stock, &previous.ObservedAt)
+	if err == sql.ErrNoRows {
+		previous = nil
+	} else if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO tracker.price_history (product_id, price, currency, stock_status, observed_at) VALUES ($1, $2, $3, $4, $5)",
+		productId, point.Price.Amount, point.Price.Currency, point.Stock, point.ObservedAt)
+	if err != nil {
+		return err
+	}
+
+	if previous == nil || previous.Price != point.Price || previous.Stock != point.Stock {
+		change := PriceChange{
+			Link:       link,
+			Name:       name,
+			Price:      point.Price,
+			Stock:      point.Stock,
+			ObservedAt: point.ObservedAt,
+		}
+		if previous != nil {
+			change.PreviousPrice, change.PreviousStock = &previous.Price, &previous.Stock
+		}
+		if err := insertOutbox(ctx, tx, TopicPriceChanged, change); err != nil {
+			return err
+		}
+	}
+
+	previousVariants, err := replaceVariants(ctx, tx, productId, details.Variants, point.ObservedAt)
+	if err != nil {
+		return err
+	}
+
+	rules, err := selectWatchedRules(ctx, tx, link)
+	if err != nil {
+		return err
+	}
 
-	var name string
-	var start_price float32
+	for _, rule := range rules {
+		ruleName, rulePrevious, ruleCurrent := name, previous, point
+		if rule.VariantId != "" {
+			var variant string
+			variant, rulePrevious, ruleCurrent = variantPoints(rule.VariantId, previousVariants, details.Variants, point.ObservedAt)
+			ruleName = fmt.Sprintf("%s (%s)", name, variant)
+		}
 
-	err := db.Conn.QueryRow("SELECT product_name, start_price FROM auth.items WHERE link=$1", link).Scan(
-		&name, &start_price)
+		if !match(rule, rulePrevious, ruleCurrent) {
+			continue
+		}
 
-	return name, start_price, err
+		event := AlertEvent{
+			Id:        uuid.New().String(),
+			RuleId:    rule.Id,
+			UserId:    rule.UserId,
+			Link:      link,
+			Name:      ruleName,
+			Kind:      rule.Kind,
+			Price:     ruleCurrent.Price,
+			CreatedAt: point.ObservedAt,
+		}
+		if rulePrevious != nil && rulePrevious.Price.Currency == ruleCurrent.Price.Currency {
+			event.PreviousPrice = &rulePrevious.Price
+		}
+		if err := insertOutbox(ctx, tx, TopicAlert, event); err != nil {
+			return err
+		}
+	}
 
+	return tx.Commit()
 }
 
-func (db *DBConn) InsertItemFromDB(userId, link, name string, price float32) error {
+func (db *DBConn) SelectPriceHistoryFromDB(ctx context.Context, link string, from, to time.Time) ([]PricePoint, error) {
 
-	id := uuid.New().String()
+	rows, err := db.Conn.QueryContext(ctx,
+		`SELECT h.price, h.currency, h.stock_status, h.observed_at FROM tracker.price_history h
+		JOIN tracker.products p ON p.id = h.product_id
+		WHERE p.link = $1 AND h.observed_at >= $2 AND h.observed_at <= $3 ORDER BY h.observed_at`,
+		link, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	_, err := db.Conn.Exec("INSERT INTO auth.items VALUES ($1, $2, $3, $4, $5, $6, $7)",
-		id, userId, link, name, price, price, time.Now())
+	var points []PricePoint
+	for rows.Next() {
+		var point PricePoint
+		if err := rows.Scan(&point.Price.Amount, &point.Price.Currency, &point.Stock, &point.ObservedAt); err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
 
-	return err
+	return points, rows.Err()
 }
 
-func (db *DBConn) UpdateItemFromDB(price float32, link string) error {
+// DeleteSubscriptionFromDB unsubscribes the user. The product and its price
+// history stay in the catalog. sql.ErrNoRows means the user has no such subscription.
+func (db *DBConn) DeleteSubscriptionFromDB(ctx context.Context, userId, id string) error {
+
+	res, err := db.Conn.ExecContext(ctx, "DELETE FROM tracker.subscriptions WHERE id = $1 AND user_id = $2", id, userId)
+	if err != nil {
+		return err
+	}
 
-	_, err := db.Conn.Exec("UPDATE auth.items SET current_price = $1 WHERE link = $2", price, link)
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return sql.ErrNoRows
+	}
 
-	return err
+	return nil
 }
 
-func (db *DBConn) SelectAllItemsFromDB(userId string) (*sql.Rows, error) {
+// UpdateSubscriptionFromDB applies update to the user's subscription and
+// returns the result. sql.ErrNoRows means the user has no such subscription.
+func (db *DBConn) UpdateSubscriptionFromDB(ctx context.Context, userId, id string, update SubscriptionUpdate) (Subscription, error) {
 
-	rows, err := db.Conn.Query("SELECT start_price, link FROM auth.items WHERE user_id = $1", userId)
+	var customName *string
+	if update.CustomName != nil && *update.CustomName != "" {
+		customName = update.CustomName
+	}
 
-	return rows, err
+	// The start price is reset to the current price of the variant the
+	// subscription is pinned to after the update. Out of stock the shop shows
+	// no price, the old start price is kept then.
+	return scanSubscription(db.Conn.QueryRowContext(ctx,
+		`WITH updated AS (
+			UPDATE tracker.subscriptions s SET
+				custom_name = CASE WHEN $3 THEN $4 ELSE s.custom_name END,
+				paused = COALESCE($5, s.paused),
+				variant_id = COALESCE($7, s.variant_id),
+				start_price = CASE WHEN $6 AND c.available THEN c.price ELSE s.start_price END,
+				currency = CASE WHEN $6 AND c.available THEN c.currency ELSE s.currency END
+			FROM tracker.subscriptions cur
+			JOIN tracker.products p ON p.id = cur.product_id
+			LEFT JOIN tracker.product_variants v ON v.product_id = p.id AND v.variant_id = COALESCE($7, cur.variant_id)
+			CROSS JOIN LATERAL (SELECT price, currency, price > 0 AND stock_status <> 2 AS available
+				FROM (SELECT
+					CASE WHEN COALESCE($7, cur.variant_id) = '' THEN p.current_price ELSE v.price END AS price,
+					COALESCE(v.currency, p.currency) AS currency,
+					CASE WHEN COALESCE($7, cur.variant_id) = '' THEN p.stock_status ELSE COALESCE(v.stock_status, 2) END AS stock_status) latest) c
+			WHERE cur.id = s.id AND s.id = $1 AND s.user_id = $2
+			RETURNING s.*
+		)
+		SELECT `+subscriptionColumns+`
+		FROM updated s `+subscriptionJoins,
+		id, userId, update.CustomName != nil, customName, update.Paused, update.ResetStartPrice, update.VariantId))
 }
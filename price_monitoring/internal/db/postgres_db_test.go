@@ -0,0 +1,110 @@
+package postgres_db
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+)
+
+var subscriptionRowColumns = []string{"id", "link", "product_name", "custom_name", "start_price", "start_currency",
+	"current_price", "currency", "stock_status", "paused",
+	"original_price", "brand", "seller", "rating", "review_count", "quantity", "image_url", "variant_id", "variant_name"}
+
+// subscriptionRow is a row of subscriptionRowColumns with prices in kopecks
+// and no product details.
+func subscriptionRow(id, link, name string, start, current int64, variantId, variantName string) []driver.Value {
+	return []driver.Value{id, link, name, "", start, "RUB", current, "RUB", 1, false,
+		0, "", "", 0, 0, 0, "", variantId, variantName}
+}
+
+func TestSelectSubscriptionsFromDB(t *testing.T) {
+	t.Run("all subscriptions", func(t *testing.T) {
+		conn, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer conn.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE s.user_id = $1 ORDER BY s.created_at, s.id")).
+			WithArgs("123").
+			WillReturnRows(sqlmock.NewRows(subscriptionRowColumns).
+				AddRow(subscriptionRow("id1", "http://example.com/item1", "Item1", 10000, 9000, "", "")...).
+				AddRow(subscriptionRow("id2", "http://example.com/item2", "Item2", 20000, 21000, "102", "XXL")...)).
+			RowsWillBeClosed()
+
+		db := &DBConn{Conn: conn}
+		subs, err := db.SelectSubscriptionsFromDB(context.Background(), "123", Page{})
+		require.NoError(t, err)
+
+		require.Len(t, subs, 2)
+		assert.Equal(t, "id1", subs[0].Id)
+		assert.Equal(t, "Item1", subs[0].Name)
+		assert.Equal(t, money.New(10000, "RUB"), subs[0].StartPrice)
+		assert.Equal(t, money.New(9000, "RUB"), subs[0].CurrentPrice)
+		assert.Equal(t, "102", subs[1].VariantId)
+		assert.Equal(t, "XXL", subs[1].VariantName)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("page after a subscription", func(t *testing.T) {
+		conn, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer conn.Close()
+
+		mock.ExpectQuery(`\(s\.created_at, s\.id\) > \(SELECT created_at, id FROM tracker\.subscriptions\s+WHERE id = \$2 AND user_id = \$1\) ORDER BY s\.created_at, s\.id LIMIT \$3`).
+			WithArgs("123", "id1", 2).
+			WillReturnRows(sqlmock.NewRows(subscriptionRowColumns)).
+			RowsWillBeClosed()
+
+		db := &DBConn{Conn: conn}
+		subs, err := db.SelectSubscriptionsFromDB(context.Background(), "123", Page{After: "id1", Limit: 2})
+		require.NoError(t, err)
+		assert.Empty(t, subs)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("closes the rows on a scan error", func(t *testing.T) {
+		conn, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer conn.Close()
+
+		row := subscriptionRow("id1", "http://example.com/item1", "Item1", 10000, 9000, "", "")
+		row[4] = "not a price"
+		mock.ExpectQuery("SELECT").
+			WillReturnRows(sqlmock.NewRows(subscriptionRowColumns).
+				AddRow(row...).
+				AddRow(subscriptionRow("id2", "http://example.com/item2", "Item2", 20000, 21000, "", "")...)).
+			RowsWillBeClosed()
+
+		db := &DBConn{Conn: conn}
+		_, err = db.SelectSubscriptionsFromDB(context.Background(), "123", Page{})
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestInsertSubscriptionFromDB(t *testing.T) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO tracker.products")).
+		WithArgs(sqlmock.AnyArg(), "http://example.com/item", "Item", 9000, "RUB", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("product"))
+	mock.ExpectQuery(`ON CONFLICT \(user_id, product_id\) DO UPDATE SET variant_id = EXCLUDED\.variant_id,\s+`+
+		`start_price = EXCLUDED\.start_price, currency = EXCLUDED\.currency`).
+		WithArgs(sqlmock.AnyArg(), "123", "product", 9000, "RUB", sqlmock.AnyArg(), "102").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("existing"))
+	mock.ExpectCommit()
+
+	db := &DBConn{Conn: conn}
+	id, err := db.InsertSubscriptionFromDB(context.Background(), "123", "http://example.com/item", "102", "Item", money.New(9000, "RUB"))
+	require.NoError(t, err)
+	assert.Equal(t, "existing", id, "adding a tracked product again repins the subscription")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,27 @@
+package postgres_db
+
+import (
+	"context"
+	"time"
+)
+
+// SelectDisplayCurrencyFromDB returns the currency the user wants to see
+// prices in. sql.ErrNoRows means the user has not chosen one.
+func (db *DBConn) SelectDisplayCurrencyFromDB(ctx context.Context, userId string) (string, error) {
+
+	var currency string
+	err := db.Conn.QueryRowContext(ctx,
+		"SELECT display_currency FROM tracker.user_preferences WHERE user_id = $1", userId).Scan(&currency)
+
+	return currency, err
+}
+
+func (db *DBConn) UpsertDisplayCurrencyFromDB(ctx context.Context, userId, currency string) error {
+
+	_, err := db.Conn.ExecContext(ctx,
+		`INSERT INTO tracker.user_preferences (user_id, display_currency, updated_at) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET display_currency = EXCLUDED.display_currency, updated_at = EXCLUDED.updated_at`,
+		userId, currency, time.Now())
+
+	return err
+}
@@ -0,0 +1,77 @@
+package postgres_db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// stockOutOfStock is the stock status of parser.StockOutOfStock.
+const stockOutOfStock int16 = 2
+
+// replaceVariants stores the variants of a product observed at observedAt,
+// removing those no longer listed, and returns the previous state of every
+// variant stored before by id.
+func replaceVariants(ctx context.Context, tx *sql.Tx, productId string, variants []Variant, observedAt time.Time) (map[string]PricePoint, error) {
+
+	rows, err := tx.QueryContext(ctx,
+		"SELECT variant_id, price, currency, stock_status, updated_at FROM tracker.product_variants WHERE product_id = $1",
+		productId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	previous := make(map[string]PricePoint)
+	for rows.Next() {
+		var id string
+		var point PricePoint
+		if err := rows.Scan(&id, &point.Price.Amount, &point.Price.Currency, &point.Stock, &point.ObservedAt); err != nil {
+			return nil, err
+		}
+		previous[id] = point
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(variants))
+	for i, variant := range variants {
+		ids[i] = variant.Id
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO tracker.product_variants (product_id, variant_id, name, price, currency, original_price, stock_status, quantity, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT (product_id, variant_id) DO UPDATE SET name = EXCLUDED.name, price = EXCLUDED.price,
+				currency = EXCLUDED.currency, original_price = EXCLUDED.original_price, stock_status = EXCLUDED.stock_status,
+				quantity = EXCLUDED.quantity, updated_at = EXCLUDED.updated_at`,
+			productId, variant.Id, variant.Name, variant.Price.Amount, variant.Price.Currency, variant.OriginalPrice.Amount,
+			variant.Stock, variant.Quantity, observedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM tracker.product_variants WHERE product_id = $1 AND variant_id <> ALL($2)",
+		productId, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return previous, nil
+}
+
+// variantPoints returns the name of variant id and its state before and at
+// observedAt. A variant the shop no longer lists is out of stock.
+func variantPoints(id string, previous map[string]PricePoint, variants []Variant, observedAt time.Time) (string, *PricePoint, PricePoint) {
+	var before *PricePoint
+	if point, ok := previous[id]; ok {
+		before = &point
+	}
+
+	for _, variant := range variants {
+		if variant.Id == id {
+			return variant.Name, before, PricePoint{Price: variant.Price, Stock: variant.Stock, ObservedAt: observedAt}
+		}
+	}
+	return "", before, PricePoint{Stock: stockOutOfStock, ObservedAt: observedAt}
+}
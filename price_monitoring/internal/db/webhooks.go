@@ -0,0 +1,285 @@
+package postgres_db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DeliveryPending int16 = iota
+	DeliveryDelivered
+	DeliveryFailed
+)
+
+type Webhook struct {
+	Id        string
+	UserId    string
+	URL       string
+	Secret    string
+	Active    bool
+	CreatedAt time.Time
+}
+
+type WebhookDelivery struct {
+	Id           string
+	WebhookId    string
+	EventId      string
+	Topic        string
+	Status       int16
+	Attempts     int
+	ResponseCode int
+	LastError    string
+	CreatedAt    time.Time
+	DeliveredAt  *time.Time
+}
+
+// DueDelivery is a delivery claimed for sending, with everything needed to send it.
+type DueDelivery struct {
+	Id        string
+	Attempts  int
+	URL       string
+	Secret    string
+	WebhookId string
+	Event     OutboxEvent
+}
+
+// InsertWebhookFromDB registers an active webhook for webhook.UserId.
+func (db *DBConn) InsertWebhookFromDB(ctx context.Context, webhook Webhook) (Webhook, error) {
+
+	webhook.Id = uuid.New().String()
+	webhook.Active = true
+	webhook.CreatedAt = time.Now()
+
+	_, err := db.Conn.ExecContext(ctx,
+		"INSERT INTO tracker.webhooks (id, user_id, url, secret, active, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		webhook.Id, webhook.UserId, webhook.URL, webhook.Secret, webhook.Active, webhook.CreatedAt)
+
+	return webhook, err
+}
+
+// SelectWebhooksFromDB lists the user's webhooks without their secrets.
+func (db *DBConn) SelectWebhooksFromDB(ctx context.Context, userId string) ([]Webhook, error) {
+
+	rows, err := db.Conn.QueryContext(ctx,
+		"SELECT id, user_id, url, active, created_at FROM tracker.webhooks WHERE user_id = $1 ORDER BY created_at", userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []Webhook
+	for rows.Next() {
+		var webhook Webhook
+		if err := rows.Scan(&webhook.Id, &webhook.UserId, &webhook.URL, &webhook.Active, &webhook.CreatedAt); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// DeleteWebhookFromDB removes the webhook and its deliveries.
+// sql.ErrNoRows means the user has no such webhook.
+func (db *DBConn) DeleteWebhookFromDB(ctx context.Context, userId, id string) error {
+
+	res, err := db.Conn.ExecContext(ctx, "DELETE FROM tracker.webhooks WHERE id = $1 AND user_id = $2", id, userId)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SelectWebhookDeliveriesFromDB returns the latest deliveries of a webhook,
+// newest first. sql.ErrNoRows means the user has no such webhook.
+func (db *DBConn) SelectWebhookDeliveriesFromDB(ctx context.Context, userId, webhookId string, limit int) ([]WebhookDelivery, error) {
+
+	var owned bool
+	err := db.Conn.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM tracker.webhooks WHERE id = $1 AND user_id = $2)", webhookId, userId).Scan(&owned)
+	if err != nil {
+		return nil, err
+	}
+	if !owned {
+		return nil, sql.ErrNoRows
+	}
+
+	rows, err := db.Conn.QueryContext(ctx,
+		`SELECT d.id, d.webhook_id, d.event_id, o.topic, d.status, d.attempts, COALESCE(d.response_code, 0),
+			COALESCE(d.last_error, ''), d.created_at, d.delivered_at
+		FROM tracker.webhook_deliveries d JOIN tracker.outbox o ON o.id = d.event_id
+		WHERE d.webhook_id = $1 ORDER BY d.created_at DESC LIMIT $2`, webhookId, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []WebhookDelivery
+	for rows.Next() {
+		var delivery WebhookDelivery
+		if err := rows.Scan(&delivery.Id, &delivery.WebhookId, &delivery.EventId, &delivery.Topic, &delivery.Status,
+			&delivery.Attempts, &delivery.ResponseCode, &delivery.LastError, &delivery.CreatedAt, &delivery.DeliveredAt); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// SelectSubscribersFromDB returns the users actively tracking link.
+func (db *DBConn) SelectSubscribersFromDB(ctx context.Context, link string) ([]string, error) {
+
+	rows, err := db.Conn.QueryContext(ctx,
+		`SELECT s.user_id FROM tracker.subscriptions s JOIN tracker.products p ON p.id = s.product_id
+		WHERE p.link = $1 AND NOT s.paused`, link)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []string
+	for rows.Next() {
+		var user string
+		if err := rows.Scan(&user); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// EnqueueWebhookDeliveriesFromDB schedules the outbox event for every active
+// webhook of the users. An event is enqueued once per webhook however often
+// it is published.
+func (db *DBConn) EnqueueWebhookDeliveriesFromDB(ctx context.Context, eventId string, userIds []string) error {
+
+	_, err := db.Conn.ExecContext(ctx,
+		`INSERT INTO tracker.webhook_deliveries (id, webhook_id, event_id, next_attempt_at, created_at)
+		SELECT gen_random_uuid(), w.id, $1, $3, $3 FROM tracker.webhooks w
+		WHERE w.active AND w.user_id = ANY($2)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING`,
+		eventId, append([]string{}, userIds...), time.Now())
+
+	return err
+}
+
+// ClaimWebhookDeliveriesFromDB returns up to limit due deliveries of active
+// webhooks and hides them from other dispatchers for lease.
+func (db *DBConn) ClaimWebhookDeliveriesFromDB(ctx context.Context, limit int, lease time.Duration) ([]DueDelivery, error) {
+
+	rows, err := db.Conn.QueryContext(ctx,
+		`WITH claimed AS (
+			UPDATE tracker.webhook_deliveries SET next_attempt_at = $3
+			WHERE id IN (
+				SELECT d.id FROM tracker.webhook_deliveries d JOIN tracker.webhooks w ON w.id = d.webhook_id
+				WHERE d.status = $1 AND d.next_attempt_at <= now() AND w.active
+				ORDER BY d.next_attempt_at LIMIT $2 FOR UPDATE OF d SKIP LOCKED
+			)
+			RETURNING id, webhook_id, event_id, attempts
+		)
+		SELECT c.id, c.attempts, w.id, w.url, w.secret, o.id, o.topic, o.payload, o.created_at
+		FROM claimed c
+		JOIN tracker.webhooks w ON w.id = c.webhook_id
+		JOIN tracker.outbox o ON o.id = c.event_id`,
+		DeliveryPending, limit, time.Now().Add(lease))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []DueDelivery
+	for rows.Next() {
+		var d DueDelivery
+		if err := rows.Scan(&d.Id, &d.Attempts, &d.WebhookId, &d.URL, &d.Secret,
+			&d.Event.Id, &d.Event.Topic, &d.Event.Payload, &d.Event.CreatedAt); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// MarkWebhookDeliveredFromDB records a successful delivery and resets the
+// failure count of its webhook.
+func (db *DBConn) MarkWebhookDeliveredFromDB(ctx context.Context, id string, responseCode int) error {
+
+	tx, err := db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var webhookId string
+	err = tx.QueryRowContext(ctx,
+		`UPDATE tracker.webhook_deliveries SET status = $2, attempts = attempts + 1, response_code = $3,
+			last_error = NULL, delivered_at = $4
+		WHERE id = $1 RETURNING webhook_id`,
+		id, DeliveryDelivered, responseCode, time.Now()).Scan(&webhookId)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE tracker.webhooks SET consecutive_failures = 0 WHERE id = $1", webhookId)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// MarkWebhookDeliveryFailedFromDB records a failed attempt. The delivery is
+// retried at retryAt unless final is set. The webhook is disabled once
+// disableAfter attempts in a row failed. It reports whether the webhook was
+// disabled by this failure.
+func (db *DBConn) MarkWebhookDeliveryFailedFromDB(ctx context.Context, id string, responseCode int, lastErr string,
+	retryAt time.Time, final bool, disableAfter int) (bool, error) {
+
+	tx, err := db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	status := DeliveryPending
+	if final {
+		status = DeliveryFailed
+	}
+
+	var webhookId string
+	err = tx.QueryRowContext(ctx,
+		`UPDATE tracker.webhook_deliveries SET status = $2, attempts = attempts + 1, response_code = NULLIF($3, 0),
+			last_error = $4, next_attempt_at = $5
+		WHERE id = $1 RETURNING webhook_id`,
+		id, status, responseCode, lastErr, retryAt).Scan(&webhookId)
+	if err != nil {
+		return false, err
+	}
+
+	var disabled bool
+	err = tx.QueryRowContext(ctx,
+		`UPDATE tracker.webhooks SET consecutive_failures = consecutive_failures + 1,
+			active = active AND consecutive_failures + 1 < $2,
+			disabled_at = CASE WHEN active AND consecutive_failures + 1 >= $2 THEN $3 ELSE disabled_at END
+		WHERE id = $1 RETURNING COALESCE(disabled_at = $3, FALSE)`,
+		webhookId, disableAfter, time.Now()).Scan(&disabled)
+	if err != nil {
+		return false, err
+	}
+
+	return disabled, tx.Commit()
+}
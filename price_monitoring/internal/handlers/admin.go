@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+
+	proto "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AdminTokenHeader is the metadata key carrying the token of the admin RPCs.
+const AdminTokenHeader = "x-admin-token"
+
+// adminMethods are the RPCs of the services and operators rather than the
+// users: they see and change the events of every user.
+var adminMethods = map[string]bool{
+	proto.Scraper_PendingAlerts_FullMethodName:      true,
+	proto.Scraper_AckAlerts_FullMethodName:          true,
+	proto.Scraper_ReplayOutboxEvents_FullMethodName: true,
+	proto.Scraper_Health_FullMethodName:             true,
+}
+
+// AdminAuth rejects the admin RPCs of callers without the token. With an
+// empty token the admin RPCs are disabled.
+func AdminAuth(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !adminMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		if token == "" {
+			return nil, status.Error(codes.PermissionDenied, "admin rpcs are disabled")
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(AdminTokenHeader)
+		if len(values) != 1 || subtle.ConstantTimeCompare([]byte(values[0]), []byte(token)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "invalid admin token")
+		}
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	proto "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdminAuth(t *testing.T) {
+	called := func(ctx context.Context, req any) (any, error) { return "called", nil }
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(AdminTokenHeader, token))
+	}
+	pending := &grpc.UnaryServerInfo{FullMethod: proto.Scraper_PendingAlerts_FullMethodName}
+
+	tests := []struct {
+		name  string
+		token string
+		ctx   context.Context
+		info  *grpc.UnaryServerInfo
+		code  codes.Code
+	}{
+		{"user rpc without token", "secret", context.Background(), &grpc.UnaryServerInfo{FullMethod: proto.Scraper_GetItem_FullMethodName}, codes.OK},
+		{"admin rpc with token", "secret", withToken("secret"), pending, codes.OK},
+		{"admin rpc without token", "secret", context.Background(), pending, codes.Unauthenticated},
+		{"admin rpc with wrong token", "secret", withToken("guess"), pending, codes.Unauthenticated},
+		{"admin rpcs disabled", "", withToken(""), pending, codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := AdminAuth(tt.token)(tt.ctx, nil, tt.info, called)
+			assert.Equal(t, tt.code, status.Code(err))
+			if tt.code == codes.OK {
+				assert.Equal(t, "called", resp)
+			}
+		})
+	}
+}
@@ -3,119 +3,744 @@ package handlers
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"log"
+	"sync"
+	"time"
 
+	"github.com/google/uuid"
+	postgres_db "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/parser"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/scraper"
 	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/service"
 	proto "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// FanOut bounds the scraping done by GetAllItems.
+type FanOut struct {
+	// Concurrency is the number of links scraped at the same time.
+	Concurrency int
+	// ItemTimeout limits the time spent on a single link.
+	ItemTimeout time.Duration
+}
+
+var DefaultFanOut = FanOut{
+	Concurrency: 8,
+	ItemTimeout: 15 * time.Second,
+}
+
 type Handler struct {
 	proto.UnimplementedScraperServer
-	Serv service.ServiceManager
+	Serv   service.ServiceManager
+	FanOut FanOut
 }
 
 func NewHandler(service service.ServiceManager) *Handler {
 	return &Handler{
-		Serv: service,
+		Serv:   service,
+		FanOut: DefaultFanOut,
 	}
 }
 
 func (s *Handler) GetItem(ctx context.Context, req *proto.GetItemRequest) (*proto.GetItemResponse, error) {
 
-	_, start_price, err := s.Serv.SelectItem(req.Link)
+	link, err := s.Serv.CanonicalLink(ctx, req.Link)
+	if err != nil {
+		return nil, parserError(err)
+	}
+
+	sub, err := s.Serv.SelectItem(ctx, req.UserId, link)
 
 	if err == sql.ErrNoRows {
 
-		name, status, price, err := s.Serv.ParserItem(req.Link)
+		product, err := s.Serv.ParserItem(ctx, link)
 		if err != nil {
-			return nil, err
+			return nil, parserError(err)
 		}
+		setCacheHeader(ctx, product)
 
-		err = s.Serv.InsertItem(req.UserId, req.Link, name, price)
+		price := product.Price
+		if req.VariantId != "" {
+			variant, err := product.Variant(req.VariantId)
+			if err != nil {
+				return nil, parserError(err)
+			}
+			price = variant.Price
+		}
+
+		id, err := s.Serv.InsertItem(ctx, req.UserId, link, req.VariantId, product.Name, price)
 
 		if err != nil {
 			return nil, fmt.Errorf("cannot add new item Error: %v", err)
 		}
-		result := proto.ItemResponse{
-			Name:         name,
-			StartPrice:   price,
-			CurrentPrice: price,
-			DiffPrice:    0,
-			Status:       status,
-		}
+		result := liveItem(postgres_db.Subscription{Id: id, StartPrice: price, VariantId: req.VariantId}, product)
+		s.convertItems(ctx, req.UserId, result)
 
 		return &proto.GetItemResponse{
-			Item: &result,
+			Item: result,
 		}, nil
 
 	} else if err != nil {
 		return nil, err
 	}
 
-	name, status, price, err := s.Serv.ParserItem(req.Link)
+	product, err := s.Serv.ParserItem(ctx, link)
 	if err != nil {
-		return nil, err
+		return nil, parserError(err)
 	}
+	setCacheHeader(ctx, product)
 
-	err = s.Serv.UpdateItem(price, req.Link)
-	if err != nil {
-		return nil, fmt.Errorf("cannot update current_price Error: %v", err)
+	if req.VariantId != "" {
+		if _, err := product.Variant(req.VariantId); err != nil {
+			return nil, parserError(err)
+		}
 	}
 
-	result := proto.ItemResponse{
-		Name:         name,
-		StartPrice:   start_price,
-		CurrentPrice: price,
-		DiffPrice:    price - start_price,
-		Status:       status,
+	if req.VariantId != "" && req.VariantId != sub.VariantId {
+		sub, err = s.Serv.UpdateSubscription(ctx, req.UserId, sub.Id, postgres_db.SubscriptionUpdate{
+			VariantId:       &req.VariantId,
+			ResetStartPrice: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot pin variant Error: %v", err)
+		}
 	}
 
+	result := liveItem(sub, product)
+	s.convertItems(ctx, req.UserId, result)
+
 	return &proto.GetItemResponse{
-		Item: &result,
+		Item: result,
 	}, nil
 
 }
 
+// MaxPageSize is the largest page GetAllItems returns.
+const MaxPageSize = 100
+
+// GetAllItems returns the items of the user, a page of them if req.PageSize
+// is set. Links are scraped concurrently and a link that fails is reported on
+// its item, with the last observed price, instead of failing the whole
+// response. With req.Cached nothing is scraped.
 func (s *Handler) GetAllItems(ctx context.Context, req *proto.GetAllItemsRequest) (*proto.GetAllItemsResponse, error) {
 
-	var items []*proto.ItemResponse
+	if req.PageToken != "" {
+		if err := uuid.Validate(req.PageToken); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+	if req.PageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid page size")
+	}
+
+	size := min(int(req.PageSize), MaxPageSize)
+	page := postgres_db.Page{After: req.PageToken}
+	if size > 0 {
+		// The item after the page tells whether there is a next one.
+		page.Limit = size + 1
+	}
 
-	rows, err := s.Serv.SelectAllItems(req.UserId)
+	subs, err := s.Serv.SelectItems(ctx, req.UserId, page)
 	if err != nil {
 		return nil, fmt.Errorf("cannot get data from postgres Error: %v", err)
 	}
-	var hasRows bool
 
-	for rows.Next() {
-		hasRows = true
-		var link string
-		var start_price float32
+	if len(subs) == 0 && req.PageToken == "" {
+		return nil, fmt.Errorf("this user_id doesnot have links")
+	}
 
-		if err := rows.Scan(&start_price, &link); err != nil {
-			return nil, err
+	var next string
+	if size > 0 && len(subs) > size {
+		subs = subs[:size]
+		next = subs[size-1].Id
+	}
+
+	items := make([]*proto.ItemResponse, len(subs))
+	if req.Cached {
+		for i, sub := range subs {
+			items[i] = cachedItem(sub)
 		}
-		name, status, price, err := s.Serv.ParserItem(link)
+		s.convertItems(ctx, req.UserId, items...)
+		return &proto.GetAllItemsResponse{Items: items, NextPageToken: next}, nil
+	}
+
+	cache := make([]string, len(subs))
+	sem := make(chan struct{}, max(s.FanOut.Concurrency, 1))
+	var wg sync.WaitGroup
+	// A caller that gave up does not get the remaining items scraped.
+fanOut:
+	for i, sub := range subs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break fanOut
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			items[i], cache[i] = s.scrapeItem(ctx, sub)
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	s.convertItems(ctx, req.UserId, items...)
+
+	grpc.SetHeader(ctx, metadata.Pairs(cacheHeader(cache)...))
+
+	return &proto.GetAllItemsResponse{Items: items, NextPageToken: next}, nil
+
+}
+
+// scrapeItem scrapes the link of sub within FanOut.ItemTimeout and falls back
+// to the last observed price if that fails. It also returns the cache status
+// of the scrape.
+func (s *Handler) scrapeItem(ctx context.Context, sub postgres_db.Subscription) (*proto.ItemResponse, string) {
+	if s.FanOut.ItemTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.FanOut.ItemTimeout)
+		defer cancel()
+	}
+
+	product, err := s.Serv.ParserItem(ctx, sub.Link)
+	if err != nil {
+		item := cachedItem(sub)
+		item.Error = err.Error()
+		return item, cacheMiss
+	}
+
+	return liveItem(sub, product), cacheStatus(product)
+}
+
+// CacheHeader is the response metadata key reporting whether the scrape cache
+// served the products. GetAllItems sets one value per item, in item order.
+const CacheHeader = "x-cache"
+
+const (
+	cacheHit  = "hit"
+	cacheMiss = "miss"
+)
+
+func cacheStatus(product *parser.Product) string {
+	if product.FromCache {
+		return cacheHit
+	}
+	return cacheMiss
+}
+
+func cacheHeader(statuses []string) []string {
+	pairs := make([]string, 0, 2*len(statuses))
+	for _, status := range statuses {
+		pairs = append(pairs, CacheHeader, status)
+	}
+	return pairs
+}
+
+// setCacheHeader reports the cache status of a single scrape. Failing to send
+// the header only loses the report.
+func setCacheHeader(ctx context.Context, product *parser.Product) {
+	grpc.SetHeader(ctx, metadata.Pairs(CacheHeader, cacheStatus(product)))
+}
+
+// cachedItem describes sub with the product as last observed.
+func cachedItem(sub postgres_db.Subscription) *proto.ItemResponse {
+	return &proto.ItemResponse{
+		Name:            sub.DisplayName(),
+		StartPrice:      moneyProto(sub.StartPrice),
+		CurrentPrice:    moneyProto(sub.CurrentPrice),
+		DiffPrice:       diffPrice(sub.CurrentPrice, sub.StartPrice),
+		Status:          stockStatus(parser.Stock(sub.Stock)),
+		Id:              sub.Id,
+		Paused:          sub.Paused,
+		Source:          proto.PriceSource_PRICE_SOURCE_CACHED,
+		OriginalPrice:   moneyProto(sub.Details.OriginalPrice),
+		DiscountPercent: int32(money.DiscountPercent(sub.Details.OriginalPrice, sub.CurrentPrice)),
+		Brand:           sub.Details.Brand,
+		Seller:          sub.Details.Seller,
+		Rating:          sub.Details.Rating,
+		ReviewCount:     int32(sub.Details.Reviews),
+		Quantity:        int32(sub.Details.Quantity),
+		ImageUrl:        sub.Details.ImageURL,
+		VariantId:       sub.VariantId,
+		VariantName:     sub.VariantName,
+	}
+}
+
+// liveItem describes sub with the product just scraped from its link.
+func liveItem(sub postgres_db.Subscription, product *parser.Product) *proto.ItemResponse {
+	sub.Name, sub.CurrentPrice, sub.Stock = product.Name, product.Price, int16(product.Stock)
+	sub.Details = postgres_db.ProductDetails{
+		OriginalPrice: product.OriginalPrice,
+		Brand:         product.Brand,
+		Seller:        product.Seller,
+		Rating:        product.Rating,
+		Reviews:       product.Reviews,
+		Quantity:      product.Quantity,
+		ImageURL:      product.ImageURL,
+	}
+	if sub.VariantId != "" {
+		variant, err := product.Variant(sub.VariantId)
 		if err != nil {
-			return nil, err
+			// The shop no longer lists the variant.
+			variant = parser.Variant{Name: sub.VariantName, Stock: parser.StockOutOfStock}
+		}
+		sub.VariantName, sub.CurrentPrice, sub.Stock = variant.Name, variant.Price, int16(variant.Stock)
+		sub.Details.OriginalPrice, sub.Details.Quantity = variant.OriginalPrice, variant.Quantity
+	}
+
+	item := cachedItem(sub)
+	item.Source = proto.PriceSource_PRICE_SOURCE_LIVE
+	for _, variant := range product.Variants {
+		item.Variants = append(item.Variants, &proto.Variant{
+			Id:            variant.ID,
+			Name:          variant.Name,
+			Price:         moneyProto(variant.Price),
+			OriginalPrice: moneyProto(variant.OriginalPrice),
+			Status:        stockStatus(variant.Stock),
+			Quantity:      int32(variant.Quantity),
+		})
+	}
+	return item
+}
+
+// convertItems sets the converted prices of items in the display currency of
+// the user. Prices without an exchange rate are left unconverted, they are
+// still shown in their own currency.
+func (s *Handler) convertItems(ctx context.Context, userId string, items ...*proto.ItemResponse) {
+	currency, err := s.Serv.DisplayCurrency(ctx, userId)
+	if err != nil {
+		log.Printf("cannot get display currency of %s err: %v", userId, err)
+		return
+	}
+
+	for _, item := range items {
+		item.ConvertedStartPrice = s.convert(ctx, item.StartPrice, currency)
+		item.ConvertedCurrentPrice = s.convert(ctx, item.CurrentPrice, currency)
+		if item.ConvertedStartPrice != nil && item.ConvertedCurrentPrice != nil {
+			item.ConvertedDiffPrice = diffPrice(moneyValue(item.ConvertedCurrentPrice), moneyValue(item.ConvertedStartPrice))
 		}
+	}
+}
+
+// convert returns m in currency, nil if m is not set or cannot be converted.
+func (s *Handler) convert(ctx context.Context, m *proto.Money, currency string) *proto.Money {
+	if m == nil {
+		return nil
+	}
+
+	converted, err := s.Serv.Convert(ctx, moneyValue(m), currency)
+	if err != nil {
+		return nil
+	}
+	return moneyProto(converted)
+}
+
+// moneyProto converts m, nil if it is not set.
+func moneyProto(m money.Money) *proto.Money {
+	if m.IsZero() {
+		return nil
+	}
+	return &proto.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+// moneyValue converts m, zero if it is not set.
+func moneyValue(m *proto.Money) money.Money {
+	if m == nil {
+		return money.Money{}
+	}
+	return money.New(m.Amount, m.Currency)
+}
+
+// diffPrice returns current - start, nil if they are in different currencies.
+func diffPrice(current, start money.Money) *proto.Money {
+	diff, err := current.Sub(start)
+	if err != nil {
+		return nil
+	}
+	return &proto.Money{Amount: diff.Amount, Currency: diff.Currency}
+}
+
+func (s *Handler) GetPriceHistory(ctx context.Context, req *proto.GetPriceHistoryRequest) (*proto.GetPriceHistoryResponse, error) {
+
+	link, err := s.Serv.CanonicalLink(ctx, req.Link)
+	if err != nil {
+		return nil, parserError(err)
+	}
 
-		items = append(items, &proto.ItemResponse{
-			Name:         name,
-			StartPrice:   start_price,
-			CurrentPrice: price,
-			DiffPrice:    price - start_price,
-			Status:       status,
+	query := service.HistoryQuery{
+		UserId:      req.UserId,
+		Link:        link,
+		Step:        req.Step.AsDuration(),
+		MaxPoints:   int(req.MaxPoints),
+		Aggregation: service.Aggregation(req.Aggregation),
+	}
+	if req.From != nil {
+		query.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		query.To = req.To.AsTime()
+	}
+
+	history, err := s.Serv.PriceHistory(ctx, query)
+	switch {
+	case errors.Is(err, service.ErrItemNotTracked):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidRange):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, fmt.Errorf("cannot get price history Error: %v", err)
+	}
+
+	points := make([]*proto.PricePoint, 0, len(history))
+	for _, point := range history {
+		points = append(points, &proto.PricePoint{
+			Time:   timestamppb.New(point.ObservedAt),
+			Price:  moneyProto(point.Price),
+			Status: stockStatus(parser.Stock(point.Stock)),
 		})
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("cannot read strings Error: %v", err)
+	return &proto.GetPriceHistoryResponse{Points: points}, nil
+}
+
+func (s *Handler) RemoveItem(ctx context.Context, req *proto.RemoveItemRequest) (*proto.RemoveItemResponse, error) {
+
+	if err := uuid.Validate(req.Id); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid item id")
 	}
 
-	if !hasRows {
-		return nil, fmt.Errorf("this user_id doesnot have links Error: %v", err)
+	err := s.Serv.RemoveItem(ctx, req.UserId, req.Id)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, status.Error(codes.NotFound, "item is not tracked")
+	case err != nil:
+		return nil, fmt.Errorf("cannot remove item Error: %v", err)
 	}
 
-	return &proto.GetAllItemsResponse{Items: items}, nil
+	return &proto.RemoveItemResponse{}, nil
+}
+
+func (s *Handler) UpdateSubscription(ctx context.Context, req *proto.UpdateSubscriptionRequest) (*proto.UpdateSubscriptionResponse, error) {
 
+	if err := uuid.Validate(req.Id); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid item id")
+	}
+
+	sub, err := s.Serv.UpdateSubscription(ctx, req.UserId, req.Id, postgres_db.SubscriptionUpdate{
+		CustomName:      req.Name,
+		Paused:          req.Paused,
+		ResetStartPrice: req.ResetStartPrice,
+	})
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, status.Error(codes.NotFound, "item is not tracked")
+	case err != nil:
+		return nil, fmt.Errorf("cannot update item Error: %v", err)
+	}
+
+	item := cachedItem(sub)
+	s.convertItems(ctx, req.UserId, item)
+
+	return &proto.UpdateSubscriptionResponse{
+		Item: item,
+	}, nil
+}
+
+func (s *Handler) CreateAlertRule(ctx context.Context, req *proto.CreateAlertRuleRequest) (*proto.CreateAlertRuleResponse, error) {
+
+	if err := uuid.Validate(req.ItemId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid item id")
+	}
+
+	rule, err := s.Serv.CreateAlertRule(ctx, req.UserId, postgres_db.AlertRule{
+		SubscriptionId: req.ItemId,
+		Kind:           int16(req.Kind),
+		TargetPrice:    moneyValue(req.TargetPrice),
+		DropPercent:    req.DropPercent,
+	})
+	switch {
+	case errors.Is(err, service.ErrInvalidRule):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrItemNotTracked):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, fmt.Errorf("cannot create alert rule Error: %v", err)
+	}
+
+	return &proto.CreateAlertRuleResponse{Rule: alertRule(rule)}, nil
+}
+
+func (s *Handler) ListAlertRules(ctx context.Context, req *proto.ListAlertRulesRequest) (*proto.ListAlertRulesResponse, error) {
+
+	rules, err := s.Serv.AlertRules(ctx, req.UserId, req.ItemId)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get alert rules Error: %v", err)
+	}
+
+	result := make([]*proto.AlertRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, alertRule(rule))
+	}
+
+	return &proto.ListAlertRulesResponse{Rules: result}, nil
+}
+
+func (s *Handler) DeleteAlertRule(ctx context.Context, req *proto.DeleteAlertRuleRequest) (*proto.DeleteAlertRuleResponse, error) {
+
+	if err := uuid.Validate(req.Id); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid alert rule id")
+	}
+
+	err := s.Serv.DeleteAlertRule(ctx, req.UserId, req.Id)
+	switch {
+	case errors.Is(err, service.ErrRuleNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, fmt.Errorf("cannot delete alert rule Error: %v", err)
+	}
+
+	return &proto.DeleteAlertRuleResponse{}, nil
+}
+
+func (s *Handler) PendingAlerts(ctx context.Context, req *proto.PendingAlertsRequest) (*proto.PendingAlertsResponse, error) {
+
+	events, err := s.Serv.PendingAlerts(ctx, int(req.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get pending alerts Error: %v", err)
+	}
+
+	result := make([]*proto.AlertEvent, 0, len(events))
+	for _, event := range events {
+		item := &proto.AlertEvent{
+			Id:        event.Id,
+			UserId:    event.UserId,
+			Link:      event.Link,
+			Name:      event.Name,
+			Kind:      proto.AlertKind(event.Kind),
+			Price:     moneyProto(event.Price),
+			CreatedAt: timestamppb.New(event.CreatedAt),
+		}
+		if event.PreviousPrice != nil {
+			item.PreviousPrice = moneyProto(*event.PreviousPrice)
+		}
+		result = append(result, item)
+	}
+
+	return &proto.PendingAlertsResponse{Events: result}, nil
+}
+
+func (s *Handler) AckAlerts(ctx context.Context, req *proto.AckAlertsRequest) (*proto.AckAlertsResponse, error) {
+
+	for _, id := range req.Ids {
+		if err := uuid.Validate(id); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid alert id")
+		}
+	}
+
+	if err := s.Serv.AckAlerts(ctx, req.Ids); err != nil {
+		return nil, fmt.Errorf("cannot acknowledge alerts Error: %v", err)
+	}
+
+	return &proto.AckAlertsResponse{}, nil
+}
+
+func (s *Handler) ReplayOutboxEvents(ctx context.Context, req *proto.ReplayOutboxEventsRequest) (*proto.ReplayOutboxEventsResponse, error) {
+
+	for _, id := range req.Ids {
+		if err := uuid.Validate(id); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid event id")
+		}
+	}
+
+	replayed, err := s.Serv.ReplayOutboxEvents(ctx, req.Ids)
+	if err != nil {
+		return nil, fmt.Errorf("cannot replay outbox events Error: %v", err)
+	}
+
+	return &proto.ReplayOutboxEventsResponse{Replayed: replayed}, nil
+}
+
+func (s *Handler) CreateWebhook(ctx context.Context, req *proto.CreateWebhookRequest) (*proto.CreateWebhookResponse, error) {
+
+	hook, err := s.Serv.CreateWebhook(ctx, req.UserId, req.Url)
+	switch {
+	case errors.Is(err, service.ErrInvalidWebhook):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, fmt.Errorf("cannot create webhook Error: %v", err)
+	}
+
+	result := webhook(hook)
+	result.Secret = hook.Secret
+
+	return &proto.CreateWebhookResponse{Webhook: result}, nil
+}
+
+func (s *Handler) ListWebhooks(ctx context.Context, req *proto.ListWebhooksRequest) (*proto.ListWebhooksResponse, error) {
+
+	hooks, err := s.Serv.Webhooks(ctx, req.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get webhooks Error: %v", err)
+	}
+
+	result := make([]*proto.Webhook, 0, len(hooks))
+	for _, hook := range hooks {
+		result = append(result, webhook(hook))
+	}
+
+	return &proto.ListWebhooksResponse{Webhooks: result}, nil
+}
+
+func (s *Handler) DeleteWebhook(ctx context.Context, req *proto.DeleteWebhookRequest) (*proto.DeleteWebhookResponse, error) {
+
+	if err := uuid.Validate(req.Id); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid webhook id")
+	}
+
+	err := s.Serv.DeleteWebhook(ctx, req.UserId, req.Id)
+	switch {
+	case errors.Is(err, service.ErrWebhookNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, fmt.Errorf("cannot delete webhook Error: %v", err)
+	}
+
+	return &proto.DeleteWebhookResponse{}, nil
+}
+
+func (s *Handler) ListWebhookDeliveries(ctx context.Context, req *proto.ListWebhookDeliveriesRequest) (*proto.ListWebhookDeliveriesResponse, error) {
+
+	if err := uuid.Validate(req.WebhookId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid webhook id")
+	}
+
+	deliveries, err := s.Serv.WebhookDeliveries(ctx, req.UserId, req.WebhookId, int(req.Limit))
+	switch {
+	case errors.Is(err, service.ErrWebhookNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, fmt.Errorf("cannot get webhook deliveries Error: %v", err)
+	}
+
+	result := make([]*proto.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		item := &proto.WebhookDelivery{
+			Id:           delivery.Id,
+			EventId:      delivery.EventId,
+			Event:        delivery.Topic,
+			Status:       proto.DeliveryStatus(delivery.Status),
+			Attempts:     int32(delivery.Attempts),
+			ResponseCode: int32(delivery.ResponseCode),
+			LastError:    delivery.LastError,
+			CreatedAt:    timestamppb.New(delivery.CreatedAt),
+		}
+		if delivery.DeliveredAt != nil {
+			item.DeliveredAt = timestamppb.New(*delivery.DeliveredAt)
+		}
+		result = append(result, item)
+	}
+
+	return &proto.ListWebhookDeliveriesResponse{Deliveries: result}, nil
+}
+
+func (s *Handler) Health(ctx context.Context, req *proto.HealthRequest) (*proto.HealthResponse, error) {
+
+	breakers := s.Serv.UpstreamHealth(ctx)
+
+	upstreams := make([]*proto.UpstreamHealth, 0, len(breakers))
+	for _, b := range breakers {
+		upstream := &proto.UpstreamHealth{
+			Name:                b.Name,
+			State:               breakerState(b.State),
+			ConsecutiveFailures: int32(b.Failures),
+		}
+		if !b.OpenedAt.IsZero() {
+			upstream.OpenedAt = timestamppb.New(b.OpenedAt)
+		}
+		upstreams = append(upstreams, upstream)
+	}
+
+	return &proto.HealthResponse{Upstreams: upstreams}, nil
+}
+
+func (s *Handler) SetDisplayCurrency(ctx context.Context, req *proto.SetDisplayCurrencyRequest) (*proto.SetDisplayCurrencyResponse, error) {
+
+	currency, err := s.Serv.SetDisplayCurrency(ctx, req.UserId, req.Currency)
+	switch {
+	case errors.Is(err, money.ErrInvalidCurrency):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, fmt.Errorf("cannot set display currency Error: %v", err)
+	}
+
+	return &proto.SetDisplayCurrencyResponse{Currency: currency}, nil
+}
+
+func breakerState(state scraper.BreakerState) proto.BreakerState {
+	switch state {
+	case scraper.BreakerOpen:
+		return proto.BreakerState_BREAKER_STATE_OPEN
+	case scraper.BreakerHalfOpen:
+		return proto.BreakerState_BREAKER_STATE_HALF_OPEN
+	default:
+		return proto.BreakerState_BREAKER_STATE_CLOSED
+	}
+}
+
+// webhook converts hook leaving out the secret, it is only returned on creation.
+func webhook(hook postgres_db.Webhook) *proto.Webhook {
+	return &proto.Webhook{
+		Id:        hook.Id,
+		Url:       hook.URL,
+		Active:    hook.Active,
+		CreatedAt: timestamppb.New(hook.CreatedAt),
+	}
+}
+
+func alertRule(rule postgres_db.AlertRule) *proto.AlertRule {
+	return &proto.AlertRule{
+		Id:          rule.Id,
+		ItemId:      rule.SubscriptionId,
+		Kind:        proto.AlertKind(rule.Kind),
+		TargetPrice: moneyProto(rule.TargetPrice),
+		DropPercent: rule.DropPercent,
+		CreatedAt:   timestamppb.New(rule.CreatedAt),
+	}
+}
+
+func stockStatus(stock parser.Stock) proto.StockStatus {
+	switch stock {
+	case parser.StockInStock:
+		return proto.StockStatus_STOCK_STATUS_IN_STOCK
+	case parser.StockOutOfStock:
+		return proto.StockStatus_STOCK_STATUS_OUT_OF_STOCK
+	default:
+		return proto.StockStatus_STOCK_STATUS_UNKNOWN
+	}
+}
+
+// parserError maps the parser error taxonomy onto gRPC status codes so that
+// clients do not have to match error messages.
+func parserError(err error) error {
+	switch {
+	case errors.Is(err, parser.ErrInvalidLink), errors.Is(err, parser.ErrVariantNotFound):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, parser.ErrProductNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, parser.ErrUpstreamUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, parser.ErrBlocked):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return err
 }
@@ -4,278 +4,858 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
-	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	postgres_db "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/parser"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/scraper"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/service"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/service/mocks"
 	proto "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-type ServiceManager interface {
-	ParserItem(link string) (string, string, float32, error)
-	SelectItem(link string) (string, float32, error)
-	UpdateItem(price float32, link string) error
-	InsertItem(userId, link, name string, price float32) error
-	SelectAllItems(userId string) (*sql.Rows, error)
+// newHandler returns a handler over serv. Unless the test expects otherwise,
+// links are kept as they are and prices are shown in RUB, converted only
+// from the same currency.
+func newHandler(serv *mocks.ServiceManager) *Handler {
+	serv.EXPECT().CanonicalLink(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, link string) (string, error) {
+			return link, nil
+		}).Maybe()
+	serv.EXPECT().DisplayCurrency(mock.Anything, mock.Anything).Return("RUB", nil).Maybe()
+	serv.EXPECT().Convert(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, amount money.Money, currency string) (money.Money, error) {
+			if amount.Currency != currency {
+				return money.Money{}, fmt.Errorf("no exchange rate")
+			}
+			return amount, nil
+		}).Maybe()
+
+	return NewHandler(serv)
 }
 
-type MockService struct {
-	ParserItemFunc     func(link string) (string, string, float32, error)
-	SelectItemFunc     func(link string) (string, float32, error)
-	UpdateItemFunc     func(price float32, link string) error
-	InsertItemFunc     func(userId, link, name string, price float32) error
-	SelectAllItemsFunc func(userId string) (*sql.Rows, error)
-}
+func TestGetItem(t *testing.T) {
+	t.Run("func SelectItem return sql.ErrNoRow", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItem(mock.Anything, "123", "TestLink.ru").Return(postgres_db.Subscription{}, sql.ErrNoRows)
+		serv.EXPECT().ParserItem(mock.Anything, "TestLink.ru").
+			Return(&parser.Product{Name: "TestItem", Price: rub(100), Stock: parser.StockInStock}, nil)
+		serv.EXPECT().InsertItem(mock.Anything, "123", "TestLink.ru", "", "TestItem", rub(100)).
+			Return("b6d1a1b4-5f1e-4c1b-9d6a-1f2e3d4c5b6a", nil)
+
+		resp, err := newHandler(serv).GetItem(context.Background(), &proto.GetItemRequest{UserId: "123", Link: "TestLink.ru"})
+		assert.NoError(t, err)
 
-func (m MockService) SelectItem(link string) (string, float32, error) {
+		assert.Equal(t, int64(10000), resp.Item.StartPrice.GetAmount())
+		assert.Equal(t, proto.StockStatus_STOCK_STATUS_IN_STOCK, resp.Item.Status)
+		assert.Equal(t, "TestItem", resp.Item.Name)
+		assert.Equal(t, "b6d1a1b4-5f1e-4c1b-9d6a-1f2e3d4c5b6a", resp.Item.Id)
+	})
 
-	return m.SelectItemFunc(link)
+	t.Run("func SelectItem return item", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItem(mock.Anything, "123", "TestLink.ru").
+			Return(postgres_db.Subscription{Product: postgres_db.Product{Name: "TestItem"}, StartPrice: rub(100)}, nil)
+		serv.EXPECT().ParserItem(mock.Anything, "TestLink.ru").
+			Return(&parser.Product{Name: "TestItem", Price: rub(130), Stock: parser.StockInStock}, nil)
 
-}
+		resp, err := newHandler(serv).GetItem(context.Background(), &proto.GetItemRequest{UserId: "123", Link: "TestLink.ru"})
+		assert.NoError(t, err)
 
-func (m MockService) UpdateItem(price float32, link string) error {
+		assert.Equal(t, int64(10000), resp.Item.StartPrice.GetAmount())
+		assert.Equal(t, proto.StockStatus_STOCK_STATUS_IN_STOCK, resp.Item.Status)
+		assert.Equal(t, "TestItem", resp.Item.Name)
+		assert.Equal(t, int64(3000), resp.Item.DiffPrice.GetAmount())
+	})
 
-	return m.UpdateItemFunc(price, link)
+	t.Run("custom name overrides product name", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItem(mock.Anything, mock.Anything, mock.Anything).Return(postgres_db.Subscription{
+			Product: postgres_db.Product{Name: "TestItem"}, Id: "id", CustomName: "Gift", StartPrice: rub(100), Paused: true}, nil)
+		serv.EXPECT().ParserItem(mock.Anything, mock.Anything).
+			Return(&parser.Product{Name: "TestItem", Price: rub(130), Stock: parser.StockInStock}, nil)
 
-}
+		resp, err := newHandler(serv).GetItem(context.Background(), &proto.GetItemRequest{UserId: "123", Link: "TestLink.ru"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Gift", resp.Item.Name)
+		assert.Equal(t, "id", resp.Item.Id)
+		assert.True(t, resp.Item.Paused)
+	})
 
-func (m MockService) InsertItem(userId, link, name string, price float32) error {
+	t.Run("returns product details", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItem(mock.Anything, mock.Anything, mock.Anything).
+			Return(postgres_db.Subscription{Product: postgres_db.Product{Name: "TestItem"}, StartPrice: money.New(100, "USD")}, nil)
+		serv.EXPECT().ParserItem(mock.Anything, mock.Anything).
+			Return(&parser.Product{Name: "TestItem", Price: rub(750), OriginalPrice: rub(1000), Brand: "Nike",
+				Seller: "Shop", Rating: 4.5, Reviews: 12, Quantity: 3, ImageURL: "https://img", Stock: parser.StockInStock}, nil)
 
-	return m.InsertItemFunc(userId, link, name, price)
+		resp, err := newHandler(serv).GetItem(context.Background(), &proto.GetItemRequest{UserId: "123", Link: "TestLink.ru"})
+		assert.NoError(t, err)
+		assert.Equal(t, rubProto(750), resp.Item.CurrentPrice)
+		assert.Equal(t, rubProto(1000), resp.Item.OriginalPrice)
+		assert.Equal(t, int32(25), resp.Item.DiscountPercent)
+		assert.Nil(t, resp.Item.DiffPrice, "prices in different currencies have no difference")
+		assert.Equal(t, "Nike", resp.Item.Brand)
+		assert.Equal(t, "Shop", resp.Item.Seller)
+		assert.Equal(t, float32(4.5), resp.Item.Rating)
+		assert.Equal(t, int32(12), resp.Item.ReviewCount)
+		assert.Equal(t, int32(3), resp.Item.Quantity)
+		assert.Equal(t, "https://img", resp.Item.ImageUrl)
+	})
 
-}
+	t.Run("pins a variant of a new item", func(t *testing.T) {
+		product := &parser.Product{Name: "Shirt", Price: rub(1500), Stock: parser.StockInStock, Variants: []parser.Variant{
+			{ID: "101", Name: "M", Stock: parser.StockOutOfStock},
+			{ID: "102", Name: "XXL", Price: rub(1600), Quantity: 2, Stock: parser.StockInStock},
+		}}
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItem(mock.Anything, mock.Anything, mock.Anything).Return(postgres_db.Subscription{}, sql.ErrNoRows)
+		serv.EXPECT().ParserItem(mock.Anything, mock.Anything).Return(product, nil)
+		serv.EXPECT().InsertItem(mock.Anything, "123", "TestLink.ru", "102", "Shirt", rub(1600)).Return("id", nil)
+
+		resp, err := newHandler(serv).GetItem(context.Background(), &proto.GetItemRequest{UserId: "123", Link: "TestLink.ru", VariantId: "102"})
+		assert.NoError(t, err)
+		assert.Equal(t, "102", resp.Item.VariantId)
+		assert.Equal(t, "XXL", resp.Item.VariantName)
+		assert.Equal(t, rubProto(1600), resp.Item.CurrentPrice)
+		assert.Equal(t, int32(2), resp.Item.Quantity)
+		assert.Len(t, resp.Item.Variants, 2)
+		assert.Equal(t, proto.StockStatus_STOCK_STATUS_OUT_OF_STOCK, resp.Item.Variants[0].Status)
+		assert.Nil(t, resp.Item.Variants[0].Price)
+	})
+
+	t.Run("unknown variant", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItem(mock.Anything, mock.Anything, mock.Anything).Return(postgres_db.Subscription{}, sql.ErrNoRows)
+		serv.EXPECT().ParserItem(mock.Anything, mock.Anything).
+			Return(&parser.Product{Name: "Shirt", Price: rub(1500), Stock: parser.StockInStock}, nil)
+
+		resp, err := newHandler(serv).GetItem(context.Background(), &proto.GetItemRequest{UserId: "123", Link: "TestLink.ru", VariantId: "999"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Nil(t, resp)
+	})
+
+	t.Run("re-pins the variant of a tracked item", func(t *testing.T) {
+		var update postgres_db.SubscriptionUpdate
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItem(mock.Anything, mock.Anything, mock.Anything).
+			Return(postgres_db.Subscription{Product: postgres_db.Product{Name: "Shirt"}, Id: "id", StartPrice: rub(1600), VariantId: "102"}, nil)
+		serv.EXPECT().ParserItem(mock.Anything, mock.Anything).
+			Return(&parser.Product{Name: "Shirt", Price: rub(1500), Stock: parser.StockInStock, Variants: []parser.Variant{
+				{ID: "101", Name: "M", Stock: parser.StockOutOfStock},
+			}}, nil)
+		serv.EXPECT().UpdateSubscription(mock.Anything, "123", "id", mock.Anything).
+			RunAndReturn(func(ctx context.Context, userId, id string, u postgres_db.SubscriptionUpdate) (postgres_db.Subscription, error) {
+				update = u
+				return postgres_db.Subscription{Product: postgres_db.Product{Name: "Shirt"}, Id: id,
+					StartPrice: money.New(0, "RUB"), VariantId: "101", VariantName: "M"}, nil
+			})
+
+		resp, err := newHandler(serv).GetItem(context.Background(), &proto.GetItemRequest{UserId: "123", Link: "TestLink.ru", VariantId: "101"})
+		assert.NoError(t, err)
+		assert.Equal(t, "101", *update.VariantId)
+		assert.True(t, update.ResetStartPrice)
+		assert.Equal(t, "M", resp.Item.VariantName)
+		assert.Equal(t, proto.StockStatus_STOCK_STATUS_OUT_OF_STOCK, resp.Item.Status, "the variant is out of stock while the product is not")
+	})
+
+	t.Run("converts prices to the display currency", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItem(mock.Anything, mock.Anything, mock.Anything).
+			Return(postgres_db.Subscription{Product: postgres_db.Product{Name: "TestItem"}, StartPrice: money.New(1000, "USD")}, nil)
+		serv.EXPECT().ParserItem(mock.Anything, mock.Anything).
+			Return(&parser.Product{Name: "TestItem", Price: money.New(800, "USD"), Stock: parser.StockInStock}, nil)
+		serv.EXPECT().Convert(mock.Anything, mock.Anything, "RUB").
+			RunAndReturn(func(ctx context.Context, m money.Money, currency string) (money.Money, error) {
+				return money.New(m.Amount*80, currency), nil
+			})
+
+		resp, err := newHandler(serv).GetItem(context.Background(), &proto.GetItemRequest{UserId: "123", Link: "TestLink.ru"})
+		assert.NoError(t, err)
+		assert.Equal(t, &proto.Money{Amount: 800, Currency: "USD"}, resp.Item.CurrentPrice)
+		assert.Equal(t, rubProto(800), resp.Item.ConvertedStartPrice)
+		assert.Equal(t, rubProto(640), resp.Item.ConvertedCurrentPrice)
+		assert.Equal(t, rubProto(-160), resp.Item.ConvertedDiffPrice)
+	})
+
+	t.Run("leaves converted prices unset without a rate", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItem(mock.Anything, mock.Anything, mock.Anything).
+			Return(postgres_db.Subscription{Product: postgres_db.Product{Name: "TestItem"}, StartPrice: money.New(1000, "USD")}, nil)
+		serv.EXPECT().ParserItem(mock.Anything, mock.Anything).
+			Return(&parser.Product{Name: "TestItem", Price: money.New(800, "USD"), Stock: parser.StockInStock}, nil)
 
-func (m MockService) SelectAllItems(userId string) (*sql.Rows, error) {
+		resp, err := newHandler(serv).GetItem(context.Background(), &proto.GetItemRequest{UserId: "123", Link: "TestLink.ru"})
+		assert.NoError(t, err)
+		assert.Equal(t, &proto.Money{Amount: 800, Currency: "USD"}, resp.Item.CurrentPrice)
+		assert.Nil(t, resp.Item.ConvertedStartPrice)
+		assert.Nil(t, resp.Item.ConvertedCurrentPrice)
+		assert.Nil(t, resp.Item.ConvertedDiffPrice)
+	})
+
+	t.Run("func InsertItem return error", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItem(mock.Anything, mock.Anything, mock.Anything).Return(postgres_db.Subscription{}, sql.ErrNoRows)
+		serv.EXPECT().ParserItem(mock.Anything, mock.Anything).
+			Return(&parser.Product{Name: "TestItem", Price: rub(130), Stock: parser.StockInStock}, nil)
+		serv.EXPECT().InsertItem(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return("", fmt.Errorf("cannot insert item"))
+
+		resp, err := newHandler(serv).GetItem(context.Background(), &proto.GetItemRequest{UserId: "123", Link: "TestLink.ru"})
+		assert.EqualError(t, err, "cannot add new item Error: cannot insert item")
+		assert.Nil(t, resp)
+	})
 
-	return m.SelectAllItemsFunc(userId)
+	t.Run("func ParserItem return error", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItem(mock.Anything, mock.Anything, mock.Anything).Return(postgres_db.Subscription{}, sql.ErrNoRows)
+		serv.EXPECT().ParserItem(mock.Anything, mock.Anything).Return(nil, fmt.Errorf("cannot parse item"))
+
+		resp, err := newHandler(serv).GetItem(context.Background(), &proto.GetItemRequest{UserId: "123", Link: "TestLink.ru"})
+		assert.EqualError(t, err, "cannot parse item")
+		assert.Nil(t, resp)
+	})
+
+	t.Run("func ParserItem return typed error", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItem(mock.Anything, mock.Anything, mock.Anything).Return(postgres_db.Subscription{}, sql.ErrNoRows)
+		serv.EXPECT().ParserItem(mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("cannot parse this link: %w", parser.ErrInvalidLink))
+
+		resp, err := newHandler(serv).GetItem(context.Background(), &proto.GetItemRequest{UserId: "123", Link: "TestLink.ru"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Nil(t, resp)
+	})
+
+	t.Run("uses the canonical link", func(t *testing.T) {
+		const (
+			link      = "https://m.wildberries.ru/catalog/12345/detail.aspx?utm_source=tg"
+			canonical = "https://www.wildberries.ru/catalog/12345/detail.aspx"
+		)
+
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().CanonicalLink(mock.Anything, link).Return(canonical, nil)
+		serv.EXPECT().SelectItem(mock.Anything, "123", canonical).Return(postgres_db.Subscription{}, sql.ErrNoRows)
+		serv.EXPECT().ParserItem(mock.Anything, canonical).
+			Return(&parser.Product{Name: "TestItem", Price: rub(100), Stock: parser.StockInStock}, nil)
+		serv.EXPECT().InsertItem(mock.Anything, "123", canonical, "", "TestItem", rub(100)).
+			Return("b6d1a1b4-5f1e-4c1b-9d6a-1f2e3d4c5b6a", nil)
+
+		_, err := newHandler(serv).GetItem(context.Background(), &proto.GetItemRequest{UserId: "123", Link: link})
+		assert.NoError(t, err)
+	})
+
+	t.Run("link cannot be canonicalized", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().CanonicalLink(mock.Anything, "text").Return("", fmt.Errorf("cannot resolve this link: %w", parser.ErrInvalidLink))
+
+		resp, err := newHandler(serv).GetItem(context.Background(), &proto.GetItemRequest{UserId: "123", Link: "text"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Nil(t, resp)
+	})
 
 }
 
-func (m MockService) ParserItem(link string) (string, string, float32, error) {
+// item is a subscription with prices in rubles, no product details and no
+// pinned variant.
+func item(id string, start int64, link, customName string, paused bool, name string, current int64, stock parser.Stock) postgres_db.Subscription {
+	return postgres_db.Subscription{
+		Product: postgres_db.Product{
+			Link:         link,
+			Name:         name,
+			CurrentPrice: rub(current),
+			Stock:        int16(stock),
+		},
+		Id:         id,
+		CustomName: customName,
+		StartPrice: rub(start),
+		Paused:     paused,
+	}
+}
 
-	return m.ParserItemFunc(link)
+func rub(rubles int64) money.Money {
+	return money.New(rubles*100, "RUB")
 }
 
-func TestGetItem(t *testing.T) {
-	t.Run("func SelectItem return sql.ErrNoRow", func(t *testing.T) {
-		mock := MockService{
-			SelectItemFunc: func(link string) (string, float32, error) {
-				return "", 0, sql.ErrNoRows
-			},
-			ParserItemFunc: func(link string) (string, string, float32, error) {
-				return "TestItem", "TestStatus", 100.0, nil
-			},
-			InsertItemFunc: func(userId, link, name string, price float32) error {
-				return nil
-			},
-		}
+func rubProto(rubles int64) *proto.Money {
+	return &proto.Money{Amount: rubles * 100, Currency: "RUB"}
+}
 
-		req := &proto.GetItemRequest{
-			UserId: "123",
-			Link:   "TestLink.ru",
-		}
+func TestGetAllItems(t *testing.T) {
 
-		handler := NewHandler(mock)
+	t.Run("SelectItems return error", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItems(mock.Anything, "123", postgres_db.Page{}).Return(nil, fmt.Errorf("cannot select items"))
 
-		resp, err := handler.GetItem(context.Background(), req)
-		if err != nil {
-			t.Errorf("unexpected err %v", err)
-		}
+		resp, err := newHandler(serv).GetAllItems(context.Background(), &proto.GetAllItemsRequest{UserId: "123"})
+		assert.EqualError(t, err, "cannot get data from postgres Error: cannot select items")
+		assert.Nil(t, resp)
+	})
 
-		if resp.Item.StartPrice != 100.0 || resp.Item.Status != "TestStatus" || resp.Item.Name != "TestItem" {
-			t.Errorf("expected StartPrice=%f Status=%s, Name=%s, got StartPrice=%f Status=%s, Name=%s",
-				100.0, "TestStatus", "TestItem", resp.Item.StartPrice, resp.Item.Status, resp.Item.Name)
-		}
+	t.Run("user has no items", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItems(mock.Anything, "123", postgres_db.Page{}).Return(nil, nil)
+
+		resp, err := newHandler(serv).GetAllItems(context.Background(), &proto.GetAllItemsRequest{UserId: "123"})
+		assert.Error(t, err)
+		assert.Nil(t, resp)
 	})
 
-	t.Run("func SelectItem return item", func(t *testing.T) {
-		mock := MockService{
-			SelectItemFunc: func(link string) (string, float32, error) {
-				return "TestItem", 100.0, nil
-			},
-			ParserItemFunc: func(link string) (string, string, float32, error) {
-				return "TestItem", "TestStatus", 130.0, nil
-			},
-			UpdateItemFunc: func(price float32, link string) error {
-				return nil
-			},
-		}
+	t.Run("SelectItems return items", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItems(mock.Anything, "123", postgres_db.Page{}).Return([]postgres_db.Subscription{
+			item("id1", 100, "http://example.com/item1", "", false, "Item1", 100, parser.StockInStock),
+			item("id2", 200, "http://example.com/item2", "", true, "Item2", 200, parser.StockInStock),
+		}, nil)
+		serv.EXPECT().ParserItem(mock.Anything, "http://example.com/item1").
+			Return(&parser.Product{Name: "Item1", Price: rub(110), Stock: parser.StockInStock}, nil)
+		serv.EXPECT().ParserItem(mock.Anything, "http://example.com/item2").
+			Return(&parser.Product{Name: "Item2", Price: rub(190), Stock: parser.StockOutOfStock}, nil)
+
+		resp, err := newHandler(serv).GetAllItems(context.Background(), &proto.GetAllItemsRequest{UserId: "123"})
+		assert.NoError(t, err)
 
-		req := &proto.GetItemRequest{
-			UserId: "123",
-			Link:   "TestLink.ru",
-		}
+		assert.Len(t, resp.Items, 2)
+		assert.Equal(t, "Item1", resp.Items[0].Name)
+		assert.Equal(t, rubProto(10), resp.Items[0].DiffPrice)
+		assert.Equal(t, proto.StockStatus_STOCK_STATUS_IN_STOCK, resp.Items[0].Status)
+		assert.Equal(t, "Item2", resp.Items[1].Name)
+		assert.Equal(t, rubProto(-10), resp.Items[1].DiffPrice)
+		assert.Equal(t, proto.StockStatus_STOCK_STATUS_OUT_OF_STOCK, resp.Items[1].Status)
+		assert.Equal(t, "id2", resp.Items[1].Id)
+		assert.True(t, resp.Items[1].Paused)
+		assert.Empty(t, resp.NextPageToken)
+	})
 
-		handler := NewHandler(mock)
+	t.Run("failing link does not hide the rest", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItems(mock.Anything, mock.Anything, mock.Anything).Return([]postgres_db.Subscription{
+			item("id1", 100, "http://example.com/item1", "", false, "Item1", 95, parser.StockInStock),
+			item("id2", 200, "http://example.com/item2", "Mine", false, "Item2", 180, parser.StockInStock),
+		}, nil)
+		serv.EXPECT().ParserItem(mock.Anything, "http://example.com/item1").Return(nil, parser.ErrUpstreamUnavailable)
+		serv.EXPECT().ParserItem(mock.Anything, "http://example.com/item2").
+			Return(&parser.Product{Name: "Item2", Price: rub(150), Stock: parser.StockInStock}, nil)
+
+		resp, err := newHandler(serv).GetAllItems(context.Background(), &proto.GetAllItemsRequest{UserId: "123"})
+		assert.NoError(t, err)
 
-		resp, err := handler.GetItem(context.Background(), req)
-		if err != nil {
-			t.Errorf("unexpected err %v", err)
-		}
+		assert.Len(t, resp.Items, 2)
+		assert.Equal(t, proto.PriceSource_PRICE_SOURCE_CACHED, resp.Items[0].Source)
+		assert.Equal(t, parser.ErrUpstreamUnavailable.Error(), resp.Items[0].Error)
+		assert.Equal(t, rubProto(95), resp.Items[0].CurrentPrice)
+		assert.Equal(t, rubProto(-5), resp.Items[0].DiffPrice)
+		assert.Equal(t, proto.PriceSource_PRICE_SOURCE_LIVE, resp.Items[1].Source)
+		assert.Empty(t, resp.Items[1].Error)
+		assert.Equal(t, "Mine", resp.Items[1].Name)
+		assert.Equal(t, rubProto(150), resp.Items[1].CurrentPrice)
+	})
 
-		if resp.Item.StartPrice != 100.0 || resp.Item.Status != "TestStatus" || resp.Item.Name != "TestItem" || resp.Item.DiffPrice != 30 {
-			t.Errorf("expected StartPrice=%f Status=%s, Name=%s Different=%f, got StartPrice=%f Status=%s, Name=%s Different=%f",
-				100.0, "TestStatus", "TestItem", 30.0, resp.Item.StartPrice, resp.Item.Status, resp.Item.Name, resp.Item.DiffPrice)
-		}
+	t.Run("cached prices", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItems(mock.Anything, mock.Anything, mock.Anything).Return([]postgres_db.Subscription{
+			item("id1", 100, "http://example.com/item1", "", false, "Item1", 90, parser.StockOutOfStock),
+		}, nil)
+
+		resp, err := newHandler(serv).GetAllItems(context.Background(), &proto.GetAllItemsRequest{UserId: "123", Cached: true})
+		assert.NoError(t, err)
+
+		assert.Len(t, resp.Items, 1)
+		assert.Equal(t, proto.PriceSource_PRICE_SOURCE_CACHED, resp.Items[0].Source)
+		assert.Equal(t, "Item1", resp.Items[0].Name)
+		assert.Equal(t, rubProto(-10), resp.Items[0].DiffPrice)
+		assert.Equal(t, proto.StockStatus_STOCK_STATUS_OUT_OF_STOCK, resp.Items[0].Status)
 	})
 
-	t.Run("func InsertItem return error", func(t *testing.T) {
-		mock := MockService{
-			SelectItemFunc: func(link string) (string, float32, error) {
-				return "", 0, sql.ErrNoRows
-			},
-			ParserItemFunc: func(link string) (string, string, float32, error) {
-				return "TestItem", "TestStatus", 130.0, nil
-			},
-			InsertItemFunc: func(userId, link, name string, price float32) error {
-				return fmt.Errorf("cannot insert item")
-			},
-		}
+	t.Run("pages", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItems(mock.Anything, "123", postgres_db.Page{Limit: 3}).Return([]postgres_db.Subscription{
+			item("id1", 100, "http://example.com/item1", "", false, "Item1", 100, parser.StockInStock),
+			item("id2", 100, "http://example.com/item2", "", false, "Item2", 100, parser.StockInStock),
+			item("id3", 100, "http://example.com/item3", "", false, "Item3", 100, parser.StockInStock),
+		}, nil)
 
-		req := &proto.GetItemRequest{
-			UserId: "123",
-			Link:   "TestLink.ru",
-		}
+		resp, err := newHandler(serv).GetAllItems(context.Background(), &proto.GetAllItemsRequest{UserId: "123", Cached: true, PageSize: 2})
+		assert.NoError(t, err)
+
+		assert.Len(t, resp.Items, 2)
+		assert.Equal(t, "id2", resp.NextPageToken)
+	})
 
-		handler := NewHandler(mock)
+	t.Run("last page", func(t *testing.T) {
+		const token = "b6d1a1b4-5f1e-4c1b-9d6a-1f2e3d4c5b6a"
 
-		resp, err := handler.GetItem(context.Background(), req)
-		if err.Error() != "cannot add new item Error: cannot insert item" {
-			t.Errorf("unexpected err %v, expected %v", err, fmt.Errorf("cannot add new item Error: cannot insert item"))
-		}
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItems(mock.Anything, "123", postgres_db.Page{After: token, Limit: MaxPageSize + 1}).Return([]postgres_db.Subscription{
+			item("id3", 100, "http://example.com/item3", "", false, "Item3", 100, parser.StockInStock),
+		}, nil)
 
-		if resp != nil {
-			t.Errorf("resp should be nil")
-		}
+		resp, err := newHandler(serv).GetAllItems(context.Background(),
+			&proto.GetAllItemsRequest{UserId: "123", Cached: true, PageSize: 1000, PageToken: token})
+		assert.NoError(t, err)
+
+		assert.Len(t, resp.Items, 1)
+		assert.Empty(t, resp.NextPageToken)
 	})
 
-	t.Run("func UpdateItem return error", func(t *testing.T) {
-		mock := MockService{
-			SelectItemFunc: func(link string) (string, float32, error) {
-				return "TestItem", 100.0, nil
-			},
-			ParserItemFunc: func(link string) (string, string, float32, error) {
-				return "TestItem", "TestStatus", 130.0, nil
-			},
-			UpdateItemFunc: func(price float32, link string) error {
-				return fmt.Errorf("cannot update item")
-			},
-		}
+	t.Run("invalid page", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
 
-		req := &proto.GetItemRequest{
-			UserId: "123",
-			Link:   "TestLink.ru",
-		}
+		resp, err := newHandler(serv).GetAllItems(context.Background(), &proto.GetAllItemsRequest{UserId: "123", PageToken: "1"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Nil(t, resp)
 
-		handler := NewHandler(mock)
+		resp, err = newHandler(serv).GetAllItems(context.Background(), &proto.GetAllItemsRequest{UserId: "123", PageSize: -1})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Nil(t, resp)
+	})
 
-		resp, err := handler.GetItem(context.Background(), req)
-		if err.Error() != "cannot update current_price Error: cannot update item" {
-			t.Errorf("unexpected err %v, expected %v", err, fmt.Errorf("cannot update current_price Error: cannot update item"))
+	t.Run("scrapes concurrently within the limit", func(t *testing.T) {
+		subs := make([]postgres_db.Subscription, 10)
+		for i := range subs {
+			subs[i] = item(fmt.Sprintf("id%d", i), 100, fmt.Sprintf("http://example.com/item%d", i), "", false, "Item", 100, parser.StockInStock)
 		}
 
-		if resp != nil {
-			t.Errorf("resp should be nil")
+		var running, peak atomic.Int32
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItems(mock.Anything, mock.Anything, mock.Anything).Return(subs, nil)
+		serv.EXPECT().ParserItem(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, link string) (*parser.Product, error) {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+
+		handler := newHandler(serv)
+		handler.FanOut = FanOut{Concurrency: 3, ItemTimeout: 20 * time.Millisecond}
+
+		resp, err := handler.GetAllItems(context.Background(), &proto.GetAllItemsRequest{UserId: "123"})
+		assert.NoError(t, err)
+
+		assert.Len(t, resp.Items, 10)
+		for i, item := range resp.Items {
+			assert.Equal(t, fmt.Sprintf("id%d", i), item.Id)
+			assert.Equal(t, context.DeadlineExceeded.Error(), item.Error)
 		}
+		assert.Equal(t, int32(3), peak.Load())
 	})
 
-	t.Run("func ParserItem return error", func(t *testing.T) {
-		mock := MockService{
-			SelectItemFunc: func(link string) (string, float32, error) {
-				return "", 0, sql.ErrNoRows
-			},
-			ParserItemFunc: func(link string) (string, string, float32, error) {
-				return "", "", 0, fmt.Errorf("cannot parse item")
-			},
+	t.Run("stops when the caller gives up", func(t *testing.T) {
+		subs := make([]postgres_db.Subscription, 10)
+		for i := range subs {
+			subs[i] = item(fmt.Sprintf("id%d", i), 100, fmt.Sprintf("http://example.com/item%d", i), "", false, "Item", 100, parser.StockInStock)
 		}
 
-		req := &proto.GetItemRequest{
-			UserId: "123",
-			Link:   "TestLink.ru",
-		}
+		var scraped atomic.Int32
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItems(mock.Anything, mock.Anything, mock.Anything).Return(subs, nil)
+		serv.EXPECT().ParserItem(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, link string) (*parser.Product, error) {
+				scraped.Add(1)
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+
+		handler := newHandler(serv)
+		handler.FanOut = FanOut{Concurrency: 2}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		resp, err := handler.GetAllItems(ctx, &proto.GetAllItemsRequest{UserId: "123"})
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Nil(t, resp)
+		assert.Equal(t, int32(2), scraped.Load(), "no scrape starts after the deadline")
+	})
 
-		handler := NewHandler(mock)
+}
 
-		resp, err := handler.GetItem(context.Background(), req)
-		if err.Error() != "cannot parse item" {
-			t.Errorf("unexpected err %v, expected %v", err, fmt.Errorf("cannot parse item"))
-		}
+func TestGetPriceHistory(t *testing.T) {
 
-		if resp != nil {
-			t.Errorf("resp should be nil")
-		}
+	t.Run("item is not tracked", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().PriceHistory(mock.Anything, mock.Anything).Return(nil, service.ErrItemNotTracked)
+
+		resp, err := newHandler(serv).GetPriceHistory(context.Background(), &proto.GetPriceHistoryRequest{UserId: "123", Link: "TestLink.ru"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Nil(t, resp)
 	})
 
+	t.Run("returns points", func(t *testing.T) {
+		observed := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+		from := observed.Add(-time.Hour)
+
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().PriceHistory(mock.Anything, service.HistoryQuery{
+			UserId:      "123",
+			Link:        "TestLink.ru",
+			From:        from,
+			Step:        time.Hour,
+			Aggregation: service.AggregationMin,
+		}).Return([]postgres_db.PricePoint{{Price: rub(99), Stock: int16(parser.StockOutOfStock), ObservedAt: observed}}, nil)
+
+		resp, err := newHandler(serv).GetPriceHistory(context.Background(), &proto.GetPriceHistoryRequest{
+			UserId:      "123",
+			Link:        "TestLink.ru",
+			From:        timestamppb.New(from),
+			Step:        durationpb.New(time.Hour),
+			Aggregation: proto.Aggregation_AGGREGATION_MIN,
+		})
+		assert.NoError(t, err)
+
+		assert.Len(t, resp.Points, 1)
+		assert.Equal(t, rubProto(99), resp.Points[0].Price)
+		assert.Equal(t, observed, resp.Points[0].Time.AsTime())
+		assert.Equal(t, proto.StockStatus_STOCK_STATUS_OUT_OF_STOCK, resp.Points[0].Status)
+	})
 }
 
-func TestGetAllItems(t *testing.T) {
+func TestRemoveItem(t *testing.T) {
+	const id = "b6d1a1b4-5f1e-4c1b-9d6a-1f2e3d4c5b6a"
 
-	t.Run("SelectAllItems return error", func(t *testing.T) {
-		mock := MockService{
-			SelectAllItemsFunc: func(userId string) (*sql.Rows, error) {
-				return nil, fmt.Errorf("cannot select items")
-			},
-		}
+	t.Run("invalid id", func(t *testing.T) {
+		resp, err := newHandler(mocks.NewServiceManager(t)).RemoveItem(context.Background(), &proto.RemoveItemRequest{UserId: "123", Id: "1"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Nil(t, resp)
+	})
+
+	t.Run("item is not tracked", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().RemoveItem(mock.Anything, mock.Anything, mock.Anything).Return(sql.ErrNoRows)
 
-		handler := NewHandler(mock)
+		resp, err := newHandler(serv).RemoveItem(context.Background(), &proto.RemoveItemRequest{UserId: "123", Id: id})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Nil(t, resp)
+	})
 
-		req := &proto.GetAllItemsRequest{
+	t.Run("removes item", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().RemoveItem(mock.Anything, "123", id).Return(nil)
+
+		_, err := newHandler(serv).RemoveItem(context.Background(), &proto.RemoveItemRequest{UserId: "123", Id: id})
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdateSubscription(t *testing.T) {
+	const id = "b6d1a1b4-5f1e-4c1b-9d6a-1f2e3d4c5b6a"
+
+	t.Run("item is not tracked", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().UpdateSubscription(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(postgres_db.Subscription{}, sql.ErrNoRows)
+
+		resp, err := newHandler(serv).UpdateSubscription(context.Background(), &proto.UpdateSubscriptionRequest{UserId: "123", Id: id})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Nil(t, resp)
+	})
+
+	t.Run("pauses item", func(t *testing.T) {
+		paused := true
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().UpdateSubscription(mock.Anything, "123", id, postgres_db.SubscriptionUpdate{Paused: &paused, ResetStartPrice: true}).
+			Return(postgres_db.Subscription{Product: postgres_db.Product{Name: "TestItem", CurrentPrice: rub(90)},
+				Id: id, StartPrice: rub(90), Paused: true}, nil)
+
+		resp, err := newHandler(serv).UpdateSubscription(context.Background(), &proto.UpdateSubscriptionRequest{
+			UserId:          "123",
+			Id:              id,
+			Paused:          &paused,
+			ResetStartPrice: true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "TestItem", resp.Item.Name)
+		assert.Equal(t, rubProto(0), resp.Item.DiffPrice)
+		assert.True(t, resp.Item.Paused)
+	})
+}
+
+func TestCreateAlertRule(t *testing.T) {
+	const id = "b6d1a1b4-5f1e-4c1b-9d6a-1f2e3d4c5b6a"
+
+	t.Run("invalid rule", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().CreateAlertRule(mock.Anything, mock.Anything, mock.Anything).
+			Return(postgres_db.AlertRule{}, fmt.Errorf("%w: target price must be positive", service.ErrInvalidRule))
+
+		resp, err := newHandler(serv).CreateAlertRule(context.Background(), &proto.CreateAlertRuleRequest{
 			UserId: "123",
-		}
+			ItemId: id,
+			Kind:   proto.AlertKind_ALERT_KIND_TARGET_PRICE,
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Nil(t, resp)
+	})
 
-		resp, err := handler.GetAllItems(context.Background(), req)
+	t.Run("item is not tracked", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().CreateAlertRule(mock.Anything, mock.Anything, mock.Anything).
+			Return(postgres_db.AlertRule{}, service.ErrItemNotTracked)
 
-		if err.Error() != "cannot get data from postgres Error: cannot select items" {
-			t.Errorf("unexpected err:%v, expected %v", err, fmt.Errorf("cannot get data from postgres Error: cannot select items"))
-		}
+		resp, err := newHandler(serv).CreateAlertRule(context.Background(), &proto.CreateAlertRuleRequest{
+			UserId: "123",
+			ItemId: id,
+			Kind:   proto.AlertKind_ALERT_KIND_ANY_DROP,
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Nil(t, resp)
+	})
 
-		if resp != nil {
-			t.Errorf("resp should be nil")
-		}
+	t.Run("creates rule", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().CreateAlertRule(mock.Anything, "123", mock.Anything).
+			RunAndReturn(func(ctx context.Context, userId string, rule postgres_db.AlertRule) (postgres_db.AlertRule, error) {
+				assert.Equal(t, int16(service.AlertPercentDrop), rule.Kind)
+				assert.Equal(t, float32(15), rule.DropPercent)
+
+				rule.Id = "rule"
+				return rule, nil
+			})
+
+		resp, err := newHandler(serv).CreateAlertRule(context.Background(), &proto.CreateAlertRuleRequest{
+			UserId:      "123",
+			ItemId:      id,
+			Kind:        proto.AlertKind_ALERT_KIND_PERCENT_DROP,
+			DropPercent: 15,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "rule", resp.Rule.Id)
+		assert.Equal(t, id, resp.Rule.ItemId)
+		assert.Equal(t, proto.AlertKind_ALERT_KIND_PERCENT_DROP, resp.Rule.Kind)
+	})
+}
 
+func TestDeleteAlertRule(t *testing.T) {
+	serv := mocks.NewServiceManager(t)
+	serv.EXPECT().DeleteAlertRule(mock.Anything, mock.Anything, mock.Anything).Return(service.ErrRuleNotFound)
+
+	resp, err := newHandler(serv).DeleteAlertRule(context.Background(), &proto.DeleteAlertRuleRequest{
+		UserId: "123",
+		Id:     "b6d1a1b4-5f1e-4c1b-9d6a-1f2e3d4c5b6a",
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Nil(t, resp)
+}
+
+func TestPendingAlerts(t *testing.T) {
+	created := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	previous := rub(120)
+
+	serv := mocks.NewServiceManager(t)
+	serv.EXPECT().PendingAlerts(mock.Anything, 10).Return([]postgres_db.AlertEvent{
+		{Id: "event", UserId: "123", Kind: int16(service.AlertTargetPrice), Price: rub(99), PreviousPrice: &previous, CreatedAt: created},
+		{Id: "first", UserId: "123", Kind: int16(service.AlertBackInStock), Price: rub(99), CreatedAt: created},
+	}, nil)
+
+	resp, err := newHandler(serv).PendingAlerts(context.Background(), &proto.PendingAlertsRequest{Limit: 10})
+	assert.NoError(t, err)
+
+	assert.Len(t, resp.Events, 2)
+	assert.Equal(t, proto.AlertKind_ALERT_KIND_TARGET_PRICE, resp.Events[0].Kind)
+	assert.Equal(t, rubProto(120), resp.Events[0].PreviousPrice)
+	assert.Equal(t, created, resp.Events[0].CreatedAt.AsTime())
+	assert.Nil(t, resp.Events[1].PreviousPrice)
+}
+
+func TestAckAlerts(t *testing.T) {
+	t.Run("invalid id", func(t *testing.T) {
+		resp, err := newHandler(mocks.NewServiceManager(t)).AckAlerts(context.Background(), &proto.AckAlertsRequest{Ids: []string{"1"}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Nil(t, resp)
 	})
 
-	t.Run("SelectAllItems return sql.Rows", func(t *testing.T) {
+	t.Run("acknowledges events", func(t *testing.T) {
+		ids := []string{"b6d1a1b4-5f1e-4c1b-9d6a-1f2e3d4c5b6a"}
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().AckAlerts(mock.Anything, ids).Return(nil)
 
-		db, mock, err := sqlmock.New()
+		_, err := newHandler(serv).AckAlerts(context.Background(), &proto.AckAlertsRequest{Ids: ids})
 		assert.NoError(t, err)
-		defer db.Close()
-
-		rows := sqlmock.NewRows([]string{"start_price", "link"}).
-			AddRow(100.0, "http://example.com/item1").
-			AddRow(200.0, "http://example.com/item2")
-
-		mock.ExpectQuery("SELECT start_price, link FROM items WHERE user_id = ?").
-			WithArgs("123").
-			WillReturnRows(rows)
-
-		mockS := MockService{
-			SelectAllItemsFunc: func(userId string) (*sql.Rows, error) {
-				return db.Query("SELECT start_price, link FROM items WHERE user_id = ?", userId)
-			},
-			ParserItemFunc: func(link string) (string, string, float32, error) {
-				if link == "http://example.com/item1" {
-					return "Item1", "Available", 110.0, nil
-				}
-				return "Item2", "Out of stock", 190.0, nil
-			},
-		}
+	})
+}
 
-		handler := NewHandler(mockS)
+func TestReplayOutboxEvents(t *testing.T) {
+	t.Run("invalid id", func(t *testing.T) {
+		resp, err := newHandler(mocks.NewServiceManager(t)).ReplayOutboxEvents(context.Background(), &proto.ReplayOutboxEventsRequest{Ids: []string{"1"}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Nil(t, resp)
+	})
 
-		req := &proto.GetAllItemsRequest{UserId: "123"}
+	t.Run("replays all dead events", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().ReplayOutboxEvents(mock.Anything, []string(nil)).Return(3, nil)
 
-		resp, err := handler.GetAllItems(context.Background(), req)
+		resp, err := newHandler(serv).ReplayOutboxEvents(context.Background(), &proto.ReplayOutboxEventsRequest{})
 		assert.NoError(t, err)
+		assert.Equal(t, int64(3), resp.Replayed)
+	})
+}
 
-		assert.Len(t, resp.Items, 2)
-		assert.Equal(t, "Item1", resp.Items[0].Name)
-		assert.Equal(t, float32(10.0), resp.Items[0].DiffPrice)
-		assert.Equal(t, "Item2", resp.Items[1].Name)
-		assert.Equal(t, float32(-10.0), resp.Items[1].DiffPrice)
+func TestCreateWebhook(t *testing.T) {
+	t.Run("invalid url", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().CreateWebhook(mock.Anything, mock.Anything, mock.Anything).Return(postgres_db.Webhook{}, service.ErrInvalidWebhook)
 
-		assert.NoError(t, mock.ExpectationsWereMet())
+		resp, err := newHandler(serv).CreateWebhook(context.Background(), &proto.CreateWebhookRequest{UserId: "123", Url: "ftp://x"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Nil(t, resp)
+	})
+
+	t.Run("returns the secret once", func(t *testing.T) {
+		hook := postgres_db.Webhook{Id: "hook", UserId: "123", URL: "https://example.com/hook", Secret: "secret", Active: true}
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().CreateWebhook(mock.Anything, "123", hook.URL).Return(hook, nil)
+		serv.EXPECT().Webhooks(mock.Anything, "123").Return([]postgres_db.Webhook{hook}, nil)
+
+		created, err := newHandler(serv).CreateWebhook(context.Background(), &proto.CreateWebhookRequest{UserId: "123", Url: hook.URL})
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", created.Webhook.Secret)
 
+		listed, err := newHandler(serv).ListWebhooks(context.Background(), &proto.ListWebhooksRequest{UserId: "123"})
+		assert.NoError(t, err)
+		assert.Len(t, listed.Webhooks, 1)
+		assert.Empty(t, listed.Webhooks[0].Secret)
 	})
+}
+
+func TestListWebhookDeliveries(t *testing.T) {
+	const id = "b6d1a1b4-5f1e-4c1b-9d6a-1f2e3d4c5b6a"
+
+	t.Run("invalid id", func(t *testing.T) {
+		resp, err := newHandler(mocks.NewServiceManager(t)).ListWebhookDeliveries(context.Background(), &proto.ListWebhookDeliveriesRequest{WebhookId: "1"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Nil(t, resp)
+	})
+
+	t.Run("webhook of another user", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().WebhookDeliveries(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, service.ErrWebhookNotFound)
+
+		resp, err := newHandler(serv).ListWebhookDeliveries(context.Background(), &proto.ListWebhookDeliveriesRequest{UserId: "123", WebhookId: id})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Nil(t, resp)
+	})
+
+	t.Run("lists deliveries", func(t *testing.T) {
+		delivered := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().WebhookDeliveries(mock.Anything, "123", id, 20).Return([]postgres_db.WebhookDelivery{
+			{Id: "ok", Topic: postgres_db.TopicAlert, Status: postgres_db.DeliveryDelivered, Attempts: 1, ResponseCode: 204, DeliveredAt: &delivered},
+			{Id: "retry", Topic: postgres_db.TopicPriceChanged, Status: postgres_db.DeliveryPending, Attempts: 2, LastError: "unexpected status 500"},
+		}, nil)
+
+		resp, err := newHandler(serv).ListWebhookDeliveries(context.Background(),
+			&proto.ListWebhookDeliveriesRequest{UserId: "123", WebhookId: id, Limit: 20})
+		assert.NoError(t, err)
+
+		assert.Len(t, resp.Deliveries, 2)
+		assert.Equal(t, proto.DeliveryStatus_DELIVERY_STATUS_DELIVERED, resp.Deliveries[0].Status)
+		assert.Equal(t, delivered, resp.Deliveries[0].DeliveredAt.AsTime())
+		assert.Equal(t, proto.DeliveryStatus_DELIVERY_STATUS_PENDING, resp.Deliveries[1].Status)
+		assert.Nil(t, resp.Deliveries[1].DeliveredAt)
+	})
+}
 
+// headerStream captures the response metadata set by a handler.
+type headerStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (h *headerStream) SetHeader(md metadata.MD) error {
+	h.header = metadata.Join(h.header, md)
+	return nil
+}
+
+func TestCacheHeader(t *testing.T) {
+	t.Run("GetItem", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItem(mock.Anything, mock.Anything, mock.Anything).Return(postgres_db.Subscription{Id: "id1", StartPrice: rub(100)}, nil)
+		serv.EXPECT().ParserItem(mock.Anything, mock.Anything).Return(&parser.Product{Name: "Item", Price: rub(90), FromCache: true}, nil)
+
+		stream := &headerStream{}
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+
+		_, err := newHandler(serv).GetItem(ctx, &proto.GetItemRequest{UserId: "123", Link: "http://example.com/item"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"hit"}, stream.header.Get(CacheHeader))
+	})
+
+	t.Run("GetAllItems", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SelectItems(mock.Anything, mock.Anything, mock.Anything).Return([]postgres_db.Subscription{
+			item("id1", 100, "http://example.com/item1", "", false, "Item1", 100, parser.StockInStock),
+			item("id2", 100, "http://example.com/item2", "", false, "Item2", 100, parser.StockInStock),
+			item("id3", 100, "http://example.com/item3", "", false, "Item3", 100, parser.StockInStock),
+		}, nil)
+		serv.EXPECT().ParserItem(mock.Anything, "http://example.com/item1").Return(&parser.Product{Price: rub(90), FromCache: true}, nil)
+		serv.EXPECT().ParserItem(mock.Anything, "http://example.com/item2").Return(&parser.Product{Price: rub(90)}, nil)
+		serv.EXPECT().ParserItem(mock.Anything, "http://example.com/item3").Return(nil, parser.ErrBlocked)
+
+		stream := &headerStream{}
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+
+		_, err := newHandler(serv).GetAllItems(ctx, &proto.GetAllItemsRequest{UserId: "123"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"hit", "miss", "miss"}, stream.header.Get(CacheHeader))
+	})
+}
+
+func TestHealth(t *testing.T) {
+	opened := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	serv := mocks.NewServiceManager(t)
+	serv.EXPECT().UpstreamHealth(mock.Anything).Return([]scraper.BreakerStatus{
+		{Name: "shop.example", State: scraper.BreakerClosed, Failures: 1},
+		{Name: "wildberries", State: scraper.BreakerOpen, Failures: 5, OpenedAt: opened},
+	})
+
+	resp, err := newHandler(serv).Health(context.Background(), &proto.HealthRequest{})
+	assert.NoError(t, err)
+
+	assert.Len(t, resp.Upstreams, 2)
+	assert.Equal(t, proto.BreakerState_BREAKER_STATE_CLOSED, resp.Upstreams[0].State)
+	assert.Nil(t, resp.Upstreams[0].OpenedAt)
+	assert.Equal(t, "wildberries", resp.Upstreams[1].Name)
+	assert.Equal(t, proto.BreakerState_BREAKER_STATE_OPEN, resp.Upstreams[1].State)
+	assert.Equal(t, int32(5), resp.Upstreams[1].ConsecutiveFailures)
+	assert.Equal(t, opened, resp.Upstreams[1].OpenedAt.AsTime())
+}
+
+func TestSetDisplayCurrency(t *testing.T) {
+	t.Run("invalid currency", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SetDisplayCurrency(mock.Anything, "123", "rubles").Return("", fmt.Errorf("%w: %q", money.ErrInvalidCurrency, "rubles"))
+
+		resp, err := newHandler(serv).SetDisplayCurrency(context.Background(), &proto.SetDisplayCurrencyRequest{UserId: "123", Currency: "rubles"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Nil(t, resp)
+	})
+
+	t.Run("returns the stored currency", func(t *testing.T) {
+		serv := mocks.NewServiceManager(t)
+		serv.EXPECT().SetDisplayCurrency(mock.Anything, "123", "usd").Return("USD", nil)
+
+		resp, err := newHandler(serv).SetDisplayCurrency(context.Background(), &proto.SetDisplayCurrencyRequest{UserId: "123", Currency: "usd"})
+		assert.NoError(t, err)
+		assert.Equal(t, "USD", resp.Currency)
+	})
 }
@@ -0,0 +1,165 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is assumed for prices of shops that do not state one.
+const DefaultCurrency = "RUB"
+
+var (
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrInvalidCurrency  = errors.New("invalid currency code")
+	ErrCurrencyMismatch = errors.New("amounts are in different currencies")
+)
+
+// Money is an exact amount in the minor units of an ISO 4217 currency, e.g.
+// kopecks for RUB. The zero value is no amount at all.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// Exponent returns the number of minor unit digits of currency.
+func Exponent(currency string) int {
+	switch strings.ToUpper(currency) {
+	case "JPY", "KRW", "VND", "CLP", "ISK", "UZS":
+		return 0
+	case "KWD", "BHD", "OMR", "JOD", "TND":
+		return 3
+	default:
+		return 2
+	}
+}
+
+// Parse reads a decimal amount in major units, "1999.5" or "1999", with a
+// dot as the decimal separator. Digits beyond the minor unit are rounded
+// half away from zero.
+func Parse(s, currency string) (Money, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok || strings.ContainsAny(s, "/eE") {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	amount, ok := round(r.Mul(r, pow10(Exponent(currency))))
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+
+	return New(amount, currency), nil
+}
+
+// ValidCurrency reports whether code looks like an ISO 4217 code: three
+// upper case letters.
+func ValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Convert returns m in currency at rate, the number of units of currency one
+// unit of m's currency is worth. The result is rounded half away from zero
+// to the minor unit of currency.
+func (m Money) Convert(rate *big.Rat, currency string) (Money, error) {
+	r := new(big.Rat).SetInt64(m.Amount)
+	r.Mul(r, rate)
+	r.Mul(r, pow10(Exponent(currency)))
+	r.Quo(r, pow10(Exponent(m.Currency)))
+
+	amount, ok := round(r)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %s at %s", ErrInvalidAmount, m, rate.FloatString(6))
+	}
+	return New(amount, currency), nil
+}
+
+func pow10(n int) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil))
+}
+
+// round rounds r half away from zero, false if it does not fit an int64.
+func round(r *big.Rat) (int64, bool) {
+	// (2n + d) / 2d truncated, sign restored.
+	num, den := new(big.Int).Abs(r.Num()), r.Denom()
+	q := new(big.Int).Quo(new(big.Int).Add(new(big.Int).Mul(num, big.NewInt(2)), den), new(big.Int).Mul(den, big.NewInt(2)))
+	if r.Sign() < 0 {
+		q.Neg(q)
+	}
+	return q.Int64(), q.IsInt64()
+}
+
+// IsZero reports whether m is the zero value, no amount.
+func (m Money) IsZero() bool {
+	return m == Money{}
+}
+
+// Sub returns m - o. Both must be in the same currency.
+func (m Money) Sub(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount - o.Amount, Currency: m.Currency}, nil
+}
+
+// Cmp compares m and o, which must be in the same currency: -1 if m < o,
+// 0 if they are equal, +1 if m > o.
+func (m Money) Cmp(o Money) (int, error) {
+	if m.Currency != o.Currency {
+		return 0, ErrCurrencyMismatch
+	}
+	switch {
+	case m.Amount < o.Amount:
+		return -1, nil
+	case m.Amount > o.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// DiscountPercent returns by how many whole percent price is below original,
+// rounded half up, zero if it is not below or the currencies differ.
+func DiscountPercent(original, price Money) int {
+	if original.Currency != price.Currency || original.Amount <= 0 || price.Amount >= original.Amount {
+		return 0
+	}
+	off := original.Amount - price.Amount
+	return int((off*200 + original.Amount) / (original.Amount * 2))
+}
+
+// Major formats the amount in major units: "1999.50".
+func (m Money) Major() string {
+	exp := Exponent(m.Currency)
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if exp == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+// String formats m for people: "1999.50 RUB".
+func (m Money) String() string {
+	return m.Major() + " " + m.Currency
+}
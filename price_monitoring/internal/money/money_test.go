@@ -0,0 +1,95 @@
+package money
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		currency string
+		want     Money
+	}{
+		{"1999.5", "RUB", Money{199950, "RUB"}},
+		{"1999", "rub", Money{199900, "RUB"}},
+		{"0.1", "USD", Money{10, "USD"}},
+		{"19.999", "USD", Money{2000, "USD"}},
+		{"19.994", "USD", Money{1999, "USD"}},
+		{"-0.005", "EUR", Money{-1, "EUR"}},
+		{"1500", "JPY", Money{1500, "JPY"}},
+		{"1500.5", "JPY", Money{1501, "JPY"}},
+	} {
+		got, err := Parse(tc.in, tc.currency)
+		require.NoError(t, err, tc.in)
+		assert.Equal(t, tc.want, got, tc.in)
+	}
+
+	for _, in := range []string{"", "abc", "1/2", "1e3", "99999999999999999999999"} {
+		_, err := Parse(in, "RUB")
+		assert.ErrorIs(t, err, ErrInvalidAmount, in)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	diff, err := New(99990, "RUB").Sub(New(100000, "RUB"))
+	require.NoError(t, err)
+	assert.Equal(t, New(-10, "RUB"), diff)
+
+	_, err = New(1, "RUB").Sub(New(1, "USD"))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	cmp, err := New(1, "RUB").Cmp(New(2, "RUB"))
+	require.NoError(t, err)
+	assert.Equal(t, -1, cmp)
+}
+
+func TestDiscountPercent(t *testing.T) {
+	assert.Equal(t, 25, DiscountPercent(New(400000, "RUB"), New(300000, "RUB")))
+	assert.Equal(t, 33, DiscountPercent(New(300, "RUB"), New(200, "RUB")))
+	assert.Equal(t, 67, DiscountPercent(New(300, "RUB"), New(100, "RUB")))
+	assert.Equal(t, 0, DiscountPercent(New(300, "RUB"), New(300, "RUB")))
+	assert.Equal(t, 0, DiscountPercent(Money{}, New(300, "RUB")))
+	assert.Equal(t, 0, DiscountPercent(New(400, "USD"), New(300, "RUB")))
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "1999.50 RUB", New(199950, "RUB").String())
+	assert.Equal(t, "0.05", New(5, "RUB").Major())
+	assert.Equal(t, "-0.10", New(-10, "USD").Major())
+	assert.Equal(t, "1500 JPY", New(1500, "JPY").String())
+	assert.Equal(t, "1.500", New(1500, "KWD").Major())
+}
+
+func TestConvert(t *testing.T) {
+	rate := func(s string) *big.Rat {
+		r, _ := new(big.Rat).SetString(s)
+		return r
+	}
+
+	got, err := New(1999, "USD").Convert(rate("90.125"), "RUB")
+	require.NoError(t, err)
+	assert.Equal(t, New(180160, "RUB"), got, "19.99 * 90.125 = 1801.59875")
+
+	got, err = New(100000, "RUB").Convert(rate("1.6"), "JPY")
+	require.NoError(t, err)
+	assert.Equal(t, New(1600, "JPY"), got)
+
+	got, err = New(1500, "JPY").Convert(rate("0.00625"), "USD")
+	require.NoError(t, err)
+	assert.Equal(t, New(938, "USD"), got, "9.375 rounds half away from zero")
+
+	_, err = New(math.MaxInt64, "USD").Convert(rate("100"), "RUB")
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+}
+
+func TestValidCurrency(t *testing.T) {
+	assert.True(t, ValidCurrency("USD"))
+	for _, code := range []string{"", "usd", "US", "USDT", "U$D"} {
+		assert.False(t, ValidCurrency(code), code)
+	}
+}
@@ -0,0 +1,53 @@
+// Package netguard keeps the outgoing requests of the tracker, to webhooks
+// and to the links of the users, out of its own network.
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"syscall"
+)
+
+// ErrPrivateAddress is returned for URLs pointing into the network of the
+// tracker: its own services, the cloud metadata endpoint, localhost.
+var ErrPrivateAddress = errors.New("address is not public")
+
+// PublicAddr reports whether ip is reachable from the internet, not a
+// loopback, private, link-local, multicast or unspecified address.
+func PublicAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsValid() && !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+// CheckHost resolves host and fails with ErrPrivateAddress if any of its
+// addresses is not public.
+func CheckHost(ctx context.Context, host string) error {
+	ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("cannot resolve %s: %w", host, err)
+	}
+	for _, ip := range ips {
+		if !PublicAddr(ip) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrPrivateAddress, host, ip)
+		}
+	}
+	return nil
+}
+
+// Control is a net.Dialer.Control refusing connections to addresses that are
+// not public. It runs after the name is resolved, so a host that resolved to
+// a public address when it was checked cannot be rebound to a private one.
+func Control(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !PublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, addrPort.Addr())
+	}
+	return nil
+}
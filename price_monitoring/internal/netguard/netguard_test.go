@@ -0,0 +1,35 @@
+package netguard
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicAddr(t *testing.T) {
+	for addr, public := range map[string]bool{
+		"93.184.216.34":    true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"10.0.0.5":         false,
+		"172.18.0.2":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"0.0.0.0":          false,
+		"::1":              false,
+		"fe80::1":          false,
+		"fd00::1":          false,
+		"::ffff:127.0.0.1": false,
+	} {
+		assert.Equal(t, public, PublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	assert.ErrorIs(t, CheckHost(context.Background(), "127.0.0.1"), ErrPrivateAddress)
+	assert.ErrorIs(t, CheckHost(context.Background(), "169.254.169.254"), ErrPrivateAddress)
+	assert.ErrorIs(t, CheckHost(context.Background(), "localhost"), ErrPrivateAddress)
+	assert.NoError(t, CheckHost(context.Background(), "93.184.216.34"))
+}
@@ -0,0 +1,155 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	postgres_db "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
+)
+
+// Store is the part of the database the relay needs.
+type Store interface {
+	ClaimOutboxEventsFromDB(ctx context.Context, limit int, lease time.Duration) ([]postgres_db.OutboxEvent, error)
+	MarkOutboxSentFromDB(ctx context.Context, id string) error
+	MarkOutboxSinkDeliveredFromDB(ctx context.Context, id string, sink string) error
+	MarkOutboxFailedFromDB(ctx context.Context, id string, lastErr string, retryAt time.Time, dead bool) error
+}
+
+// Sink publishes outbox events to one destination. After a failure an event
+// is published again only to the sinks that have not accepted it, named by
+// Name. A relay dying between publishing and recording the delivery still
+// repeats it, so sinks must tolerate duplicates, e.g. by using the event id
+// as idempotency key.
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, event postgres_db.OutboxEvent) error
+}
+
+type Config struct {
+	// PollInterval between two checks for due events.
+	PollInterval time.Duration
+	BatchSize    int
+	// MaxAttempts after which an event is moved to the dead letter state.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled after every
+	// failed attempt up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Lease hides claimed events from other relays while they are published.
+	Lease time.Duration
+}
+
+// Relay publishes the events written to the outbox to every sink and marks
+// them sent once all sinks accepted them.
+type Relay struct {
+	store Store
+	sinks []Sink
+	cfg   Config
+}
+
+func New(store Store, sinks []Sink, cfg Config) *Relay {
+	if cfg.BatchSize < 1 {
+		cfg.BatchSize = 100
+	}
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+
+	return &Relay{
+		store: store,
+		sinks: sinks,
+		cfg:   cfg,
+	}
+}
+
+// Run relays events until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	for {
+		// A full batch means more events are due, fetch them right away.
+		if r.RunOnce(ctx) == r.cfg.BatchSize {
+			continue
+		}
+
+		timer := time.NewTimer(r.cfg.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// RunOnce relays one batch of due events and returns its size.
+func (r *Relay) RunOnce(ctx context.Context) int {
+	if ctx.Err() != nil {
+		return 0
+	}
+
+	events, err := r.store.ClaimOutboxEventsFromDB(ctx, r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		log.Printf("outbox: cannot claim events err: %v", err)
+		return 0
+	}
+
+	for _, event := range events {
+		r.relay(ctx, event)
+	}
+
+	return len(events)
+}
+
+func (r *Relay) relay(ctx context.Context, event postgres_db.OutboxEvent) {
+	err := r.publish(ctx, event)
+	if err == nil {
+		if err := r.store.MarkOutboxSentFromDB(ctx, event.Id); err != nil {
+			log.Printf("outbox: cannot mark %s sent err: %v", event.Id, err)
+		}
+		return
+	}
+
+	attempts := event.Attempts + 1
+	dead := attempts >= r.cfg.MaxAttempts
+	if dead {
+		log.Printf("outbox: giving up on %s %s after %d attempts err: %v", event.Topic, event.Id, attempts, err)
+	} else {
+		log.Printf("outbox: cannot publish %s %s err: %v", event.Topic, event.Id, err)
+	}
+
+	if err := r.store.MarkOutboxFailedFromDB(ctx, event.Id, err.Error(), time.Now().Add(r.backoff(attempts)), dead); err != nil {
+		log.Printf("outbox: cannot record failure of %s err: %v", event.Id, err)
+	}
+}
+
+// publish sends event to the sinks that have not accepted it yet and records
+// every delivery, so that a retry skips them.
+func (r *Relay) publish(ctx context.Context, event postgres_db.OutboxEvent) error {
+	var errs []error
+	for _, sink := range r.sinks {
+		if slices.Contains(event.DeliveredSinks, sink.Name()) {
+			continue
+		}
+		if err := sink.Publish(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+			continue
+		}
+		if err := r.store.MarkOutboxSinkDeliveredFromDB(ctx, event.Id, sink.Name()); err != nil {
+			log.Printf("outbox: cannot record delivery of %s to %s err: %v", event.Id, sink.Name(), err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// backoff returns the delay before the retry following the given number of
+// failed attempts.
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.cfg.Backoff
+	for i := 1; i < attempts && delay < r.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, r.cfg.MaxBackoff)
+}
@@ -0,0 +1,166 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	postgres_db "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
+)
+
+type failure struct {
+	retryAt time.Time
+	dead    bool
+}
+
+type fakeStore struct {
+	events    []postgres_db.OutboxEvent
+	sent      []string
+	failed    map[string]failure
+	delivered map[string][]string
+}
+
+func (f *fakeStore) ClaimOutboxEventsFromDB(ctx context.Context, limit int, lease time.Duration) ([]postgres_db.OutboxEvent, error) {
+	events := f.events[:min(limit, len(f.events))]
+	f.events = f.events[len(events):]
+	for i := range events {
+		events[i].DeliveredSinks = append(events[i].DeliveredSinks, f.delivered[events[i].Id]...)
+	}
+	return events, nil
+}
+
+func (f *fakeStore) MarkOutboxSinkDeliveredFromDB(ctx context.Context, id string, sink string) error {
+	if f.delivered == nil {
+		f.delivered = map[string][]string{}
+	}
+	f.delivered[id] = append(f.delivered[id], sink)
+	return nil
+}
+
+func (f *fakeStore) MarkOutboxSentFromDB(ctx context.Context, id string) error {
+	f.sent = append(f.sent, id)
+	return nil
+}
+
+func (f *fakeStore) MarkOutboxFailedFromDB(ctx context.Context, id string, lastErr string, retryAt time.Time, dead bool) error {
+	f.failed[id] = failure{retryAt: retryAt, dead: dead}
+	return nil
+}
+
+type fakeSink struct {
+	name      string
+	fail      map[string]bool
+	published []string
+}
+
+func (f *fakeSink) Name() string {
+	return f.name
+}
+
+func (f *fakeSink) Publish(ctx context.Context, event postgres_db.OutboxEvent) error {
+	f.published = append(f.published, event.Id)
+	if f.fail[event.Id] {
+		return fmt.Errorf("unavailable")
+	}
+	return nil
+}
+
+func TestRunOnce(t *testing.T) {
+	store := &fakeStore{
+		events: []postgres_db.OutboxEvent{
+			{Id: "ok"},
+			{Id: "retry", Attempts: 1},
+			{Id: "dead", Attempts: 2},
+		},
+		failed: map[string]failure{},
+	}
+	healthy := &fakeSink{name: "healthy"}
+	broken := &fakeSink{name: "broken", fail: map[string]bool{"retry": true, "dead": true}}
+
+	relay := New(store, []Sink{healthy, broken}, Config{
+		BatchSize:   10,
+		MaxAttempts: 3,
+		Backoff:     time.Minute,
+		MaxBackoff:  time.Hour,
+	})
+
+	start := time.Now()
+	assert.Equal(t, 3, relay.RunOnce(context.Background()))
+
+	assert.Equal(t, []string{"ok", "retry", "dead"}, healthy.published)
+	assert.Equal(t, []string{"ok"}, store.sent)
+
+	assert.False(t, store.failed["retry"].dead)
+	assert.WithinDuration(t, start.Add(2*time.Minute), store.failed["retry"].retryAt, time.Second)
+	assert.True(t, store.failed["dead"].dead)
+}
+
+func TestRetryOnlyFailedSinks(t *testing.T) {
+	store := &fakeStore{
+		events: []postgres_db.OutboxEvent{{Id: "event"}},
+		failed: map[string]failure{},
+	}
+	first := &fakeSink{name: "first"}
+	broken := &fakeSink{name: "broken", fail: map[string]bool{"event": true}}
+	last := &fakeSink{name: "last"}
+
+	relay := New(store, []Sink{first, broken, last}, Config{BatchSize: 10, MaxAttempts: 3})
+
+	assert.Equal(t, 1, relay.RunOnce(context.Background()))
+	assert.Contains(t, store.failed, "event")
+	assert.ElementsMatch(t, []string{"first", "last"}, store.delivered["event"])
+
+	// The retry reaches the broken sink alone.
+	broken.fail = nil
+	store.events = []postgres_db.OutboxEvent{{Id: "event", Attempts: 1}}
+	assert.Equal(t, 1, relay.RunOnce(context.Background()))
+
+	assert.Equal(t, []string{"event"}, first.published)
+	assert.Equal(t, []string{"event"}, last.published)
+	assert.Equal(t, []string{"event", "event"}, broken.published)
+	assert.Equal(t, []string{"event"}, store.sent)
+}
+
+func TestBackoff(t *testing.T) {
+	relay := New(nil, nil, Config{Backoff: time.Second, MaxBackoff: 5 * time.Second})
+
+	assert.Equal(t, time.Second, relay.backoff(1))
+	assert.Equal(t, 4*time.Second, relay.backoff(3))
+	assert.Equal(t, 5*time.Second, relay.backoff(10))
+}
+
+func TestWebhookSink(t *testing.T) {
+	var got Envelope
+	var key string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key = r.Header.Get("Idempotency-Key")
+		body, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, &got))
+
+		if got.Topic == "broken" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, time.Second)
+
+	err := sink.Publish(context.Background(), postgres_db.OutboxEvent{
+		Id:      "event",
+		Topic:   postgres_db.TopicPriceChanged,
+		Payload: []byte(`{"price":{"amount":1000,"currency":"RUB"}}`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "event", key)
+	assert.Equal(t, postgres_db.TopicPriceChanged, got.Topic)
+	assert.JSONEq(t, `{"price":{"amount":1000,"currency":"RUB"}}`, string(got.Data))
+
+	err = sink.Publish(context.Background(), postgres_db.OutboxEvent{Id: "event", Topic: "broken", Payload: []byte(`{}`)})
+	assert.Error(t, err)
+}
@@ -0,0 +1,136 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	postgres_db "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
+)
+
+// Envelope is the JSON document sent to webhooks.
+type Envelope struct {
+	Id        string          `json:"id"`
+	Topic     string          `json:"topic"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// AlertInbox is the queue the Telegram bot pulls alerts from.
+type AlertInbox interface {
+	InsertAlertEventsFromDB(ctx context.Context, events []postgres_db.AlertEvent) error
+}
+
+// TelegramSink hands alert events over to the Telegram bot. Other topics are ignored.
+type TelegramSink struct {
+	inbox AlertInbox
+}
+
+func NewTelegramSink(inbox AlertInbox) *TelegramSink {
+	return &TelegramSink{
+		inbox: inbox,
+	}
+}
+
+func (s *TelegramSink) Name() string {
+	return "telegram"
+}
+
+func (s *TelegramSink) Publish(ctx context.Context, event postgres_db.OutboxEvent) error {
+	if event.Topic != postgres_db.TopicAlert {
+		return nil
+	}
+
+	var alert postgres_db.AlertEvent
+	if err := json.Unmarshal(event.Payload, &alert); err != nil {
+		return fmt.Errorf("invalid alert payload: %w", err)
+	}
+
+	return s.inbox.InsertAlertEventsFromDB(ctx, []postgres_db.AlertEvent{alert})
+}
+
+// WebhookSink POSTs every event as an Envelope to a fixed URL. The event id
+// is sent in the Idempotency-Key header for receivers to drop duplicates.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookSink(url string, timeout time.Duration) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+func (s *WebhookSink) Publish(ctx context.Context, event postgres_db.OutboxEvent) error {
+	body, err := json.Marshal(Envelope{
+		Id:        event.Id,
+		Topic:     event.Topic,
+		CreatedAt: event.CreatedAt,
+		Data:      event.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", event.Id)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// RedisSink appends every event to a Redis stream. Consumers deduplicate by
+// the id field.
+type RedisSink struct {
+	client *redis.Client
+	stream string
+	maxLen int64
+}
+
+func NewRedisSink(client *redis.Client, stream string, maxLen int64) *RedisSink {
+	return &RedisSink{
+		client: client,
+		stream: stream,
+		maxLen: maxLen,
+	}
+}
+
+func (s *RedisSink) Name() string {
+	return "redis"
+}
+
+func (s *RedisSink) Publish(ctx context.Context, event postgres_db.OutboxEvent) error {
+	return s.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: s.stream,
+		MaxLen: s.maxLen,
+		Approx: true,
+		Values: map[string]any{
+			"id":         event.Id,
+			"topic":      event.Topic,
+			"created_at": event.CreatedAt.Format(time.RFC3339Nano),
+			"payload":    string(event.Payload),
+		},
+	}).Err()
+}
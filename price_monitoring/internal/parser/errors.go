@@ -0,0 +1,48 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/netguard"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/scraper"
+)
+
+var (
+	ErrInvalidLink         = errors.New("Ссылка некорректна. Убедись, что она ведёт на страницу товара.")
+	ErrOutOfStock          = errors.New("Товара нет в наличии")
+	ErrProductNotFound     = errors.New("Не удалось извлечь информацию. Возможно, товар не существует или временно недоступен.")
+	ErrUpstreamUnavailable = errors.New("Магазин временно недоступен, попробуй позже.")
+	ErrBlocked             = errors.New("Магазин отклонил запрос, попробуй позже.")
+	ErrVariantNotFound     = errors.New("У товара нет такого варианта, выбери размер или цвет из списка.")
+	// ErrNotApplicable is returned by an Extractor that does not handle the link.
+	ErrNotApplicable = errors.New("extractor does not handle this link")
+)
+
+// fetchError classifies a transport level failure into the parser error taxonomy.
+// Cancellation by the caller is returned as is.
+func fetchError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return err
+	}
+	if errors.Is(err, netguard.ErrPrivateAddress) {
+		return fmt.Errorf("%w: %v", ErrInvalidLink, err)
+	}
+
+	var statusErr *scraper.StatusError
+	if errors.As(err, &statusErr) {
+		if statusErr.Challenge {
+			return fmt.Errorf("%w: %v", ErrBlocked, err)
+		}
+		switch statusErr.Code {
+		case http.StatusNotFound, http.StatusGone:
+			return fmt.Errorf("%w: %v", ErrProductNotFound, err)
+		case http.StatusForbidden, http.StatusTooManyRequests:
+			return fmt.Errorf("%w: %v", ErrBlocked, err)
+		}
+	}
+
+	return fmt.Errorf("%w: %v", ErrUpstreamUnavailable, err)
+}
@@ -0,0 +1,358 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/scraper"
+)
+
+// Generic extracts a product from arbitrary shop pages using the structured
+// data most shops embed for search engines. Strategies are tried from the most
+// to the least reliable one: JSON-LD, OpenGraph, microdata.
+type Generic struct {
+	client *scraper.Client
+}
+
+func NewGeneric(client *scraper.Client) *Generic {
+	return &Generic{
+		client: client,
+	}
+}
+
+func (g *Generic) Extract(ctx context.Context, link string) (*Product, error) {
+	body, err := g.client.Get(ctx, link)
+	if err != nil {
+		return nil, fetchError(ctx, err)
+	}
+
+	return ExtractHTML(body)
+}
+
+// ExtractHTML runs the generic strategies against an already fetched page.
+func ExtractHTML(body []byte) (*Product, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, extract := range []func(*goquery.Document) (*Product, bool){
+		extractJSONLD,
+		extractOpenGraph,
+		extractMicrodata,
+	} {
+		if product, ok := extract(doc); ok {
+			return product, nil
+		}
+	}
+
+	return nil, ErrProductNotFound
+}
+
+func extractJSONLD(doc *goquery.Document) (*Product, bool) {
+	var product *Product
+
+	doc.Find(`script[type="application/ld+json"]`).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		var data any
+		if err := json.Unmarshal([]byte(s.Text()), &data); err != nil {
+			return true
+		}
+
+		node, ok := findLDProduct(data)
+		if !ok {
+			return true
+		}
+
+		product, ok = ldProduct(node)
+		return !ok
+	})
+
+	return product, product != nil
+}
+
+// findLDProduct walks a JSON-LD document, including arrays and @graph
+// containers, looking for a node of type Product.
+func findLDProduct(data any) (map[string]any, bool) {
+	switch v := data.(type) {
+	case []any:
+		for _, item := range v {
+			if node, ok := findLDProduct(item); ok {
+				return node, true
+			}
+		}
+	case map[string]any:
+		if ldHasType(v["@type"], "Product") {
+			return v, true
+		}
+		if graph, ok := v["@graph"]; ok {
+			return findLDProduct(graph)
+		}
+	}
+	return nil, false
+}
+
+func ldHasType(t any, want string) bool {
+	switch v := t.(type) {
+	case string:
+		return v == want || strings.HasSuffix(v, "/"+want)
+	case []any:
+		for _, item := range v {
+			if ldHasType(item, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func ldProduct(node map[string]any) (*Product, bool) {
+	offer, ok := ldOffer(node["offers"])
+	if !ok {
+		return nil, false
+	}
+
+	price, ok := ldPrice(offer, "")
+	if !ok {
+		return nil, false
+	}
+
+	name, _ := node["name"].(string)
+	availability, _ := offer["availability"].(string)
+
+	product := &Product{
+		Name:     strings.TrimSpace(name),
+		Price:    price,
+		Brand:    ldName(node["brand"]),
+		Seller:   ldName(offer["seller"]),
+		ImageURL: ldImage(node["image"]),
+		Stock:    stock(availability),
+		Strategy: StrategyJSONLD,
+	}
+	if rating, ok := node["aggregateRating"].(map[string]any); ok {
+		if value, ok := ldNumber(rating["ratingValue"]); ok {
+			product.Rating = float32(value)
+		}
+		for _, key := range []string{"reviewCount", "ratingCount"} {
+			if count, ok := ldNumber(rating[key]); ok {
+				product.Reviews = int(count)
+				break
+			}
+		}
+	}
+	return product, true
+}
+
+// ldName reads things like brand and seller, given as a name or as a node.
+func ldName(v any) string {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]any:
+		name, _ := v["name"].(string)
+		return strings.TrimSpace(name)
+	}
+	return ""
+}
+
+// ldImage returns the first image, given as a URL, a list or an ImageObject.
+func ldImage(v any) string {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case []any:
+		if len(v) > 0 {
+			return ldImage(v[0])
+		}
+	case map[string]any:
+		return ldImage(v["url"])
+	}
+	return ""
+}
+
+func ldNumber(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func ldOffer(offers any) (map[string]any, bool) {
+	switch v := offers.(type) {
+	case map[string]any:
+		return v, true
+	case []any:
+		for _, item := range v {
+			if offer, ok := item.(map[string]any); ok {
+				return offer, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// ldPrice reads the price of an offer. A priceSpecification without its own
+// currency inherits the one of the offer. Prices are schema.org numbers,
+// with a dot as the decimal separator even when given as strings.
+func ldPrice(offer map[string]any, currency string) (money.Money, bool) {
+	if c, ok := offer["priceCurrency"].(string); ok {
+		currency = c
+	}
+	code, ok := currencyCode(currency)
+	if !ok {
+		return money.Money{}, false
+	}
+
+	for _, key := range []string{"price", "lowPrice"} {
+		var amount string
+		switch v := offer[key].(type) {
+		case float64:
+			amount = strconv.FormatFloat(v, 'f', -1, 64)
+		case string:
+			amount = v
+		default:
+			continue
+		}
+		if price, err := money.Parse(amount, code); err == nil {
+			return price, true
+		}
+	}
+
+	if spec, ok := offer["priceSpecification"].(map[string]any); ok {
+		return ldPrice(spec, currency)
+	}
+	return money.Money{}, false
+}
+
+func extractOpenGraph(doc *goquery.Document) (*Product, bool) {
+	meta := func(property string) string {
+		content, _ := doc.Find(`meta[property="` + property + `"]`).First().Attr("content")
+		return strings.TrimSpace(content)
+	}
+
+	amount, currency := meta("product:price:amount"), meta("product:price:currency")
+	if amount == "" {
+		amount, currency = meta("og:price:amount"), meta("og:price:currency")
+	}
+
+	price, ok := parsePrice(amount, currency)
+	if !ok {
+		return nil, false
+	}
+
+	availability := meta("product:availability")
+	if availability == "" {
+		availability = meta("og:availability")
+	}
+
+	return &Product{
+		Name:     meta("og:title"),
+		Price:    price,
+		Brand:    meta("product:brand"),
+		ImageURL: meta("og:image"),
+		Stock:    stock(availability),
+		Strategy: StrategyOpenGraph,
+	}, true
+}
+
+func extractMicrodata(doc *goquery.Document) (*Product, bool) {
+	scope := doc.Find(`[itemscope][itemtype*="schema.org/Product"]`).First()
+	if scope.Length() == 0 {
+		return nil, false
+	}
+
+	price, ok := parsePrice(itemprop(scope, "price"), itemprop(scope, "priceCurrency"))
+	if !ok {
+		return nil, false
+	}
+
+	return &Product{
+		Name:     itemprop(scope, "name"),
+		Price:    price,
+		Brand:    itemprop(scope, "brand"),
+		ImageURL: itemprop(scope, "image"),
+		Stock:    stock(itemprop(scope, "availability")),
+		Strategy: StrategyMicrodata,
+	}, true
+}
+
+// itemprop returns the value of a microdata property, preferring the machine
+// readable content/href attributes over the visible text.
+func itemprop(scope *goquery.Selection, name string) string {
+	s := scope.Find(`[itemprop="` + name + `"]`).First()
+	for _, attr := range []string{"content", "href"} {
+		if v, ok := s.Attr(attr); ok {
+			return strings.TrimSpace(v)
+		}
+	}
+	return strings.TrimSpace(s.Text())
+}
+
+// stock interprets schema.org availability values as well as the free text
+// variants shops put into OpenGraph tags.
+func stock(availability string) Stock {
+	a := strings.ToLower(strings.ReplaceAll(availability, " ", ""))
+	if a == "" {
+		return StockUnknown
+	}
+	for _, out := range []string{"outofstock", "oos", "soldout", "discontinued"} {
+		if strings.Contains(a, out) {
+			return StockOutOfStock
+		}
+	}
+	return StockInStock
+}
+
+// parsePrice accepts prices as shops print them: "1 999,50 ₽", "1,999.50", "$19.99".
+// The amount is in currency, DefaultCurrency if it is empty.
+func parsePrice(s, currency string) (money.Money, bool) {
+	var b strings.Builder
+	for _, r := range s {
+		if (r >= '0' && r <= '9') || r == '.' || r == ',' {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+	if digits == "" {
+		return money.Money{}, false
+	}
+
+	lastDot := strings.LastIndex(digits, ".")
+	lastComma := strings.LastIndex(digits, ",")
+	switch {
+	case lastComma < 0 && lastDot >= 0 && (strings.Count(digits, ".") > 1 || len(digits)-lastDot-1 == 3):
+		digits = strings.ReplaceAll(digits, ".", "")
+	case lastComma > lastDot && len(digits)-lastComma-1 <= 2:
+		digits = strings.ReplaceAll(digits[:lastComma], ".", "") + "." + digits[lastComma+1:]
+	default:
+		digits = strings.ReplaceAll(digits, ",", "")
+	}
+
+	code, ok := currencyCode(currency)
+	if !ok {
+		return money.Money{}, false
+	}
+	price, err := money.Parse(digits, code)
+	if err != nil {
+		return money.Money{}, false
+	}
+	return price, true
+}
+
+// currencyCode returns the ISO 4217 code a page states, DefaultCurrency if
+// it states none. Anything else, like a currency sign, is rejected.
+func currencyCode(currency string) (string, bool) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return money.DefaultCurrency, true
+	}
+	return currency, money.ValidCurrency(currency)
+}
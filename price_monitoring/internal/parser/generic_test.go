@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+)
+
+func TestExtractHTML(t *testing.T) {
+	t.Run("json-ld in @graph", func(t *testing.T) {
+		page := `<html><head><script type="application/ld+json">
+		{"@context":"https://schema.org","@graph":[
+			{"@type":"BreadcrumbList"},
+			{"@type":"Product","name":"Чайник","offers":{"@type":"Offer","price":"2490.00","priceCurrency":"RUB","availability":"https://schema.org/InStock"}}
+		]}</script></head></html>`
+
+		product, err := ExtractHTML([]byte(page))
+		assert.NoError(t, err)
+		assert.Equal(t, StrategyJSONLD, product.Strategy)
+		assert.Equal(t, "Чайник", product.Name)
+		assert.Equal(t, money.New(249000, "RUB"), product.Price)
+		assert.Equal(t, StockInStock, product.Stock)
+	})
+
+	t.Run("json-ld aggregate offer out of stock", func(t *testing.T) {
+		page := `<script type="application/ld+json">
+		[{"@type":["Product"],"name":"Lamp","offers":[{"@type":"AggregateOffer","lowPrice":19.5,"availability":"http://schema.org/OutOfStock"}]}]
+		</script>`
+
+		product, err := ExtractHTML([]byte(page))
+		assert.NoError(t, err)
+		assert.Equal(t, money.New(1950, "RUB"), product.Price)
+		assert.Equal(t, StockOutOfStock, product.Stock)
+	})
+
+	t.Run("opengraph when json-ld has no price", func(t *testing.T) {
+		page := `<html><head>
+		<script type="application/ld+json">{"@type":"Product","name":"Lamp"}</script>
+		<meta property="og:title" content="Lamp">
+		<meta property="product:price:amount" content="1 299,90">
+		<meta property="product:availability" content="out of stock">
+		</head></html>`
+
+		product, err := ExtractHTML([]byte(page))
+		assert.NoError(t, err)
+		assert.Equal(t, StrategyOpenGraph, product.Strategy)
+		assert.Equal(t, "Lamp", product.Name)
+		assert.Equal(t, money.New(129990, "RUB"), product.Price)
+		assert.Equal(t, StockOutOfStock, product.Stock)
+	})
+
+	t.Run("microdata", func(t *testing.T) {
+		page := `<div itemscope itemtype="https://schema.org/Product">
+			<h1 itemprop="name">Кружка</h1>
+			<div itemprop="offers" itemscope itemtype="https://schema.org/Offer">
+				<span itemprop="price" content="350">350 ₽</span>
+				<link itemprop="availability" href="https://schema.org/InStock">
+			</div>
+		</div>`
+
+		product, err := ExtractHTML([]byte(page))
+		assert.NoError(t, err)
+		assert.Equal(t, StrategyMicrodata, product.Strategy)
+		assert.Equal(t, "Кружка", product.Name)
+		assert.Equal(t, money.New(35000, "RUB"), product.Price)
+		assert.Equal(t, StockInStock, product.Stock)
+	})
+
+	t.Run("json-ld details", func(t *testing.T) {
+		page := `<script type="application/ld+json">
+		{"@type":"Product","name":"Чайник","image":["https://shop.example/1.jpg","https://shop.example/2.jpg"],
+		"brand":{"@type":"Brand","name":"Bork"},
+		"aggregateRating":{"ratingValue":"4.7","reviewCount":"128"},
+		"offers":{"@type":"Offer","priceCurrency":"USD","seller":{"@type":"Organization","name":"Shop"},
+			"priceSpecification":{"price":"24.90"},"availability":"InStock"}}
+		</script>`
+
+		product, err := ExtractHTML([]byte(page))
+		assert.NoError(t, err)
+		assert.Equal(t, money.New(2490, "USD"), product.Price)
+		assert.Equal(t, "Bork", product.Brand)
+		assert.Equal(t, "Shop", product.Seller)
+		assert.Equal(t, float32(4.7), product.Rating)
+		assert.Equal(t, 128, product.Reviews)
+		assert.Equal(t, "https://shop.example/1.jpg", product.ImageURL)
+	})
+
+	t.Run("json-ld string prices are dot-decimal", func(t *testing.T) {
+		page := `<script type="application/ld+json">
+		{"@type":"Product","name":"Pen","offers":{"@type":"Offer","price":"1.500","priceCurrency":"EUR"}}
+		</script>`
+
+		product, err := ExtractHTML([]byte(page))
+		assert.NoError(t, err)
+		assert.Equal(t, money.New(150, "EUR"), product.Price)
+	})
+
+	t.Run("json-ld with an unknown currency", func(t *testing.T) {
+		page := `<script type="application/ld+json">
+		{"@type":"Product","name":"Pen","offers":{"@type":"Offer","price":"15","priceCurrency":"руб."}}
+		</script>`
+
+		_, err := ExtractHTML([]byte(page))
+		assert.ErrorIs(t, err, ErrProductNotFound)
+	})
+
+	t.Run("no structured data", func(t *testing.T) {
+		_, err := ExtractHTML([]byte(`<html><body><p>hello</p></body></html>`))
+		assert.ErrorIs(t, err, ErrProductNotFound)
+	})
+}
+
+func TestParsePrice(t *testing.T) {
+	for in, want := range map[string]int64{
+		"1 999,50 ₽": 199950,
+		"1,999.50":   199950,
+		"$19.99":     1999,
+		"1.999":      199900,
+		"12 990":     1299000,
+	} {
+		got, ok := parsePrice(in, "")
+		assert.True(t, ok, in)
+		assert.Equal(t, money.New(want, money.DefaultCurrency), got, in)
+	}
+
+	got, ok := parsePrice("19.99", "usd")
+	assert.True(t, ok)
+	assert.Equal(t, money.New(1999, "USD"), got)
+
+	_, ok = parsePrice("по запросу", "")
+	assert.False(t, ok)
+
+	_, ok = parsePrice("19.99", "$")
+	assert.False(t, ok, "currency signs are not codes")
+}
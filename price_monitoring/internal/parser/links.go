@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/scraper"
+)
+
+// Canonical is implemented by marketplaces that know the canonical page of
+// their products and the short links they issue.
+type Canonical interface {
+	// CanonicalLink returns the product page of id.
+	CanonicalLink(id string) string
+	// ShortLink reports whether u redirects to a product page of the
+	// marketplace.
+	ShortLink(u *url.URL) bool
+}
+
+// Shorteners are the link shorteners whose links are resolved before they
+// are canonicalized.
+var Shorteners = []string{"clck.ru", "bit.ly", "goo.su", "vk.cc", "tinyurl.com"}
+
+// trackingParams are stripped from links of shops without a canonical form.
+// Parameters starting with utm_ are stripped as well.
+var trackingParams = []string{
+	"gclid", "gbraid", "wbraid", "yclid", "ysclid", "fbclid", "msclkid",
+	"_openstat", "erid", "mc_cid", "mc_eid",
+}
+
+// Links turns the links users paste into canonical product URLs, so that
+// every spelling of a link is tracked as the same product.
+type Links struct {
+	registry *Registry
+	client   *scraper.Client
+}
+
+func NewLinks(registry *Registry, client *scraper.Client) *Links {
+	return &Links{
+		registry: registry,
+		client:   client,
+	}
+}
+
+// Canonical returns the canonical form of link. Short links are resolved
+// first. Links of marketplaces become their canonical product page, other
+// links lose the tracking parameters and the fragment.
+func (l *Links) Canonical(ctx context.Context, link string) (string, error) {
+	u, err := parseLink(link)
+	if err != nil {
+		return "", err
+	}
+
+	if l.short(u) {
+		resolved, err := l.client.Resolve(ctx, u.String())
+		if err != nil {
+			return "", fetchError(ctx, err)
+		}
+		if u, err = parseLink(resolved); err != nil {
+			return "", err
+		}
+	}
+
+	if m, ok := l.registry.Lookup(u); ok {
+		if c, ok := m.(Canonical); ok {
+			id, err := m.ProductID(u)
+			if err != nil {
+				return "", err
+			}
+			return c.CanonicalLink(id), nil
+		}
+	}
+
+	return canonicalURL(u), nil
+}
+
+func (l *Links) short(u *url.URL) bool {
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if slices.Contains(Shorteners, host) {
+		return true
+	}
+
+	for _, m := range l.registry.All() {
+		if c, ok := m.(Canonical); ok && c.ShortLink(u) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseLink(link string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || u.Host == "" {
+		return nil, ErrInvalidLink
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, ErrInvalidLink
+	}
+	return u, nil
+}
+
+// canonicalURL normalizes a link of a shop without a canonical form: the host
+// is lowercased without the default port, tracking parameters and the
+// fragment are dropped and the remaining parameters are sorted.
+func canonicalURL(u *url.URL) string {
+	c := *u
+	c.Scheme = strings.ToLower(c.Scheme)
+	c.Host = strings.ToLower(c.Host)
+	if port := c.Port(); (c.Scheme == "http" && port == "80") || (c.Scheme == "https" && port == "443") {
+		c.Host = c.Hostname()
+	}
+	if c.Path == "" {
+		c.Path = "/"
+	}
+	c.Fragment, c.RawFragment = "", ""
+	c.User = nil
+
+	query := c.Query()
+	for name := range query {
+		if strings.HasPrefix(strings.ToLower(name), "utm_") || slices.Contains(trackingParams, strings.ToLower(name)) {
+			query.Del(name)
+		}
+	}
+	// Encode sorts by name.
+	c.RawQuery = query.Encode()
+	c.ForceQuery = false
+
+	return c.String()
+}
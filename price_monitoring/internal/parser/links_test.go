@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/scraper"
+)
+
+// shortMarketplace serves product pages under /item/ and short links under
+// /s/ of the test server.
+type shortMarketplace struct {
+	fakeMarketplace
+}
+
+func (m shortMarketplace) Match(u *url.URL) bool {
+	return u.Hostname() == m.host && strings.HasPrefix(u.Path, "/item/")
+}
+
+func (m shortMarketplace) ProductID(u *url.URL) (string, error) {
+	return strings.TrimPrefix(u.Path, "/item/"), nil
+}
+
+func (m shortMarketplace) CanonicalLink(id string) string {
+	return "https://shop.example/item/" + id
+}
+
+func (m shortMarketplace) ShortLink(u *url.URL) bool {
+	return u.Hostname() == m.host && strings.HasPrefix(u.Path, "/s/")
+}
+
+func TestLinksCanonical(t *testing.T) {
+	client := scraper.New(scraper.Config{Timeout: time.Second, AllowPrivate: true})
+	links := NewLinks(NewRegistry(NewWildberries(client)), client)
+
+	for _, tc := range []struct {
+		name string
+		link string
+		want string
+	}{
+		{"wildberries", "https://www.wildberries.ru/catalog/12345/detail.aspx", "https://www.wildberries.ru/catalog/12345/detail.aspx"},
+		{"wildberries mobile", "https://m.wildberries.ru/catalog/12345/detail.aspx?size=1", "https://www.wildberries.ru/catalog/12345/detail.aspx"},
+		{"wildberries tracking", " https://WILDBERRIES.ru/catalog/12345/detail.aspx?utm_source=tg&targetUrl=GP#reviews", "https://www.wildberries.ru/catalog/12345/detail.aspx"},
+		{"tracking params", "https://shop.example/item?utm_source=tg&id=1&gclid=x", "https://shop.example/item?id=1"},
+		{"sorted params", "https://shop.example/item?b=2&a=1", "https://shop.example/item?a=1&b=2"},
+		{"host and port", "HTTPS://Shop.Example:443#top", "https://shop.example/"},
+		{"other port", "http://shop.example:8080/item", "http://shop.example:8080/item"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			link, err := links.Canonical(context.Background(), tc.link)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, link)
+		})
+	}
+
+	for _, link := range []string{"just text", "ftp://shop.example/item", "https://www.wildberries.ru/catalog/"} {
+		_, err := links.Canonical(context.Background(), link)
+		assert.ErrorIs(t, err, ErrInvalidLink, link)
+	}
+}
+
+func TestLinksResolveShortLinks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/s/abc":
+			http.Redirect(w, r, "/item/42?utm_source=share", http.StatusFound)
+		case "/item/42":
+			w.Write([]byte("ok"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	client := scraper.New(scraper.Config{Timeout: time.Second, AllowPrivate: true})
+	links := NewLinks(NewRegistry(shortMarketplace{fakeMarketplace{name: "shop", host: u.Hostname()}}), client)
+
+	link, err := links.Canonical(context.Background(), srv.URL+"/s/abc")
+	require.NoError(t, err)
+	assert.Equal(t, "https://shop.example/item/42", link)
+
+	_, err = links.Canonical(context.Background(), srv.URL+"/s/missing")
+	assert.ErrorIs(t, err, ErrProductNotFound)
+
+	guarded := NewLinks(NewRegistry(shortMarketplace{fakeMarketplace{name: "shop", host: u.Hostname()}}),
+		scraper.New(scraper.Config{Timeout: time.Second}))
+	_, err = guarded.Canonical(context.Background(), srv.URL+"/s/abc")
+	assert.ErrorIs(t, err, ErrInvalidLink, "links into the network of the tracker are not fetched")
+}
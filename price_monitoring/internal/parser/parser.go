@@ -1,37 +1,201 @@
 package parser
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
-	"fmt"
-	"os/exec"
+	"net/url"
+
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+)
+
+// Strategy tells how a product was extracted, from the most reliable
+// dedicated marketplace API down to generic page markup.
+type Strategy string
+
+const (
+	StrategyMarketplace Strategy = "marketplace"
+	StrategyRules       Strategy = "rules"
+	StrategyJSONLD      Strategy = "json-ld"
+	StrategyOpenGraph   Strategy = "opengraph"
+	StrategyMicrodata   Strategy = "microdata"
 )
 
-func Parser(link string) (string, float32, error) {
-	cmd := exec.Command("/usr/bin/python3", "./scraper.py", link)
+type Stock int
+
+const (
+	StockUnknown Stock = iota
+	StockInStock
+	StockOutOfStock
+)
+
+// Product is the marketplace independent result of parsing a link.
+// Descriptive fields the shop does not provide are left zero.
+type Product struct {
+	Marketplace string
+	ID          string
+	Link        string
+	Name        string
+	Price       money.Money
+	// OriginalPrice is the price before the discount.
+	OriginalPrice money.Money
+	Brand         string
+	Seller        string
+	// Rating is the average review score out of 5.
+	Rating  float32
+	Reviews int
+	// Quantity is the number of units in stock.
+	Quantity int
+	ImageURL string
+	Stock    Stock
+	// Variants are the sizes or colors the product comes in, each with its
+	// own price and stock. Price and Stock above describe the product as the
+	// shop shows it, in stock while any variant is.
+	Variants []Variant
+	Strategy Strategy
+	// FromCache is set when the product was served by a cache in front of
+	// the parser instead of being scraped.
+	FromCache bool `json:"-"`
+}
+
+// Variant is one size or color of a product. Its Price is zero when it is
+// out of stock.
+type Variant struct {
+	ID            string
+	Name          string
+	Price         money.Money
+	OriginalPrice money.Money
+	Quantity      int
+	Stock         Stock
+}
+
+// Marketplace is an adapter for a single shop.
+type Marketplace interface {
+	// Name is a short stable identifier, e.g. "wildberries".
+	Name() string
+	// Match reports whether the link belongs to this marketplace.
+	Match(u *url.URL) bool
+	// ProductID extracts the marketplace product identifier from the link.
+	ProductID(u *url.URL) (string, error)
+	// Fetch downloads the raw product data for id.
+	Fetch(ctx context.Context, id string) ([]byte, error)
+	// Normalize converts the raw data returned by Fetch into a Product.
+	Normalize(id string, raw []byte) (*Product, error)
+}
+
+// Extractor handles links that no registered Marketplace matches.
+type Extractor interface {
+	Extract(ctx context.Context, link string) (*Product, error)
+}
+
+type Parser struct {
+	registry  *Registry
+	fallbacks []Extractor
+}
+
+// New creates a parser. Links that no marketplace matches are given to the
+// fallbacks in order; without fallbacks they are rejected with ErrInvalidLink.
+func New(registry *Registry, fallbacks ...Extractor) *Parser {
+	return &Parser{
+		registry:  registry,
+		fallbacks: fallbacks,
+	}
+}
+
+// Parse extracts the product behind link. A product that is out of stock is
+// returned together with ErrOutOfStock, its Price is zero then.
+func (p *Parser) Parse(ctx context.Context, link string) (*Product, error) {
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" {
+		return nil, ErrInvalidLink
+	}
+
+	m, ok := p.registry.Lookup(u)
+	if !ok {
+		product, err := p.extract(ctx, link)
+		if err != nil {
+			return nil, err
+		}
+		return product, product.Err()
+	}
 
-	output, err := cmd.Output()
+	id, err := m.ProductID(u)
 	if err != nil {
-		return "", 0, err
+		return nil, err
 	}
 
-	var result struct {
-		Name       string  `json:"name"`
-		Sale_price float32 `json:"sale_price"`
-		Error      string  `json:"error"`
+	raw, err := m.Fetch(ctx, id)
+	if err != nil {
+		return nil, fetchError(ctx, err)
 	}
 
-	err = json.Unmarshal(output, &result)
+	product, err := m.Normalize(id, raw)
 	if err != nil {
-		return "", 0, fmt.Errorf("invalid json from parser: %w", err)
+		return nil, err
+	}
+	product.Marketplace = m.Name()
+	product.ID = id
+	product.Link = link
+	product.Strategy = StrategyMarketplace
+
+	return product, product.Err()
+}
+
+// Key identifies the product behind link, so that links that differ only in
+// tracking parameters or host spelling share it. Marketplace links are keyed
+// by the product id, other links by their canonical URL.
+func (p *Parser) Key(link string) (string, error) {
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" {
+		return "", ErrInvalidLink
+	}
+
+	if m, ok := p.registry.Lookup(u); ok {
+		id, err := m.ProductID(u)
+		if err != nil {
+			return "", err
+		}
+		return m.Name() + ":" + id, nil
+	}
+
+	return canonicalURL(u), nil
+}
+
+// DiscountPercent returns by how many percent Price is below OriginalPrice.
+func (p *Product) DiscountPercent() int {
+	return money.DiscountPercent(p.OriginalPrice, p.Price)
+}
+
+// Variant returns the variant of p with id. ErrVariantNotFound means the
+// shop does not list it.
+func (p *Product) Variant(id string) (Variant, error) {
+	for _, variant := range p.Variants {
+		if variant.ID == id {
+			return variant, nil
+		}
 	}
+	return Variant{}, ErrVariantNotFound
+}
 
-	if result.Error != "" {
-		if result.Error == "Товара нет в наличии" {
-			return result.Name, 0, errors.New(result.Error)
+// Err returns the error Parse reports together with the product.
+func (p *Product) Err() error {
+	if p.Stock == StockOutOfStock {
+		return ErrOutOfStock
+	}
+	return nil
+}
+
+func (p *Parser) extract(ctx context.Context, link string) (*Product, error) {
+	for _, fallback := range p.fallbacks {
+		product, err := fallback.Extract(ctx, link)
+		if errors.Is(err, ErrNotApplicable) {
+			continue
+		}
+		if err != nil {
+			return nil, err
 		}
-		return "", 0, fmt.Errorf("parser error: %s", result.Error)
+		product.Link = link
+		return product, nil
 	}
 
-	return result.Name, result.Sale_price, nil
+	return nil, ErrInvalidLink
 }
@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+)
+
+type fakeMarketplace struct {
+	name string
+	host string
+}
+
+func (f fakeMarketplace) Name() string { return f.name }
+
+func (f fakeMarketplace) Match(u *url.URL) bool { return u.Hostname() == f.host }
+
+func (f fakeMarketplace) ProductID(u *url.URL) (string, error) { return u.Path[1:], nil }
+
+func (f fakeMarketplace) Fetch(ctx context.Context, id string) ([]byte, error) {
+	return []byte(f.name + ":" + id), nil
+}
+
+func (f fakeMarketplace) Normalize(id string, raw []byte) (*Product, error) {
+	return &Product{Name: string(raw), Price: money.New(1000, "RUB"), Stock: StockInStock}, nil
+}
+
+func TestParser(t *testing.T) {
+	registry := NewRegistry(fakeMarketplace{name: "first", host: "first.example"})
+	registry.Register(fakeMarketplace{name: "second", host: "second.example"})
+
+	p := New(registry)
+
+	t.Run("dispatches by host", func(t *testing.T) {
+		product, err := p.Parse(context.Background(), "https://second.example/42")
+		assert.NoError(t, err)
+		assert.Equal(t, "second", product.Marketplace)
+		assert.Equal(t, "42", product.ID)
+		assert.Equal(t, "second:42", product.Name)
+		assert.Equal(t, "https://second.example/42", product.Link)
+	})
+
+	t.Run("unknown marketplace", func(t *testing.T) {
+		_, err := p.Parse(context.Background(), "https://unknown.example/42")
+		assert.ErrorIs(t, err, ErrInvalidLink)
+	})
+
+	t.Run("not a link", func(t *testing.T) {
+		_, err := p.Parse(context.Background(), "just text")
+		assert.ErrorIs(t, err, ErrInvalidLink)
+	})
+
+	assert.Equal(t, []string{"first", "second"}, registry.Names())
+}
+
+func TestKey(t *testing.T) {
+	p := New(NewRegistry(fakeMarketplace{name: "first", host: "first.example"}))
+
+	key, err := p.Key("https://first.example/42?utm_source=tg")
+	assert.NoError(t, err)
+	assert.Equal(t, "first:42", key)
+
+	key, err = p.Key("HTTPS://Shop.Example/item?id=1#reviews")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://shop.example/item?id=1", key)
+
+	_, err = p.Key("just text")
+	assert.ErrorIs(t, err, ErrInvalidLink)
+}
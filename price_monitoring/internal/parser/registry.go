@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"net/url"
+	"slices"
+	"sync"
+)
+
+// Registry holds the marketplace adapters known to the parser.
+// Adapters are matched in registration order.
+type Registry struct {
+	mu           sync.RWMutex
+	marketplaces []Marketplace
+}
+
+func NewRegistry(marketplaces ...Marketplace) *Registry {
+	r := &Registry{}
+	for _, m := range marketplaces {
+		r.Register(m)
+	}
+	return r
+}
+
+func (r *Registry) Register(m Marketplace) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.marketplaces = append(r.marketplaces, m)
+}
+
+func (r *Registry) Lookup(u *url.URL) (Marketplace, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, m := range r.marketplaces {
+		if m.Match(u) {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.marketplaces))
+	for _, m := range r.marketplaces {
+		names = append(names, m.Name())
+	}
+	return names
+}
+
+// All returns the registered marketplaces in registration order.
+func (r *Registry) All() []Marketplace {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.marketplaces)
+}
@@ -0,0 +1,251 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"github.com/antchfx/htmlquery"
+	"github.com/antchfx/xpath"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/scraper"
+	"golang.org/x/net/html"
+	"gopkg.in/yaml.v3"
+)
+
+// Selector points at a single value on the page. Exactly one of CSS and
+// XPath must be set. Attr reads an attribute instead of the element text.
+type Selector struct {
+	CSS   string `yaml:"css"`
+	XPath string `yaml:"xpath"`
+	Attr  string `yaml:"attr"`
+}
+
+// Rule describes how to read a product from the pages of a simple shop.
+type Rule struct {
+	Site         string   `yaml:"site"`
+	Host         string   `yaml:"host"`
+	Name         Selector `yaml:"name"`
+	Price        Selector `yaml:"price"`
+	Availability Selector `yaml:"availability"`
+	// PriceCleanup extracts the number from the price text. The first
+	// capture group is used if there is one, the whole match otherwise.
+	PriceCleanup string `yaml:"price_cleanup"`
+	// InStockPattern decides availability from the Availability value.
+	// Without it the value is interpreted like schema.org availability.
+	InStockPattern string `yaml:"in_stock_pattern"`
+	// Currency of the prices, money.DefaultCurrency if not set.
+	Currency string `yaml:"currency"`
+
+	priceCleanup   *regexp.Regexp
+	inStockPattern *regexp.Regexp
+}
+
+// Rules is an Extractor driven by the rules file. Links of hosts without
+// a rule are passed on with ErrNotApplicable.
+type Rules struct {
+	client *scraper.Client
+	rules  []*Rule
+}
+
+func LoadRules(client *scraper.Client, file string) (*Rules, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read rules file: %w", err)
+	}
+
+	return ParseRules(client, data)
+}
+
+func ParseRules(client *scraper.Client, data []byte) (*Rules, error) {
+	var doc struct {
+		Rules []*Rule `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid rules file: %w", err)
+	}
+
+	for i, rule := range doc.Rules {
+		if err := rule.compile(); err != nil {
+			return nil, fmt.Errorf("rule %d (%s): %w", i, rule.Site, err)
+		}
+	}
+
+	return &Rules{
+		client: client,
+		rules:  doc.Rules,
+	}, nil
+}
+
+// Lookup returns the first rule whose host pattern matches host. A pattern
+// like "*.shop.ru" matches the bare "shop.ru" too.
+func (r *Rules) Lookup(host string) (*Rule, bool) {
+	host = strings.ToLower(host)
+	for _, rule := range r.rules {
+		if ok, _ := path.Match(rule.Host, host); ok {
+			return rule, true
+		}
+		if domain, ok := strings.CutPrefix(rule.Host, "*."); ok && host == domain {
+			return rule, true
+		}
+	}
+	return nil, false
+}
+
+// Site returns the rule with the given site name.
+func (r *Rules) Site(site string) (*Rule, bool) {
+	for _, rule := range r.rules {
+		if rule.Site == site {
+			return rule, true
+		}
+	}
+	return nil, false
+}
+
+func (r *Rules) Extract(ctx context.Context, link string) (*Product, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, ErrInvalidLink
+	}
+
+	rule, ok := r.Lookup(u.Hostname())
+	if !ok {
+		return nil, ErrNotApplicable
+	}
+
+	body, err := r.client.Get(ctx, link)
+	if err != nil {
+		return nil, fetchError(ctx, err)
+	}
+
+	product, err := rule.Apply(body)
+	if err != nil {
+		return nil, err
+	}
+	product.Marketplace = rule.Site
+	return product, nil
+}
+
+// Apply runs the rule against a fetched page.
+func (rule *Rule) Apply(body []byte) (*Product, error) {
+	root, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	priceText := rule.Price.value(root)
+	if rule.priceCleanup != nil {
+		match := rule.priceCleanup.FindStringSubmatch(priceText)
+		switch {
+		case match == nil:
+			priceText = ""
+		case len(match) > 1:
+			priceText = match[1]
+		default:
+			priceText = match[0]
+		}
+	}
+
+	price, ok := parsePrice(priceText, rule.Currency)
+	if !ok {
+		return nil, ErrProductNotFound
+	}
+
+	availability := rule.Availability.value(root)
+	productStock := stock(availability)
+	if rule.inStockPattern != nil {
+		productStock = StockOutOfStock
+		if rule.inStockPattern.MatchString(availability) {
+			productStock = StockInStock
+		}
+	}
+
+	return &Product{
+		Name:     rule.Name.value(root),
+		Price:    price,
+		Stock:    productStock,
+		Strategy: StrategyRules,
+	}, nil
+}
+
+func (rule *Rule) compile() error {
+	if rule.Host == "" {
+		return errors.New("host is required")
+	}
+	if _, err := path.Match(rule.Host, ""); err != nil {
+		return fmt.Errorf("host: %w", err)
+	}
+	rule.Host = strings.ToLower(rule.Host)
+
+	if rule.Price.empty() {
+		return errors.New("price selector is required")
+	}
+
+	for field, s := range map[string]Selector{"name": rule.Name, "price": rule.Price, "availability": rule.Availability} {
+		if err := s.validate(); err != nil {
+			return fmt.Errorf("%s: %w", field, err)
+		}
+	}
+
+	var err error
+	if rule.PriceCleanup != "" {
+		if rule.priceCleanup, err = regexp.Compile(rule.PriceCleanup); err != nil {
+			return fmt.Errorf("price_cleanup: %w", err)
+		}
+	}
+	if rule.InStockPattern != "" {
+		if rule.inStockPattern, err = regexp.Compile(rule.InStockPattern); err != nil {
+			return fmt.Errorf("in_stock_pattern: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s Selector) empty() bool {
+	return s.CSS == "" && s.XPath == ""
+}
+
+func (s Selector) validate() error {
+	if s.CSS != "" && s.XPath != "" {
+		return errors.New("css and xpath are mutually exclusive")
+	}
+	if s.CSS != "" {
+		if _, err := cascadia.Compile(s.CSS); err != nil {
+			return fmt.Errorf("css: %w", err)
+		}
+	}
+	if s.XPath != "" {
+		if _, err := xpath.Compile(s.XPath); err != nil {
+			return fmt.Errorf("xpath: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s Selector) value(root *html.Node) string {
+	var node *html.Node
+	switch {
+	case s.CSS != "":
+		if sel := goquery.NewDocumentFromNode(root).Find(s.CSS); sel.Length() > 0 {
+			node = sel.Get(0)
+		}
+	case s.XPath != "":
+		node = htmlquery.FindOne(root, s.XPath)
+	}
+	if node == nil {
+		return ""
+	}
+
+	if s.Attr != "" {
+		return strings.TrimSpace(htmlquery.SelectAttr(node, s.Attr))
+	}
+	return strings.Join(strings.Fields(htmlquery.InnerText(node)), " ")
+}
@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+)
+
+const testRules = `
+rules:
+  - site: shop
+    host: "*.shop.ru"
+    name:
+      css: h1.product-title
+    price:
+      xpath: //div[@class="price"]/span[1]
+    price_cleanup: '([\d\s]+(?:[.,]\d{1,2})?)\s*руб'
+    availability:
+      css: button.add-to-cart
+    in_stock_pattern: (?i)в корзину
+`
+
+func TestRules(t *testing.T) {
+	rules, err := ParseRules(nil, []byte(testRules))
+	assert.NoError(t, err)
+
+	page, err := os.ReadFile("testdata/shop.html")
+	assert.NoError(t, err)
+
+	rule, ok := rules.Lookup("www.SHOP.ru")
+	assert.True(t, ok)
+
+	product, err := rule.Apply(page)
+	assert.NoError(t, err)
+	assert.Equal(t, StrategyRules, product.Strategy)
+	assert.Equal(t, "Электрочайник Bork K515", product.Name)
+	assert.Equal(t, money.New(1299050, "RUB"), product.Price)
+	assert.Equal(t, StockInStock, product.Stock)
+
+	_, ok = rules.Lookup("shop.ru")
+	assert.True(t, ok, "a wildcard matches the bare domain")
+
+	_, ok = rules.Lookup("myshop.ru")
+	assert.False(t, ok)
+
+	_, ok = rules.Lookup("shop.com")
+	assert.False(t, ok)
+
+	_, ok = rules.Site("shop")
+	assert.True(t, ok)
+}
+
+func TestParseRulesValidation(t *testing.T) {
+	for name, rules := range map[string]string{
+		"missing host":       "rules:\n  - site: a\n    price: {css: .p}\n",
+		"missing price":      "rules:\n  - site: a\n    host: a.ru\n",
+		"bad css":            "rules:\n  - site: a\n    host: a.ru\n    price: {css: '[['}\n",
+		"bad xpath":          "rules:\n  - site: a\n    host: a.ru\n    price: {xpath: '//div['}\n",
+		"both css and xpath": "rules:\n  - site: a\n    host: a.ru\n    price: {css: .p, xpath: //p}\n",
+		"bad regex":          "rules:\n  - site: a\n    host: a.ru\n    price: {css: .p}\n    price_cleanup: '('\n",
+	} {
+		_, err := ParseRules(nil, []byte(rules))
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,196 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/scraper"
+)
+
+const wbCardURL = "https://card.wb.ru/cards/v1/detail?appType=1&curr=rub&dest=-1257786&nm="
+
+var wbDetailRe = regexp.MustCompile(`/catalog/(\d+)/detail`)
+
+type wbCardResponse struct {
+	Data struct {
+		Products []struct {
+			Name          string   `json:"name"`
+			Brand         string   `json:"brand"`
+			Supplier      string   `json:"supplier"`
+			PriceU        int64    `json:"priceU"`
+			SalePriceU    int64    `json:"salePriceU"`
+			ReviewRating  float32  `json:"reviewRating"`
+			Feedbacks     int      `json:"feedbacks"`
+			TotalQuantity int      `json:"totalQuantity"`
+			Sizes         []wbSize `json:"sizes"`
+		} `json:"products"`
+	} `json:"data"`
+}
+
+// wbSize is a size of a product card. Cards of products that come in one
+// size have a single size without a name, origName is "0" then. Older cards
+// carry no price per size, the sizes cost the price of the product.
+type wbSize struct {
+	OptionID int64  `json:"optionId"`
+	Name     string `json:"name"`
+	OrigName string `json:"origName"`
+	Stocks   []struct {
+		Qty int `json:"qty"`
+	} `json:"stocks"`
+	Price *struct {
+		Basic   int64 `json:"basic"`
+		Product int64 `json:"product"`
+	} `json:"price"`
+}
+
+func (s wbSize) name() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	if s.OrigName != "0" {
+		return s.OrigName
+	}
+	return ""
+}
+
+// wbVariants converts the named sizes of a card. Prices are in kopecks,
+// salePriceU and priceU are those of the product.
+func wbVariants(sizes []wbSize, salePriceU, priceU int64) []Variant {
+	var variants []Variant
+	for _, size := range sizes {
+		name := size.name()
+		if name == "" {
+			continue
+		}
+
+		variant := Variant{
+			ID:    strconv.FormatInt(size.OptionID, 10),
+			Name:  name,
+			Stock: StockOutOfStock,
+		}
+		for _, stock := range size.Stocks {
+			variant.Quantity += stock.Qty
+		}
+		if variant.Quantity > 0 {
+			sale, original := salePriceU, priceU
+			if size.Price != nil && size.Price.Product > 0 {
+				sale, original = size.Price.Product, size.Price.Basic
+			}
+			variant.Stock = StockInStock
+			variant.Price = money.New(sale, "RUB")
+			if original > sale {
+				variant.OriginalPrice = money.New(original, "RUB")
+			}
+		}
+		variants = append(variants, variant)
+	}
+	return variants
+}
+
+// wbBaskets are the upper bounds of the vol ranges served by each image
+// host basket-01 to basket-17, newer products are on basket-18.
+var wbBaskets = []int{143, 287, 431, 719, 1007, 1061, 1115, 1169, 1313, 1601, 1655, 1919, 2045, 2189, 2405, 2621, 2837}
+
+// wbImageURL returns the main photo of product id. Wildberries shards the
+// photos over hosts by the product id, the card does not carry the URL.
+func wbImageURL(id string) string {
+	nm, err := strconv.Atoi(id)
+	if err != nil {
+		return ""
+	}
+
+	vol, part := nm/100000, nm/1000
+	basket := len(wbBaskets) + 1
+	for i, upper := range wbBaskets {
+		if vol <= upper {
+			basket = i + 1
+			break
+		}
+	}
+
+	return fmt.Sprintf("https://basket-%02d.wbbasket.ru/vol%d/part%d/%d/images/big/1.webp", basket, vol, part, nm)
+}
+
+type Wildberries struct {
+	client  *scraper.Client
+	cardURL string
+}
+
+func NewWildberries(client *scraper.Client) *Wildberries {
+	return &Wildberries{
+		client:  client,
+		cardURL: wbCardURL,
+	}
+}
+
+func (w *Wildberries) Name() string {
+	return "wildberries"
+}
+
+func (w *Wildberries) Match(u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	return host == "wildberries.ru" || strings.HasSuffix(host, ".wildberries.ru")
+}
+
+func (w *Wildberries) ProductID(u *url.URL) (string, error) {
+	match := wbDetailRe.FindStringSubmatch(u.Path)
+	if match == nil {
+		return "", ErrInvalidLink
+	}
+	return match[1], nil
+}
+
+func (w *Wildberries) CanonicalLink(id string) string {
+	return "https://www.wildberries.ru/catalog/" + id + "/detail.aspx"
+}
+
+// ShortLink matches the wb.ru links the mobile apps share.
+func (w *Wildberries) ShortLink(u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	return host == "wb.ru" || host == "www.wb.ru"
+}
+
+func (w *Wildberries) Fetch(ctx context.Context, id string) ([]byte, error) {
+	return w.client.Get(ctx, w.cardURL+id)
+}
+
+func (w *Wildberries) Normalize(id string, raw []byte) (*Product, error) {
+	var card wbCardResponse
+	if err := json.Unmarshal(raw, &card); err != nil {
+		return nil, fmt.Errorf("invalid json from card.wb.ru: %w", err)
+	}
+
+	if len(card.Data.Products) == 0 {
+		return nil, ErrProductNotFound
+	}
+
+	item := card.Data.Products[0]
+	product := &Product{
+		Name:     item.Name,
+		Brand:    item.Brand,
+		Seller:   item.Supplier,
+		Rating:   item.ReviewRating,
+		Reviews:  item.Feedbacks,
+		Quantity: item.TotalQuantity,
+		ImageURL: wbImageURL(id),
+		Stock:    StockInStock,
+		Variants: wbVariants(item.Sizes, item.SalePriceU, item.PriceU),
+	}
+	if item.TotalQuantity == 0 {
+		product.Stock = StockOutOfStock
+		return product, nil
+	}
+
+	// The card is requested with curr=rub, prices are in kopecks.
+	product.Price = money.New(item.SalePriceU, "RUB")
+	if item.PriceU > item.SalePriceU {
+		product.OriginalPrice = money.New(item.PriceU, "RUB")
+	}
+	return product, nil
+}
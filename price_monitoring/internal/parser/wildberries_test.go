@@ -0,0 +1,176 @@
+package parser
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/scraper"
+)
+
+const wbLink = "https://www.wildberries.ru/catalog/12345/detail.aspx"
+
+func newTestWildberries(t *testing.T, handler http.HandlerFunc) *Parser {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	wb := NewWildberries(scraper.New(scraper.Config{Timeout: time.Second, AllowPrivate: true}))
+	wb.cardURL = srv.URL + "/?nm="
+	return New(NewRegistry(wb))
+}
+
+func TestWBImageURL(t *testing.T) {
+	assert.Equal(t, "https://basket-10.wbbasket.ru/vol1500/part150012/150012345/images/big/1.webp", wbImageURL("150012345"))
+	assert.Equal(t, "https://basket-18.wbbasket.ru/vol3000/part300000/300000000/images/big/1.webp", wbImageURL("300000000"))
+}
+
+func TestWildberries(t *testing.T) {
+	t.Run("product in stock", func(t *testing.T) {
+		p := newTestWildberries(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "12345", r.URL.Query().Get("nm"))
+			assert.Equal(t, "Mozilla/5.0", r.Header.Get("User-Agent"))
+			w.Write([]byte(`{"data":{"products":[{"name":"Кроссовки","brand":"Nike","supplier":"ООО Спорт",
+				"priceU":399900,"salePriceU":199950,"reviewRating":4.8,"feedbacks":1532,"totalQuantity":3}]}}`))
+		})
+
+		product, err := p.Parse(context.Background(), wbLink)
+		assert.NoError(t, err)
+		assert.Equal(t, "wildberries", product.Marketplace)
+		assert.Equal(t, "12345", product.ID)
+		assert.Equal(t, "Кроссовки", product.Name)
+		assert.Equal(t, money.New(199950, "RUB"), product.Price)
+		assert.Equal(t, money.New(399900, "RUB"), product.OriginalPrice)
+		assert.Equal(t, 50, product.DiscountPercent())
+		assert.Equal(t, "Nike", product.Brand)
+		assert.Equal(t, "ООО Спорт", product.Seller)
+		assert.Equal(t, float32(4.8), product.Rating)
+		assert.Equal(t, 1532, product.Reviews)
+		assert.Equal(t, 3, product.Quantity)
+		assert.Equal(t, "https://basket-01.wbbasket.ru/vol0/part12/12345/images/big/1.webp", product.ImageURL)
+		assert.Equal(t, StockInStock, product.Stock)
+	})
+
+	t.Run("product out of stock", func(t *testing.T) {
+		p := newTestWildberries(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"data":{"products":[{"name":"Кроссовки","salePriceU":199950,"totalQuantity":0}]}}`))
+		})
+
+		product, err := p.Parse(context.Background(), wbLink)
+		assert.ErrorIs(t, err, ErrOutOfStock)
+		assert.Equal(t, "Кроссовки", product.Name)
+		assert.True(t, product.Price.IsZero())
+		assert.Equal(t, StockOutOfStock, product.Stock)
+	})
+
+	t.Run("sizes become variants", func(t *testing.T) {
+		p := newTestWildberries(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"data":{"products":[{"name":"Футболка","priceU":199900,"salePriceU":149900,"totalQuantity":2,
+				"sizes":[
+					{"optionId":101,"name":"M","origName":"48","stocks":[]},
+					{"optionId":102,"name":"XXL","origName":"56","stocks":[{"qty":1},{"qty":1}],
+						"price":{"basic":209900,"product":159900}},
+					{"optionId":103,"name":"","origName":"50","stocks":[{"qty":4}]}
+				]}]}}`))
+		})
+
+		product, err := p.Parse(context.Background(), wbLink)
+		assert.NoError(t, err)
+		assert.Equal(t, StockInStock, product.Stock)
+		assert.Equal(t, []Variant{
+			{ID: "101", Name: "M", Stock: StockOutOfStock},
+			{ID: "102", Name: "XXL", Price: money.New(159900, "RUB"), OriginalPrice: money.New(209900, "RUB"), Quantity: 2, Stock: StockInStock},
+			{ID: "103", Name: "50", Price: money.New(149900, "RUB"), OriginalPrice: money.New(199900, "RUB"), Quantity: 4, Stock: StockInStock},
+		}, product.Variants)
+
+		variant, err := product.Variant("102")
+		assert.NoError(t, err)
+		assert.Equal(t, "XXL", variant.Name)
+		_, err = product.Variant("999")
+		assert.ErrorIs(t, err, ErrVariantNotFound)
+	})
+
+	t.Run("one size products have no variants", func(t *testing.T) {
+		p := newTestWildberries(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"data":{"products":[{"name":"Кружка","salePriceU":49900,"totalQuantity":5,
+				"sizes":[{"optionId":201,"name":"","origName":"0","stocks":[{"qty":5}]}]}]}}`))
+		})
+
+		product, err := p.Parse(context.Background(), wbLink)
+		assert.NoError(t, err)
+		assert.Empty(t, product.Variants)
+	})
+
+	t.Run("link without product id", func(t *testing.T) {
+		p := newTestWildberries(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("upstream should not be called")
+		})
+
+		_, err := p.Parse(context.Background(), "https://www.wildberries.ru/brands/nike")
+		assert.ErrorIs(t, err, ErrInvalidLink)
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		p := newTestWildberries(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"data":{"products":[]}}`))
+		})
+
+		_, err := p.Parse(context.Background(), wbLink)
+		assert.ErrorIs(t, err, ErrProductNotFound)
+	})
+
+	t.Run("upstream error", func(t *testing.T) {
+		p := newTestWildberries(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		_, err := p.Parse(context.Background(), wbLink)
+		assert.ErrorIs(t, err, ErrUpstreamUnavailable)
+	})
+
+	t.Run("blocked by upstream", func(t *testing.T) {
+		p := newTestWildberries(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+
+		_, err := p.Parse(context.Background(), wbLink)
+		assert.ErrorIs(t, err, ErrBlocked)
+	})
+
+	t.Run("captcha page", func(t *testing.T) {
+		p := newTestWildberries(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html><head><title>Подозрительная активность</title></head></html>`))
+		})
+
+		_, err := p.Parse(context.Background(), wbLink)
+		assert.ErrorIs(t, err, ErrBlocked)
+	})
+
+	t.Run("open circuit breaker", func(t *testing.T) {
+		calls := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(srv.Close)
+
+		wb := NewWildberries(scraper.New(scraper.Config{
+			Timeout:      time.Second,
+			AllowPrivate: true,
+			Breaker:      scraper.BreakerConfig{Threshold: 1, Cooldown: time.Minute},
+		}))
+		wb.cardURL = srv.URL + "/?nm="
+		p := New(NewRegistry(wb))
+
+		_, err := p.Parse(context.Background(), wbLink)
+		assert.ErrorIs(t, err, ErrUpstreamUnavailable)
+
+		_, err = p.Parse(context.Background(), wbLink)
+		assert.ErrorIs(t, err, ErrUpstreamUnavailable)
+		assert.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,102 @@
+package rates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HTTP fetches the rate table from a JSON API and keeps it for TTL. The
+// response looks like {"base": "USD", "rates": {"RUB": 90.125}}, the base
+// may also be named "base_code". When a refresh fails the last table is
+// served until a refresh succeeds.
+type HTTP struct {
+	client *http.Client
+	url    string
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	table     *Table
+	fetchedAt time.Time
+}
+
+func NewHTTP(client *http.Client, url string, ttl time.Duration) *HTTP {
+	return &HTTP{client: client, url: url, ttl: ttl}
+}
+
+func (h *HTTP) Rate(ctx context.Context, from, to string) (*big.Rat, error) {
+	table, err := h.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return table.Rate(from, to)
+}
+
+func (h *HTTP) current(ctx context.Context) (*Table, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.table != nil && time.Since(h.fetchedAt) < h.ttl {
+		return h.table, nil
+	}
+
+	table, err := h.fetch(ctx)
+	if err != nil {
+		if h.table != nil {
+			log.Printf("rates: cannot refresh, serving rates from %s err: %v", h.fetchedAt.Format(time.RFC3339), err)
+			return h.table, nil
+		}
+		return nil, err
+	}
+
+	h.table, h.fetchedAt = &table, time.Now()
+	return h.table, nil
+}
+
+func (h *HTTP) fetch(ctx context.Context) (Table, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url, nil)
+	if err != nil {
+		return Table{}, err
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return Table{}, fmt.Errorf("cannot fetch rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Table{}, fmt.Errorf("cannot fetch rates: unexpected status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Base     string                 `json:"base"`
+		BaseCode string                 `json:"base_code"`
+		Rates    map[string]json.Number `json:"rates"`
+	}
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		return Table{}, fmt.Errorf("invalid rates response: %w", err)
+	}
+
+	base := body.Base
+	if base == "" {
+		base = body.BaseCode
+	}
+	rates := make(map[string]string, len(body.Rates))
+	for currency, rate := range body.Rates {
+		rates[currency] = rate.String()
+	}
+
+	table, err := NewTable(base, rates)
+	if err != nil {
+		return Table{}, fmt.Errorf("invalid rates response: %w", err)
+	}
+	return table, nil
+}
@@ -0,0 +1,96 @@
+// Package rates provides the exchange rates prices are converted with.
+package rates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+)
+
+var ErrNoRate = errors.New("no exchange rate")
+
+// Provider returns exchange rates.
+type Provider interface {
+	// Rate returns the number of units of to that one unit of from is worth.
+	Rate(ctx context.Context, from, to string) (*big.Rat, error)
+}
+
+// Table holds rates against a base currency: one unit of Base is worth
+// Rates[c] units of c.
+type Table struct {
+	Base  string
+	Rates map[string]*big.Rat
+}
+
+// NewTable builds a table from decimal rates, "90.125", as rate files and
+// APIs publish them.
+func NewTable(base string, rates map[string]string) (Table, error) {
+	table := Table{Base: strings.ToUpper(base), Rates: make(map[string]*big.Rat, len(rates))}
+	if !money.ValidCurrency(table.Base) {
+		return Table{}, fmt.Errorf("%w: %q", money.ErrInvalidCurrency, base)
+	}
+
+	for currency, s := range rates {
+		currency = strings.ToUpper(currency)
+		if !money.ValidCurrency(currency) {
+			return Table{}, fmt.Errorf("%w: %q", money.ErrInvalidCurrency, currency)
+		}
+		rate, ok := new(big.Rat).SetString(s)
+		if !ok || rate.Sign() <= 0 {
+			return Table{}, fmt.Errorf("invalid rate of %s: %q", currency, s)
+		}
+		table.Rates[currency] = rate
+	}
+
+	return table, nil
+}
+
+// Rate returns the rate from from to to, crossed through the base currency.
+func (t Table) Rate(from, to string) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+
+	fromRate, ok := t.rate(from)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoRate, from)
+	}
+	toRate, ok := t.rate(to)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoRate, to)
+	}
+
+	return new(big.Rat).Quo(toRate, fromRate), nil
+}
+
+func (t Table) rate(currency string) (*big.Rat, bool) {
+	if currency == t.Base {
+		return big.NewRat(1, 1), true
+	}
+	rate, ok := t.Rates[currency]
+	return rate, ok
+}
+
+// Convert returns m in currency. Amounts already in currency and zero
+// amounts are returned without asking p, which may be nil then.
+func Convert(ctx context.Context, p Provider, m money.Money, currency string) (money.Money, error) {
+	if m.Currency == currency {
+		return m, nil
+	}
+	if m.Amount == 0 {
+		return money.New(0, currency), nil
+	}
+	if p == nil {
+		return money.Money{}, fmt.Errorf("%w: %s to %s", ErrNoRate, m.Currency, currency)
+	}
+
+	rate, err := p.Rate(ctx, m.Currency, currency)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return m.Convert(rate, currency)
+}
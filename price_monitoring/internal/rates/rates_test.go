@@ -0,0 +1,124 @@
+package rates
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+)
+
+func TestTable(t *testing.T) {
+	table, err := NewTable("usd", map[string]string{"RUB": "90", "eur": "0.9"})
+	require.NoError(t, err)
+
+	rate, err := table.Rate("USD", "RUB")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewRat(90, 1), rate)
+
+	rate, err = table.Rate("EUR", "RUB")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewRat(100, 1), rate, "crossed through the base")
+
+	rate, err = table.Rate("JPY", "JPY")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewRat(1, 1), rate)
+
+	_, err = table.Rate("CNY", "RUB")
+	assert.ErrorIs(t, err, ErrNoRate)
+
+	_, err = NewTable("RUB", map[string]string{"USD": "-1"})
+	assert.Error(t, err)
+	_, err = NewTable("RUB", map[string]string{"dollar": "1"})
+	assert.ErrorIs(t, err, money.ErrInvalidCurrency)
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("base: RUB\nrates:\n  USD: \"0.0125\"\n  EUR: 0.01\n"), 0o600))
+
+	static, err := LoadFile(path)
+	require.NoError(t, err)
+
+	rate, err := static.Rate(context.Background(), "USD", "RUB")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewRat(80, 1), rate)
+
+	_, err = LoadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestHTTP(t *testing.T) {
+	t.Run("caches the table for the ttl", func(t *testing.T) {
+		calls := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Write([]byte(`{"base_code":"USD","rates":{"RUB":90.125,"USD":1}}`))
+		}))
+		t.Cleanup(srv.Close)
+
+		provider := NewHTTP(srv.Client(), srv.URL, time.Hour)
+		for range 3 {
+			rate, err := provider.Rate(context.Background(), "USD", "RUB")
+			require.NoError(t, err)
+			assert.Equal(t, big.NewRat(721, 8), rate)
+		}
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("serves the last table when a refresh fails", func(t *testing.T) {
+		calls := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls > 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Write([]byte(`{"base":"RUB","rates":{"USD":0.0125}}`))
+		}))
+		t.Cleanup(srv.Close)
+
+		provider := NewHTTP(srv.Client(), srv.URL, 0)
+		_, err := provider.Rate(context.Background(), "USD", "RUB")
+		require.NoError(t, err)
+
+		rate, err := provider.Rate(context.Background(), "USD", "RUB")
+		require.NoError(t, err)
+		assert.Equal(t, big.NewRat(80, 1), rate)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("fails without a table", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`not json`))
+		}))
+		t.Cleanup(srv.Close)
+
+		_, err := NewHTTP(srv.Client(), srv.URL, time.Hour).Rate(context.Background(), "USD", "RUB")
+		assert.Error(t, err)
+	})
+}
+
+func TestConvert(t *testing.T) {
+	table, err := NewTable("RUB", map[string]string{"USD": "0.0125"})
+	require.NoError(t, err)
+	static := NewStatic(table)
+
+	got, err := Convert(context.Background(), static, money.New(1999, "USD"), "RUB")
+	require.NoError(t, err)
+	assert.Equal(t, money.New(159920, "RUB"), got)
+
+	got, err = Convert(context.Background(), nil, money.New(1999, "RUB"), "RUB")
+	require.NoError(t, err)
+	assert.Equal(t, money.New(1999, "RUB"), got, "same currency needs no provider")
+
+	_, err = Convert(context.Background(), nil, money.New(1999, "USD"), "RUB")
+	assert.ErrorIs(t, err, ErrNoRate)
+}
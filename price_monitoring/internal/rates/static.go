@@ -0,0 +1,50 @@
+package rates
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Static serves a fixed table of rates.
+type Static struct {
+	table Table
+}
+
+func NewStatic(table Table) *Static {
+	return &Static{table: table}
+}
+
+// LoadFile reads a rate table from YAML:
+//
+//	base: RUB
+//	rates:
+//	  USD: "0.0111"
+//	  EUR: "0.0102"
+func LoadFile(path string) (*Static, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read rates file: %w", err)
+	}
+
+	var file struct {
+		Base  string            `yaml:"base"`
+		Rates map[string]string `yaml:"rates"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("cannot parse rates file: %w", err)
+	}
+
+	table, err := NewTable(file.Base, file.Rates)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rates file: %w", err)
+	}
+	return NewStatic(table), nil
+}
+
+func (s *Static) Rate(ctx context.Context, from, to string) (*big.Rat, error) {
+	return s.table.Rate(from, to)
+}
@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/parser"
+)
+
+// Tracker is the part of the service the scheduler needs.
+type Tracker interface {
+	TrackedLinks(ctx context.Context) ([]string, error)
+	// ParserItem scrapes the link and records the observation.
+	ParserItem(ctx context.Context, link string) (*parser.Product, error)
+}
+
+type Config struct {
+	// Interval between the starts of two polling runs.
+	Interval time.Duration
+	// Jitter is the upper bound of a random delay added to every interval so
+	// that runs of several instances do not hit the marketplaces in lockstep.
+	Jitter time.Duration
+	// Concurrency limits the number of links scraped at the same time.
+	Concurrency int
+}
+
+// Scheduler periodically re-scrapes every tracked link and stores the new price.
+type Scheduler struct {
+	tracker Tracker
+	cfg     Config
+}
+
+func New(tracker Tracker, cfg Config) *Scheduler {
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+
+	return &Scheduler{
+		tracker: tracker,
+		cfg:     cfg,
+	}
+}
+
+// Run polls until ctx is cancelled. A run in progress is allowed to finish
+// the links it already started, no new links are picked up after cancellation.
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		s.RunOnce(ctx)
+
+		timer := time.NewTimer(s.next())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (s *Scheduler) next() time.Duration {
+	if s.cfg.Jitter <= 0 {
+		return s.cfg.Interval
+	}
+	return s.cfg.Interval + rand.N(s.cfg.Jitter)
+}
+
+// RunOnce scrapes every tracked link once and waits for all of them.
+func (s *Scheduler) RunOnce(ctx context.Context) {
+	links, err := s.tracker.TrackedLinks(ctx)
+	if err != nil {
+		log.Printf("scheduler: cannot get tracked links err: %v", err)
+		return
+	}
+
+	start := time.Now()
+	sem := make(chan struct{}, s.cfg.Concurrency)
+	var wg sync.WaitGroup
+
+loop:
+	for _, link := range links {
+		select {
+		case <-ctx.Done():
+			break loop
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			s.refresh(ctx, link)
+		}()
+	}
+
+	wg.Wait()
+	log.Printf("scheduler: refreshed %d links in %s", len(links), time.Since(start))
+}
+
+func (s *Scheduler) refresh(ctx context.Context, link string) {
+	if _, err := s.tracker.ParserItem(ctx, link); err != nil {
+		log.Printf("scheduler: cannot parse %s err: %v", link, err)
+	}
+}
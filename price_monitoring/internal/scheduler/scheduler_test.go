@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/parser"
+)
+
+type fakeTracker struct {
+	links    []string
+	inFlight atomic.Int32
+	maxSeen  atomic.Int32
+
+	mu      sync.Mutex
+	updated map[string]int64
+}
+
+func (f *fakeTracker) TrackedLinks(ctx context.Context) ([]string, error) {
+	return f.links, nil
+}
+
+func (f *fakeTracker) ParserItem(ctx context.Context, link string) (*parser.Product, error) {
+	n := f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+	for {
+		seen := f.maxSeen.Load()
+		if n <= seen || f.maxSeen.CompareAndSwap(seen, n) {
+			break
+		}
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	if link == "broken" {
+		return nil, fmt.Errorf("cannot parse")
+	}
+
+	price := money.New(int64(len(link)), "RUB")
+	f.mu.Lock()
+	f.updated[link] = price.Amount
+	f.mu.Unlock()
+	return &parser.Product{Price: price}, nil
+}
+
+func TestRunOnce(t *testing.T) {
+	tracker := &fakeTracker{
+		links:   []string{"a", "bb", "broken", "cccc", "ddddd", "eeeeee"},
+		updated: map[string]int64{},
+	}
+
+	New(tracker, Config{Concurrency: 2}).RunOnce(context.Background())
+
+	assert.Equal(t, map[string]int64{"a": 1, "bb": 2, "cccc": 4, "ddddd": 5, "eeeeee": 6}, tracker.updated)
+	assert.LessOrEqual(t, tracker.maxSeen.Load(), int32(2))
+}
+
+func TestRunStopsOnCancel(t *testing.T) {
+	tracker := &fakeTracker{links: []string{"a"}, updated: map[string]int64{}}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		New(tracker, Config{Interval: time.Hour, Concurrency: 1}).Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		tracker.mu.Lock()
+		defer tracker.mu.Unlock()
+		return len(tracker.updated) == 1
+	}, time.Second, time.Millisecond)
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop")
+	}
+}
@@ -0,0 +1,201 @@
+package scraper
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the upstream while its
+// circuit breaker is open.
+var ErrCircuitOpen = errors.New("upstream circuit breaker is open")
+
+type BreakerState int
+
+const (
+	// BreakerClosed lets every request through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails requests fast until the cooldown is over.
+	BreakerOpen
+	// BreakerHalfOpen lets a single probe through to find out whether the
+	// upstream is back.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+type BreakerConfig struct {
+	// Threshold is the number of failed requests in a row that opens the
+	// breaker, zero disables the breakers.
+	Threshold int
+	// Cooldown is the time the breaker stays open before a probe is sent.
+	Cooldown time.Duration
+}
+
+// BreakerStatus is a snapshot of the breaker of one upstream.
+type BreakerStatus struct {
+	Name     string
+	State    BreakerState
+	Failures int
+	// OpenedAt is zero unless the breaker is open or half-open.
+	OpenedAt time.Time
+}
+
+type breaker struct {
+	name string
+	cfg  BreakerConfig
+	// used is the time of the last request, guarded by the mutex of
+	// breakers.
+	used time.Time
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// allow reports whether a request may be sent now.
+func (b *breaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if now.Sub(b.openedAt) < b.cfg.Cooldown {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return true
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record reports the outcome of an allowed request.
+func (b *breaker) record(ok bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if ok {
+		b.state = BreakerClosed
+		b.failures = 0
+		b.openedAt = time.Time{}
+		return
+	}
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.cfg.Threshold {
+		b.state = BreakerOpen
+		b.openedAt = now
+	}
+}
+
+// release gives up the probe slot of a request whose outcome says nothing
+// about the upstream, e.g. because the caller cancelled it.
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func (b *breaker) status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return BreakerStatus{
+		Name:     b.name,
+		State:    b.state,
+		Failures: b.failures,
+		OpenedAt: b.openedAt,
+	}
+}
+
+// breakers keeps one breaker per marketplace and one per other host, the
+// latter dropped once the host has been idle for a while.
+type breakers struct {
+	cfg  BreakerConfig
+	idle time.Duration
+
+	mu    sync.Mutex
+	byKey map[string]*breaker
+	swept time.Time
+}
+
+func newBreakers(cfg BreakerConfig) *breakers {
+	return &breakers{cfg: cfg, idle: idleThrottle, byKey: make(map[string]*breaker)}
+}
+
+func (b *breakers) get(key, name string) *breaker {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(b.swept) >= b.idle {
+		b.sweep(now)
+	}
+
+	br, ok := b.byKey[key]
+	if !ok {
+		br = &breaker{name: name, cfg: b.cfg}
+		b.byKey[key] = br
+	}
+	br.used = now
+	return br
+}
+
+// sweep drops the breakers of the hosts without a marketplace limit that are
+// idle, unless they are open and still cooling down or waiting for a probe.
+// The caller holds b.mu.
+func (b *breakers) sweep(now time.Time) {
+	for key, br := range b.byKey {
+		if !strings.HasPrefix(key, "host:") || now.Sub(br.used) < b.idle {
+			continue
+		}
+
+		br.mu.Lock()
+		settled := !br.probing && (br.state == BreakerClosed ||
+			br.state == BreakerOpen && now.Sub(br.openedAt) >= br.cfg.Cooldown)
+		br.mu.Unlock()
+		if settled {
+			delete(b.byKey, key)
+		}
+	}
+	b.swept = now
+}
+
+func (b *breakers) statuses() []BreakerStatus {
+	b.mu.Lock()
+	list := make([]*breaker, 0, len(b.byKey))
+	for _, br := range b.byKey {
+		list = append(list, br)
+	}
+	b.mu.Unlock()
+
+	statuses := make([]BreakerStatus, len(list))
+	for i, br := range list {
+		statuses[i] = br.status()
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
@@ -0,0 +1,319 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/netguard"
+)
+
+const (
+	defaultUserAgent = "Mozilla/5.0"
+	// maxRedirects a request follows, the limit of net/http.
+	maxRedirects = 10
+)
+
+// StatusError is returned by Get for responses other than 200 OK and for
+// captcha pages, which are marked with Challenge.
+type StatusError struct {
+	Code      int
+	Challenge bool
+}
+
+func (e *StatusError) Error() string {
+	if e.Challenge {
+		return fmt.Sprintf("Ошибка при запросе: %d, captcha", e.Code)
+	}
+	return fmt.Sprintf("Ошибка при запросе: %d", e.Code)
+}
+
+// Config of a Client. The zero value sends every request once, right away.
+type Config struct {
+	Timeout   time.Duration
+	UserAgent string
+	// Limiter throttles the requests per marketplace, nil disables it.
+	Limiter *Limiter
+	Retry   RetryPolicy
+	Breaker BreakerConfig
+	// Proxies routes the requests through a proxy pool, nil sends them
+	// directly.
+	Proxies *ProxyPool
+	// Profiles are the browser headers requests are sent with, a random
+	// one per request or a fixed one per proxy. UserAgent alone is sent if
+	// empty.
+	Profiles []HeaderProfile
+	// AllowPrivate lets the links reach loopback and private addresses, for
+	// shops served on the same host in tests.
+	AllowPrivate bool
+}
+
+// Client is the HTTP layer shared by all marketplace adapters.
+type Client struct {
+	http         *http.Client
+	userAgent    string
+	limiter      *Limiter
+	retry        RetryPolicy
+	breakers     *breakers
+	proxies      *ProxyPool
+	profiles     []HeaderProfile
+	allowPrivate bool
+}
+
+func New(cfg Config) *Client {
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = defaultUserAgent
+	}
+	if cfg.Retry.MaxAttempts < 1 {
+		cfg.Retry.MaxAttempts = 1
+	}
+
+	// The links come from the users, requests to them must not reach the
+	// network of the tracker.
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if !cfg.AllowPrivate {
+		dialer.Control = netguard.Control
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+
+	c := &Client{
+		userAgent:    cfg.UserAgent,
+		limiter:      cfg.Limiter,
+		retry:        cfg.Retry,
+		breakers:     newBreakers(cfg.Breaker),
+		proxies:      cfg.Proxies,
+		profiles:     cfg.Profiles,
+		allowPrivate: cfg.AllowPrivate,
+	}
+	c.http = &http.Client{Timeout: cfg.Timeout, Transport: transport, CheckRedirect: c.checkRedirect}
+	return c
+}
+
+// Get fetches url and returns the response body. Any status other than 200 is an error.
+// Network and server errors are retried, each attempt waiting for the limits
+// of the host. While the breaker of the upstream is open Get fails with
+// ErrCircuitOpen without sending anything. Links to addresses that are not
+// public fail with netguard.ErrPrivateAddress.
+// With a proxy pool, blocked requests are sent again right away through the
+// other proxies, without counting as attempts.
+func (c *Client) Get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkHost(ctx, req.URL.Hostname()); err != nil {
+		return nil, err
+	}
+
+	br := c.breaker(req.URL.Hostname())
+	if br != nil && !br.allow(time.Now()) {
+		return nil, ErrCircuitOpen
+	}
+
+	var (
+		body       []byte
+		blockedErr error
+		reroutes   int
+	)
+	for attempt := 1; ; attempt++ {
+		body, err = c.do(ctx, req)
+		if c.proxies != nil && ctx.Err() == nil {
+			if blocked(err) && reroutes < c.proxies.Len() {
+				blockedErr = err
+				reroutes++
+				attempt--
+				continue
+			}
+			if errors.Is(err, ErrNoProxy) && blockedErr != nil {
+				// Every proxy is blocked by the host, report that rather
+				// than the empty pool.
+				err = blockedErr
+			}
+		}
+		if !transient(err) || ctx.Err() != nil || attempt >= c.retry.MaxAttempts {
+			break
+		}
+		if sleep(ctx, c.retry.delay(attempt)) != nil {
+			break
+		}
+	}
+
+	if br != nil {
+		// Without a usable proxy nothing reached the upstream, an empty pool
+		// says nothing about its health.
+		if ctx.Err() != nil || errors.Is(err, ErrNoProxy) {
+			br.release()
+		} else {
+			br.record(!transient(err), time.Now())
+		}
+	}
+	return body, err
+}
+
+// Breakers returns the state of the circuit breaker of every upstream
+// contacted so far, ordered by name.
+func (c *Client) Breakers() []BreakerStatus {
+	return c.breakers.statuses()
+}
+
+func (c *Client) breaker(host string) *breaker {
+	if c.breakers.cfg.Threshold <= 0 {
+		return nil
+	}
+
+	host = strings.ToLower(host)
+	name, key := host, "host:"+host
+	if c.limiter != nil {
+		if n, k, _ := c.limiter.resolve(host); n != DefaultLimit {
+			name, key = n, k
+		}
+	}
+	return c.breakers.get(key, name)
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request) ([]byte, error) {
+	if c.limiter != nil {
+		release, err := c.limiter.Acquire(ctx, req.URL.Hostname())
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	client, done, err := c.route(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.send(client, req)
+	if ctx.Err() == nil {
+		done(err)
+	}
+	return body, err
+}
+
+// Resolve follows the redirects of link, a short link usually, and returns
+// the URL it ends at. Every hop must be a public address.
+func (c *Client) Resolve(ctx context.Context, link string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return "", err
+	}
+	if err := c.checkHost(ctx, req.URL.Hostname()); err != nil {
+		return "", err
+	}
+
+	if c.limiter != nil {
+		release, err := c.limiter.Acquire(ctx, req.URL.Hostname())
+		if err != nil {
+			return "", err
+		}
+		defer release()
+	}
+
+	client, done, err := c.route(req)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.Do(req)
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusBadRequest {
+			err = &StatusError{Code: resp.StatusCode}
+		}
+	}
+	if ctx.Err() == nil {
+		done(err)
+	}
+	if err != nil {
+		return "", err
+	}
+	return resp.Request.URL.String(), nil
+}
+
+// checkHost refuses hosts that are not public before they are requested
+// through a proxy, the proxy rather than the dialer of the client connects
+// to them. Direct requests are checked by the dialer.
+func (c *Client) checkHost(ctx context.Context, host string) error {
+	if c.proxies == nil || c.allowPrivate {
+		return nil
+	}
+	return netguard.CheckHost(ctx, host)
+}
+
+// checkRedirect checks every hop of a redirect chain like the first request,
+// a public short link may redirect into the network of the tracker.
+func (c *Client) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	if c.allowPrivate {
+		return nil
+	}
+	return netguard.CheckHost(req.Context(), req.URL.Hostname())
+}
+
+// route picks the HTTP client req is sent with and sets its headers. done
+// reports the outcome of the request to the proxy pool.
+func (c *Client) route(req *http.Request) (*http.Client, func(error), error) {
+	if c.proxies == nil {
+		c.headers(req, rand.N(max(len(c.profiles), 1)))
+		return c.http, func(error) {}, nil
+	}
+
+	host := strings.ToLower(req.URL.Hostname())
+	pr, err := c.proxies.pick(host)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.headers(req, pr.profile)
+
+	client := *pr.client
+	client.CheckRedirect = c.checkRedirect
+	return &client, func(err error) { c.proxies.report(pr, host, err) }, nil
+}
+
+func (c *Client) send(client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		if blocked(&StatusError{Code: resp.StatusCode}) {
+			blockedTotal.WithLabelValues(strconv.Itoa(resp.StatusCode)).Inc()
+		}
+		return nil, &StatusError{Code: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if challenge(resp, body) {
+		blockedTotal.WithLabelValues("captcha").Inc()
+		return nil, &StatusError{Code: resp.StatusCode, Challenge: true}
+	}
+	return body, nil
+}
+
+// headers sets the header profile with the given index, profiles are reused
+// round robin.
+func (c *Client) headers(req *http.Request, profile int) {
+	if len(c.profiles) == 0 {
+		req.Header.Set("User-Agent", c.userAgent)
+		return
+	}
+	req.Header = make(http.Header)
+	c.profiles[profile%len(c.profiles)].apply(req)
+}
@@ -0,0 +1,226 @@
+package scraper
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/netguard"
+)
+
+func TestGetRetries(t *testing.T) {
+	t.Run("transient failures", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+
+		client := New(Config{Timeout: time.Second, AllowPrivate: true, Retry: RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}})
+
+		body, err := client.Get(context.Background(), server.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", string(body))
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("gives up after MaxAttempts", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client := New(Config{Timeout: time.Second, AllowPrivate: true, Retry: RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond}})
+
+		_, err := client.Get(context.Background(), server.URL)
+		assert.Equal(t, &StatusError{Code: http.StatusServiceUnavailable}, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("client errors are final", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := New(Config{Timeout: time.Second, AllowPrivate: true, Retry: RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}})
+
+		_, err := client.Get(context.Background(), server.URL)
+		assert.Equal(t, &StatusError{Code: http.StatusNotFound}, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+
+	for range 20 {
+		d := policy.delay(1)
+		assert.GreaterOrEqual(t, d, 50*time.Millisecond)
+		assert.LessOrEqual(t, d, 100*time.Millisecond)
+
+		d = policy.delay(5)
+		assert.GreaterOrEqual(t, d, 150*time.Millisecond)
+		assert.LessOrEqual(t, d, 300*time.Millisecond)
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	var down atomic.Bool
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if down.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := New(Config{
+		Timeout:      time.Second,
+		AllowPrivate: true,
+		Breaker:      BreakerConfig{Threshold: 2, Cooldown: 50 * time.Millisecond},
+	})
+
+	down.Store(true)
+	for range 2 {
+		_, err := client.Get(context.Background(), server.URL)
+		assert.Error(t, err)
+	}
+
+	_, err := client.Get(context.Background(), server.URL)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(2), calls.Load())
+
+	statuses := client.Breakers()
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, BreakerOpen, statuses[0].State)
+	assert.Equal(t, 2, statuses[0].Failures)
+	assert.False(t, statuses[0].OpenedAt.IsZero())
+
+	// A failed probe opens the breaker again.
+	time.Sleep(60 * time.Millisecond)
+	_, err = client.Get(context.Background(), server.URL)
+	assert.Equal(t, &StatusError{Code: http.StatusInternalServerError}, err)
+	_, err = client.Get(context.Background(), server.URL)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	// A successful one closes it.
+	down.Store(false)
+	time.Sleep(60 * time.Millisecond)
+	_, err = client.Get(context.Background(), server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, BreakerClosed, client.Breakers()[0].State)
+	assert.Equal(t, 0, client.Breakers()[0].Failures)
+}
+
+func TestBreakerHalfOpenSingleProbe(t *testing.T) {
+	b := &breaker{cfg: BreakerConfig{Threshold: 1, Cooldown: time.Minute}}
+	now := time.Now()
+
+	b.record(false, now)
+	assert.False(t, b.allow(now))
+
+	later := now.Add(time.Minute)
+	assert.True(t, b.allow(later))
+	assert.False(t, b.allow(later))
+
+	b.release()
+	assert.True(t, b.allow(later))
+}
+
+func TestBreakerDropsIdleHosts(t *testing.T) {
+	client := New(Config{
+		Limiter: NewLimiter(map[string]Limit{
+			"shop": {Hosts: []string{"*.shop.example"}},
+		}),
+		Breaker: BreakerConfig{Threshold: 1, Cooldown: time.Minute},
+	})
+	client.breakers.idle = 20 * time.Millisecond
+
+	client.breaker("a.shop.example")
+	client.breaker("a.example")
+	client.breaker("b.example").record(false, time.Now())
+	assert.Len(t, client.breakers.byKey, 3)
+
+	time.Sleep(30 * time.Millisecond)
+	client.breaker("c.example")
+
+	assert.ElementsMatch(t, []string{"shop", "host:b.example", "host:c.example"}, slices.Collect(maps.Keys(client.breakers.byKey)),
+		"idle hosts are dropped, marketplaces and open breakers are kept")
+}
+
+func TestGetRefusesPrivateAddresses(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	t.Run("direct", func(t *testing.T) {
+		client := New(Config{Timeout: time.Second, Retry: RetryPolicy{MaxAttempts: 3}})
+
+		_, err := client.Get(context.Background(), server.URL)
+		assert.ErrorIs(t, err, netguard.ErrPrivateAddress)
+		_, err = client.Get(context.Background(), "http://169.254.169.254/latest/meta-data/")
+		assert.ErrorIs(t, err, netguard.ErrPrivateAddress)
+		assert.Zero(t, calls.Load())
+	})
+
+	t.Run("proxy", func(t *testing.T) {
+		proxy := newTestProxy(t, ok)
+		pool, err := NewProxyPool(ProxyConfig{URLs: []string{proxy.URL}}, time.Second)
+		require.NoError(t, err)
+		client := New(Config{Timeout: time.Second, Proxies: pool})
+
+		_, err = client.Get(context.Background(), server.URL)
+		assert.ErrorIs(t, err, netguard.ErrPrivateAddress)
+		assert.Zero(t, proxy.calls.Load(), "the proxy must not fetch it either")
+	})
+}
+
+func TestResolveRefusesPrivateRedirects(t *testing.T) {
+	// The proxy stands in for a public shop whose short link redirects to the
+	// cloud metadata endpoint.
+	proxy := newTestProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	})
+	pool, err := NewProxyPool(ProxyConfig{URLs: []string{proxy.URL}}, time.Second)
+	require.NoError(t, err)
+	client := New(Config{Timeout: time.Second, Proxies: pool})
+
+	_, err = client.Resolve(context.Background(), "http://93.184.216.34/s/abc")
+	assert.ErrorIs(t, err, netguard.ErrPrivateAddress)
+	assert.Equal(t, int32(1), proxy.calls.Load(), "the redirect is not followed")
+}
+
+func TestBreakerGroupsMarketplaceHosts(t *testing.T) {
+	client := New(Config{
+		Limiter: NewLimiter(map[string]Limit{
+			"shop": {Hosts: []string{"*.shop.example"}},
+		}),
+		Breaker: BreakerConfig{Threshold: 1, Cooldown: time.Minute},
+	})
+
+	assert.Same(t, client.breaker("a.shop.example"), client.breaker("B.shop.example"))
+	assert.NotSame(t, client.breaker("a.other.example"), client.breaker("b.other.example"))
+	assert.Equal(t, "shop", client.breaker("a.shop.example").name)
+	assert.Equal(t, "a.other.example", client.breaker("a.other.example").name)
+}
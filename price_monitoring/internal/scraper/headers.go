@@ -0,0 +1,85 @@
+package scraper
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// HeaderProfile is a consistent set of request headers of one browser.
+type HeaderProfile map[string]string
+
+// BrowserProfiles imitate current desktop and mobile browsers. Accept-Encoding
+// is left to the transport, which decompresses only what it asked for itself.
+var BrowserProfiles = []HeaderProfile{
+	{
+		"User-Agent":                "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+		"Accept":                    "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8",
+		"Accept-Language":           "ru-RU,ru;q=0.9,en-US;q=0.8,en;q=0.7",
+		"Sec-Ch-Ua":                 `"Chromium";v="124", "Google Chrome";v="124", "Not-A.Brand";v="99"`,
+		"Sec-Ch-Ua-Mobile":          "?0",
+		"Sec-Ch-Ua-Platform":        `"Windows"`,
+		"Upgrade-Insecure-Requests": "1",
+	},
+	{
+		"User-Agent":                "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Safari/605.1.15",
+		"Accept":                    "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+		"Accept-Language":           "ru-RU,ru;q=0.9",
+		"Upgrade-Insecure-Requests": "1",
+	},
+	{
+		"User-Agent":                "Mozilla/5.0 (X11; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0",
+		"Accept":                    "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8",
+		"Accept-Language":           "ru-RU,ru;q=0.8,en-US;q=0.5,en;q=0.3",
+		"Upgrade-Insecure-Requests": "1",
+	},
+	{
+		"User-Agent":         "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Mobile Safari/537.36",
+		"Accept":             "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8",
+		"Accept-Language":    "ru-RU,ru;q=0.9,en-US;q=0.8",
+		"Sec-Ch-Ua":          `"Chromium";v="124", "Google Chrome";v="124", "Not-A.Brand";v="99"`,
+		"Sec-Ch-Ua-Mobile":   "?1",
+		"Sec-Ch-Ua-Platform": `"Android"`,
+	},
+}
+
+func (h HeaderProfile) apply(req *http.Request) {
+	for name, value := range h {
+		req.Header.Set(name, value)
+	}
+}
+
+var (
+	titleRe = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+	challengeTitles = regexp.MustCompile(`(?i)captcha|just a moment|attention required|access denied|подозрительн|доступ ограничен|вы робот`)
+	// The captcha paths and the markers of the Cloudflare and Yandex checks
+	// count only as the target of a form or the source of a script or frame,
+	// product pages may link to them or mention them in their text and
+	// scripts.
+	challengeForm = regexp.MustCompile(`(?i)<(?:form\s[^>]*\baction|(?:script|iframe)\s[^>]*\bsrc)\s*=\s*["']?[^"'\s>]*(?:/captcha/|checkcaptcha|smartcaptcha|cf-chl-)`)
+)
+
+// challenge reports whether an HTML page is a captcha or bot check served in
+// place of the requested content.
+func challenge(resp *http.Response, body []byte) bool {
+	if !strings.Contains(strings.ToLower(resp.Header.Get("Content-Type")), "html") {
+		return false
+	}
+
+	if m := titleRe.FindSubmatch(body); m != nil && challengeTitles.Match(m[1]) {
+		return true
+	}
+	return challengeForm.Match(body)
+}
+
+// blocked reports whether the host refused to serve the request: 403, 429
+// or a captcha page. Another exit address may get through.
+func blocked(err error) bool {
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	return statusErr.Challenge || statusErr.Code == http.StatusForbidden || statusErr.Code == http.StatusTooManyRequests
+}
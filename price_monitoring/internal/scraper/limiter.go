@@ -0,0 +1,233 @@
+package scraper
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// DefaultLimit is the name of the limit applied to hosts no marketplace
+// limit matches.
+const DefaultLimit = "default"
+
+// idleThrottle is how long the throttle and the circuit breaker of a host
+// without a marketplace limit are kept after its last request.
+const idleThrottle = 10 * time.Minute
+
+// Limit is the politeness policy for the hosts of one marketplace.
+type Limit struct {
+	// Hosts are the path.Match patterns of the hosts the limit applies to.
+	Hosts []string
+	// Rate is the sustained number of requests per second and Burst the
+	// number of requests allowed at once. A zero Rate disables the bucket.
+	Rate  float64
+	Burst int
+	// MaxInFlight caps the concurrent requests, zero means no cap.
+	MaxInFlight int
+	// MinDelay is the minimum time between the starts of two requests.
+	MinDelay time.Duration
+}
+
+// Limiter enforces the limits of every marketplace. The hosts of a
+// marketplace share one set of limits, every other host gets its own copy
+// of the default limit, dropped once the host has been idle for a while.
+type Limiter struct {
+	names    []string
+	limits   map[string]Limit
+	fallback Limit
+	idle     time.Duration
+
+	mu        sync.Mutex
+	throttles map[string]*throttle
+	swept     time.Time
+}
+
+// NewLimiter creates a limiter from limits keyed by marketplace name. The
+// DefaultLimit entry, if any, applies to the remaining hosts.
+func NewLimiter(limits map[string]Limit) *Limiter {
+	l := &Limiter{
+		limits:    make(map[string]Limit),
+		idle:      idleThrottle,
+		throttles: make(map[string]*throttle),
+	}
+
+	for name, limit := range limits {
+		if name == DefaultLimit {
+			l.fallback = limit
+			continue
+		}
+		hosts := make([]string, len(limit.Hosts))
+		for i, host := range limit.Hosts {
+			hosts[i] = strings.ToLower(host)
+		}
+		limit.Hosts = hosts
+		l.names = append(l.names, name)
+		l.limits[name] = limit
+	}
+	// Map order is random, keep host matching deterministic.
+	sort.Strings(l.names)
+
+	return l
+}
+
+// Acquire waits until a request to host is allowed. The returned release
+// must be called once the request is done.
+func (l *Limiter) Acquire(ctx context.Context, host string) (func(), error) {
+	return l.throttle(strings.ToLower(host)).acquire(ctx)
+}
+
+// resolve returns the name of the limit of host and the key of the state it
+// shares with other hosts.
+func (l *Limiter) resolve(host string) (name, key string, limit Limit) {
+	for _, n := range l.names {
+		if matchHost(l.limits[n].Hosts, host) {
+			return n, n, l.limits[n]
+		}
+	}
+	return DefaultLimit, "host:" + host, l.fallback
+}
+
+func (l *Limiter) throttle(host string) *throttle {
+	name, key, limit := l.resolve(host)
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.swept) >= l.idle {
+		l.sweep(now)
+	}
+
+	t, ok := l.throttles[key]
+	if !ok {
+		t = newThrottle(name, limit)
+		l.throttles[key] = t
+	}
+	t.used = now
+	return t
+}
+
+// sweep drops the throttles of the hosts without a marketplace limit that
+// are idle, the generic parser would otherwise keep one for every host it
+// has ever seen. The caller holds l.mu.
+func (l *Limiter) sweep(now time.Time) {
+	for key, t := range l.throttles {
+		if t.name == DefaultLimit && now.Sub(t.used) >= l.idle && (t.slots == nil || len(t.slots) == 0) {
+			delete(l.throttles, key)
+		}
+	}
+	l.swept = now
+}
+
+func matchHost(patterns []string, host string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, host); ok {
+			return true
+		}
+	}
+	return false
+}
+
+type throttle struct {
+	name     string
+	bucket   *rate.Limiter
+	slots    chan struct{}
+	minDelay time.Duration
+
+	// used is the time of the last request, guarded by Limiter.mu.
+	used time.Time
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newThrottle(name string, limit Limit) *throttle {
+	t := &throttle{
+		name:     name,
+		minDelay: limit.MinDelay,
+	}
+	if limit.Rate > 0 {
+		t.bucket = rate.NewLimiter(rate.Limit(limit.Rate), max(limit.Burst, 1))
+	}
+	if limit.MaxInFlight > 0 {
+		t.slots = make(chan struct{}, limit.MaxInFlight)
+	}
+	return t
+}
+
+func (t *throttle) acquire(ctx context.Context) (func(), error) {
+	start := time.Now()
+
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		default:
+			throttledTotal.WithLabelValues(t.name, "in_flight").Inc()
+			select {
+			case t.slots <- struct{}{}:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+	release := func() {
+		inFlight.WithLabelValues(t.name).Dec()
+		if t.slots != nil {
+			<-t.slots
+		}
+	}
+	inFlight.WithLabelValues(t.name).Inc()
+
+	if t.bucket != nil {
+		if !t.bucket.Allow() {
+			throttledTotal.WithLabelValues(t.name, "rate").Inc()
+			if err := t.bucket.Wait(ctx); err != nil {
+				release()
+				return nil, err
+			}
+		}
+	}
+
+	if t.minDelay > 0 {
+		if err := t.delay(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	throttleWait.WithLabelValues(t.name).Observe(time.Since(start).Seconds())
+	return release, nil
+}
+
+// delay spaces the request starts at least minDelay apart.
+func (t *throttle) delay(ctx context.Context) error {
+	t.mu.Lock()
+	now := time.Now()
+	at := t.next
+	if at.Before(now) {
+		at = now
+	}
+	t.next = at.Add(t.minDelay)
+	t.mu.Unlock()
+
+	wait := at.Sub(now)
+	if wait <= 0 {
+		return nil
+	}
+	throttledTotal.WithLabelValues(t.name, "delay").Inc()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,166 @@
+package scraper
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiterMaxInFlight(t *testing.T) {
+	l := NewLimiter(map[string]Limit{
+		"shop": {Hosts: []string{"*.shop.example"}, MaxInFlight: 2},
+	})
+
+	var running, peak atomic.Int32
+	var wg sync.WaitGroup
+	for _, host := range []string{"a.shop.example", "b.shop.example", "A.SHOP.EXAMPLE", "c.shop.example", "d.shop.example"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			release, err := l.Acquire(context.Background(), host)
+			assert.NoError(t, err)
+			defer release()
+
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), peak.Load())
+}
+
+func TestLimiterMinDelay(t *testing.T) {
+	l := NewLimiter(map[string]Limit{
+		DefaultLimit: {MinDelay: 20 * time.Millisecond},
+	})
+
+	start := time.Now()
+	for range 3 {
+		release, err := l.Acquire(context.Background(), "shop.example")
+		assert.NoError(t, err)
+		release()
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	// Other hosts get their own copy of the default limit.
+	start = time.Now()
+	release, err := l.Acquire(context.Background(), "other.example")
+	assert.NoError(t, err)
+	release()
+	assert.Less(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestLimiterDropsIdleHosts(t *testing.T) {
+	l := NewLimiter(map[string]Limit{
+		"shop":       {Hosts: []string{"shop.example"}, MaxInFlight: 1},
+		DefaultLimit: {MaxInFlight: 1},
+	})
+	l.idle = 20 * time.Millisecond
+
+	for _, host := range []string{"shop.example", "a.example", "b.example"} {
+		release, err := l.Acquire(context.Background(), host)
+		assert.NoError(t, err)
+		release()
+	}
+	busy, err := l.Acquire(context.Background(), "c.example")
+	assert.NoError(t, err)
+	defer busy()
+	assert.Len(t, l.throttles, 4)
+
+	time.Sleep(30 * time.Millisecond)
+	release, err := l.Acquire(context.Background(), "d.example")
+	assert.NoError(t, err)
+	release()
+
+	assert.ElementsMatch(t, []string{"shop", "host:c.example", "host:d.example"}, slices.Collect(maps.Keys(l.throttles)),
+		"idle hosts are dropped, marketplaces and hosts with requests in flight are kept")
+}
+
+func TestLimiterRate(t *testing.T) {
+	l := NewLimiter(map[string]Limit{
+		"shop": {Hosts: []string{"shop.example"}, Rate: 1, Burst: 1},
+	})
+
+	release, err := l.Acquire(context.Background(), "shop.example")
+	assert.NoError(t, err)
+	release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = l.Acquire(ctx, "shop.example")
+	assert.Error(t, err)
+}
+
+func TestLimiterCancelledWhileQueued(t *testing.T) {
+	l := NewLimiter(map[string]Limit{
+		DefaultLimit: {MaxInFlight: 1},
+	})
+
+	release, err := l.Acquire(context.Background(), "shop.example")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.Acquire(ctx, "shop.example")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	release, err = l.Acquire(context.Background(), "shop.example")
+	assert.NoError(t, err)
+	release()
+}
+
+func TestClientUsesLimiter(t *testing.T) {
+	var running, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := New(Config{
+		AllowPrivate: true,
+		Timeout:      time.Second,
+		Limiter: NewLimiter(map[string]Limit{
+			DefaultLimit: {MaxInFlight: 1},
+		}),
+	})
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Get(context.Background(), server.URL)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), peak.Load())
+}
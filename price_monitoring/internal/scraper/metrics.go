@@ -0,0 +1,33 @@
+package scraper
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Throttling metrics, labelled by the name of the limit: the marketplace or
+// DefaultLimit.
+var (
+	inFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "scraper_requests_in_flight",
+		Help: "Requests to the marketplace currently in progress.",
+	}, []string{"limit"})
+
+	throttledTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "scraper_throttled_requests_total",
+		Help: "Requests held back, by the limit that held them: in_flight, rate or delay.",
+	}, []string{"limit", "reason"})
+
+	throttleWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "scraper_throttle_wait_seconds",
+		Help:    "Time requests waited for the rate limits before being sent.",
+		Buckets: []float64{0.001, 0.01, 0.1, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"limit"})
+)
+
+// blockedTotal counts the responses that refused to serve a product page, by
+// status code or "captcha".
+var blockedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "scraper_blocked_responses_total",
+	Help: "Responses blocking the scraper: 403, 429 or a captcha page.",
+}, []string{"reason"})
@@ -0,0 +1,283 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ErrNoProxy is returned when every proxy of the pool is down or was blocked
+// by the host recently.
+var ErrNoProxy = errors.New("no usable proxy")
+
+type ProxyMode string
+
+const (
+	// ProxyRotate sends consecutive requests through different proxies.
+	ProxyRotate ProxyMode = "rotate"
+	// ProxySticky keeps sending the requests to a host through the same
+	// proxy until it fails or gets blocked.
+	ProxySticky ProxyMode = "sticky"
+)
+
+const defaultProxyCooldown = 5 * time.Minute
+
+type ProxyConfig struct {
+	// URLs of the proxies: http://, https:// or socks5://, credentials go
+	// into the user info.
+	URLs []string
+	// Mode defaults to ProxyRotate.
+	Mode ProxyMode
+	// MaxFailures network errors in a row take a proxy out of the pool for
+	// Cooldown. A proxy blocked by a host is not used for that host for
+	// Cooldown either.
+	MaxFailures int
+	Cooldown    time.Duration
+	// CheckURL is fetched through every proxy each CheckInterval, proxies
+	// that fail the check are not used until they pass it. Empty disables
+	// the checks.
+	CheckURL      string
+	CheckInterval time.Duration
+}
+
+type proxy struct {
+	url    *url.URL
+	client *http.Client
+	// profile is the index of the header profile the proxy presents, so
+	// that one exit address keeps looking like one browser.
+	profile int
+
+	failures  int
+	downUntil time.Time
+	blocked   map[string]time.Time
+}
+
+// stickyRoute is the proxy a host sticks to and the time of its last
+// request.
+type stickyRoute struct {
+	proxy *proxy
+	used  time.Time
+}
+
+// ProxyPool spreads the requests of a Client over outbound proxies.
+type ProxyPool struct {
+	cfg ProxyConfig
+
+	// idle is how long a host keeps its sticky proxy after its last
+	// request.
+	idle time.Duration
+
+	mu      sync.Mutex
+	proxies []*proxy
+	next    int
+	sticky  map[string]stickyRoute
+	swept   time.Time
+}
+
+// NewProxyPool creates a pool of cfg.URLs. Requests through the pool time out
+// after timeout like those of the Client.
+func NewProxyPool(cfg ProxyConfig, timeout time.Duration) (*ProxyPool, error) {
+	if cfg.Mode == "" {
+		cfg.Mode = ProxyRotate
+	}
+	if cfg.Mode != ProxyRotate && cfg.Mode != ProxySticky {
+		return nil, fmt.Errorf("unknown proxy mode %q", cfg.Mode)
+	}
+	if cfg.MaxFailures < 1 {
+		cfg.MaxFailures = 1
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = defaultProxyCooldown
+	}
+
+	pool := &ProxyPool{
+		cfg:    cfg,
+		idle:   idleThrottle,
+		sticky: make(map[string]stickyRoute),
+	}
+	for i, raw := range cfg.URLs {
+		u, err := url.Parse(raw)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy url %q", raw)
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("unsupported proxy scheme %q", u.Scheme)
+		}
+
+		pool.proxies = append(pool.proxies, &proxy{
+			url: u,
+			client: &http.Client{
+				Timeout:   timeout,
+				Transport: &http.Transport{Proxy: http.ProxyURL(u)},
+			},
+			profile: i,
+			blocked: make(map[string]time.Time),
+		})
+	}
+
+	return pool, nil
+}
+
+// Len returns the number of proxies in the pool, usable or not.
+func (p *ProxyPool) Len() int {
+	return len(p.proxies)
+}
+
+// pick returns the proxy for the next request to host.
+func (p *ProxyPool) pick(host string) (*proxy, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(p.swept) >= p.idle {
+		p.sweep(now)
+	}
+	if p.cfg.Mode == ProxySticky {
+		if route, ok := p.sticky[host]; ok && route.proxy.usable(host, now) {
+			p.sticky[host] = stickyRoute{proxy: route.proxy, used: now}
+			return route.proxy, nil
+		}
+	}
+
+	for range p.proxies {
+		pr := p.proxies[p.next]
+		p.next = (p.next + 1) % len(p.proxies)
+		if !pr.usable(host, now) {
+			continue
+		}
+		if p.cfg.Mode == ProxySticky {
+			p.sticky[host] = stickyRoute{proxy: pr, used: now}
+		}
+		return pr, nil
+	}
+
+	return nil, ErrNoProxy
+}
+
+// sweep forgets the sticky proxies of idle hosts and the blocks that are
+// over, the generic parser would otherwise keep them for every host it has
+// ever seen. The caller holds p.mu.
+func (p *ProxyPool) sweep(now time.Time) {
+	for host, route := range p.sticky {
+		if now.Sub(route.used) >= p.idle {
+			delete(p.sticky, host)
+		}
+	}
+	for _, pr := range p.proxies {
+		for host, until := range pr.blocked {
+			if now.After(until) {
+				delete(pr.blocked, host)
+			}
+		}
+	}
+	p.swept = now
+}
+
+func (pr *proxy) usable(host string, now time.Time) bool {
+	return now.After(pr.downUntil) && now.After(pr.blocked[host])
+}
+
+// report records the outcome of a request to host sent through pr.
+func (p *ProxyPool) report(pr *proxy, host string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var statusErr *StatusError
+	switch {
+	case blocked(err):
+		pr.blocked[host] = time.Now().Add(p.cfg.Cooldown)
+		if p.sticky[host].proxy == pr {
+			delete(p.sticky, host)
+		}
+		log.Printf("scraper: %s blocked proxy %s: %v", host, pr.url.Redacted(), err)
+	case err == nil, errors.As(err, &statusErr):
+		// The proxy relayed an answer of the host, it works.
+		pr.failures = 0
+	default:
+		pr.failures++
+		if pr.failures >= p.cfg.MaxFailures {
+			pr.failures = 0
+			pr.downUntil = time.Now().Add(p.cfg.Cooldown)
+			log.Printf("scraper: proxy %s is down: %v", pr.url.Redacted(), err)
+		}
+	}
+}
+
+// Run checks the proxies every CheckInterval until ctx is done.
+func (p *ProxyPool) Run(ctx context.Context) {
+	if p.cfg.CheckURL == "" || p.cfg.CheckInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(p.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		p.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check fetches CheckURL through every proxy once. Proxies that pass return
+// to the pool even if they were down, the others leave it until the next
+// check.
+func (p *ProxyPool) Check(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, pr := range p.proxies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := p.check(ctx, pr)
+			if ctx.Err() != nil {
+				return
+			}
+
+			p.mu.Lock()
+			defer p.mu.Unlock()
+
+			now := time.Now()
+			switch {
+			case err == nil:
+				if !pr.usable("", now) {
+					log.Printf("scraper: proxy %s is back", pr.url.Redacted())
+				}
+				pr.failures, pr.downUntil = 0, time.Time{}
+			case pr.usable("", now):
+				pr.downUntil = now.Add(p.cfg.CheckInterval)
+				log.Printf("scraper: proxy %s failed the health check: %v", pr.url.Redacted(), err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (p *ProxyPool) check(ctx context.Context, pr *proxy) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.CheckURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := pr.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return &StatusError{Code: resp.StatusCode}
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package scraper
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testProxy is a forward proxy that answers every request itself with
+// handler.
+type testProxy struct {
+	*httptest.Server
+	calls atomic.Int32
+	agent atomic.Value
+}
+
+func newTestProxy(t *testing.T, handler http.HandlerFunc) *testProxy {
+	p := &testProxy{}
+	p.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p.calls.Add(1)
+		p.agent.Store(r.UserAgent())
+		handler(w, r)
+	}))
+	t.Cleanup(p.Close)
+	return p
+}
+
+func ok(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("ok"))
+}
+
+func newProxyClient(t *testing.T, cfg ProxyConfig, proxies ...*testProxy) *Client {
+	for _, p := range proxies {
+		cfg.URLs = append(cfg.URLs, p.URL)
+	}
+	pool, err := NewProxyPool(cfg, time.Second)
+	require.NoError(t, err)
+
+	return New(Config{Timeout: time.Second, AllowPrivate: true, Proxies: pool, Profiles: BrowserProfiles})
+}
+
+func TestProxyModes(t *testing.T) {
+	t.Run("rotate", func(t *testing.T) {
+		a, b := newTestProxy(t, ok), newTestProxy(t, ok)
+		client := newProxyClient(t, ProxyConfig{Mode: ProxyRotate}, a, b)
+
+		for range 4 {
+			_, err := client.Get(context.Background(), "http://shop.test/item")
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(2), a.calls.Load())
+		assert.Equal(t, int32(2), b.calls.Load())
+	})
+
+	t.Run("sticky", func(t *testing.T) {
+		a, b := newTestProxy(t, ok), newTestProxy(t, ok)
+		client := newProxyClient(t, ProxyConfig{Mode: ProxySticky}, a, b)
+
+		for range 3 {
+			_, err := client.Get(context.Background(), "http://shop.test/item")
+			require.NoError(t, err)
+		}
+		_, err := client.Get(context.Background(), "http://other.test/item")
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(3), a.calls.Load())
+		assert.Equal(t, int32(1), b.calls.Load())
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		_, err := NewProxyPool(ProxyConfig{Mode: "random"}, time.Second)
+		assert.Error(t, err)
+	})
+}
+
+func TestProxyPoolDropsIdleHosts(t *testing.T) {
+	pool, err := NewProxyPool(ProxyConfig{
+		URLs:     []string{"http://a.proxy:3128", "http://b.proxy:3128"},
+		Mode:     ProxySticky,
+		Cooldown: 10 * time.Millisecond,
+	}, time.Second)
+	require.NoError(t, err)
+	pool.idle = 20 * time.Millisecond
+
+	for _, host := range []string{"a.example", "b.example"} {
+		_, err := pool.pick(host)
+		require.NoError(t, err)
+	}
+	pr, err := pool.pick("c.example")
+	require.NoError(t, err)
+	pool.report(pr, "c.example", &StatusError{Code: http.StatusForbidden})
+	assert.Len(t, pool.sticky, 2)
+
+	time.Sleep(30 * time.Millisecond)
+	_, err = pool.pick("d.example")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"d.example"}, slices.Collect(maps.Keys(pool.sticky)))
+	for _, pr := range pool.proxies {
+		assert.Empty(t, pr.blocked, "blocks that are over are forgotten")
+	}
+}
+
+func TestProxyProfiles(t *testing.T) {
+	a, b := newTestProxy(t, ok), newTestProxy(t, ok)
+	client := newProxyClient(t, ProxyConfig{}, a, b)
+
+	for range 4 {
+		_, err := client.Get(context.Background(), "http://shop.test/item")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, BrowserProfiles[0]["User-Agent"], a.agent.Load())
+	assert.Equal(t, BrowserProfiles[1]["User-Agent"], b.agent.Load())
+}
+
+func TestBlockedRequestsAreRerouted(t *testing.T) {
+	t.Run("another proxy gets through", func(t *testing.T) {
+		blocked := newTestProxy(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+		working := newTestProxy(t, ok)
+		client := newProxyClient(t, ProxyConfig{}, blocked, working)
+
+		for range 3 {
+			body, err := client.Get(context.Background(), "http://shop.test/item")
+			require.NoError(t, err)
+			assert.Equal(t, "ok", string(body))
+		}
+
+		// The blocked proxy is not used for the host again.
+		assert.Equal(t, int32(1), blocked.calls.Load())
+		assert.Equal(t, int32(3), working.calls.Load())
+
+		_, err := client.Get(context.Background(), "http://other.test/item")
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), blocked.calls.Load())
+	})
+
+	t.Run("captcha pages", func(t *testing.T) {
+		captcha := newTestProxy(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("<html><head><title>Just a moment...</title></head></html>"))
+		})
+		working := newTestProxy(t, ok)
+		client := newProxyClient(t, ProxyConfig{}, captcha, working)
+
+		body, err := client.Get(context.Background(), "http://shop.test/item")
+		require.NoError(t, err)
+		assert.Equal(t, "ok", string(body))
+	})
+
+	t.Run("every proxy blocked", func(t *testing.T) {
+		forbidden := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}
+		a, b := newTestProxy(t, forbidden), newTestProxy(t, forbidden)
+		client := newProxyClient(t, ProxyConfig{}, a, b)
+
+		_, err := client.Get(context.Background(), "http://shop.test/item")
+		assert.Equal(t, &StatusError{Code: http.StatusForbidden}, err)
+		assert.Equal(t, int32(1), a.calls.Load())
+		assert.Equal(t, int32(1), b.calls.Load())
+
+		_, err = client.Get(context.Background(), "http://shop.test/item")
+		assert.ErrorIs(t, err, ErrNoProxy)
+	})
+}
+
+func TestNoProxyKeepsBreakerClosed(t *testing.T) {
+	forbidden := newTestProxy(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	pool, err := NewProxyPool(ProxyConfig{URLs: []string{forbidden.URL}}, time.Second)
+	require.NoError(t, err)
+	client := New(Config{
+		Timeout:      time.Second,
+		AllowPrivate: true,
+		Proxies:      pool,
+		Retry:        RetryPolicy{MaxAttempts: 3},
+		Breaker:      BreakerConfig{Threshold: 1, Cooldown: time.Minute},
+	})
+
+	_, err = client.Get(context.Background(), "http://shop.test/item")
+	require.Error(t, err)
+	for range 3 {
+		_, err = client.Get(context.Background(), "http://shop.test/item")
+		assert.ErrorIs(t, err, ErrNoProxy, "not retried nor refused by an open breaker")
+	}
+
+	assert.Equal(t, BreakerClosed, client.Breakers()[0].State)
+	assert.Equal(t, 0, client.Breakers()[0].Failures)
+}
+
+func TestProxyHealth(t *testing.T) {
+	dead := newTestProxy(t, ok)
+	dead.Close()
+	working := newTestProxy(t, ok)
+
+	pool, err := NewProxyPool(ProxyConfig{
+		URLs:        []string{dead.URL, working.URL},
+		MaxFailures: 1,
+		CheckURL:    "http://check.test/",
+	}, time.Second)
+	require.NoError(t, err)
+	client := New(Config{Timeout: time.Second, AllowPrivate: true, Proxies: pool, Retry: RetryPolicy{MaxAttempts: 2}})
+
+	body, err := client.Get(context.Background(), "http://shop.test/item")
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+
+	_, err = pool.pick("shop.test")
+	require.NoError(t, err)
+	pr, err := pool.pick("shop.test")
+	require.NoError(t, err)
+	assert.Equal(t, working.URL, pr.url.String(), "the dead proxy is out of the pool")
+
+	// A passing check brings a proxy back before its cooldown is over.
+	pool.proxies[1].downUntil = time.Now().Add(time.Hour)
+	pool.Check(context.Background())
+	now := time.Now()
+	assert.False(t, pool.proxies[0].usable("shop.test", now))
+	assert.True(t, pool.proxies[1].usable("shop.test", now))
+}
+
+func TestChallenge(t *testing.T) {
+	html := http.Header{"Content-Type": {"text/html"}}
+
+	for _, tc := range []struct {
+		name   string
+		header http.Header
+		body   string
+		want   bool
+	}{
+		{"product page", html, "<title>Смартфон купить</title>", false},
+		{"cloudflare", html, "<title>Just a moment...</title>", true},
+		{"yandex", html, `<title>Ой!</title><form action="/checkcaptcha"><div class="SmartCaptcha">`, true},
+		{"yandex widget", html, `<title>Ой!</title><script src="https://smartcaptcha.yandexcloud.net/captcha.js" defer></script>`, true},
+		{"challenge script", html, `<script src="/cdn-cgi/challenge-platform/h/b/orchestrate/cf-chl-"></script>`, true},
+		{"captcha form", html, `<title>Проверка</title><form method="post" action="/captcha/check">`, true},
+		{"captcha script", html, `<script src='https://shop.ru/captcha/widget.js'></script>`, true},
+		{"link to a captcha page", html, `<title>Лампа</title><a href="/captcha/help">Не проходит проверка?</a> see /captcha/ docs`, false},
+		{"smartcaptcha in a script", html, `<title>Лампа</title><script>window.__config = {"smartcaptcha": false}</script>`, false},
+		{"cf-chl- in the text", html, `<title>Лампа</title><p>Ошибка cf-chl-bypass? Обновите страницу.</p>`, false},
+		{"russian title", html, "<title>Доступ ограничен</title>", true},
+		{"json", http.Header{"Content-Type": {"application/json"}}, `{"title":"captcha"}`, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &http.Response{Header: tc.header}
+			assert.Equal(t, tc.want, challenge(resp, []byte(tc.body)))
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/netguard"
+)
+
+// RetryPolicy controls how often a failed fetch is repeated.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, one disables retries.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled after every
+	// attempt up to MaxBackoff. Each delay is randomized between half and
+	// the full value so that clients do not retry in lockstep.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 {
+		d = min(d, p.MaxBackoff)
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// transient reports whether err is worth another attempt and counts against
+// the upstream health: network failures, timeouts and server errors.
+// Client errors like 404 and rate limiting by the shop are answers, not outages.
+func transient(err error) bool {
+	if err == nil || errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrNoProxy) ||
+		errors.Is(err, netguard.ErrPrivateAddress) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Code >= http.StatusInternalServerError || statusErr.Code == http.StatusRequestTimeout
+	}
+	return true
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
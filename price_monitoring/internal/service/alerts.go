@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+
+	postgres_db "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/parser"
+)
+
+var (
+	ErrInvalidRule  = errors.New("invalid alert rule")
+	ErrRuleNotFound = errors.New("alert rule not found")
+)
+
+type AlertKind int16
+
+const (
+	AlertUnspecified AlertKind = iota
+	// AlertTargetPrice fires when the price falls to TargetPrice or below.
+	AlertTargetPrice
+	// AlertPercentDrop fires when the price falls by DropPercent from the
+	// start price of the subscription.
+	AlertPercentDrop
+	// AlertAnyDrop fires whenever the price is lower than on the previous check.
+	AlertAnyDrop
+	// AlertBackInStock fires when an out of stock item becomes available.
+	AlertBackInStock
+)
+
+func (s *Service) CreateAlertRule(ctx context.Context, userId string, rule postgres_db.AlertRule) (postgres_db.AlertRule, error) {
+	switch AlertKind(rule.Kind) {
+	case AlertTargetPrice:
+		if rule.TargetPrice.Amount <= 0 || rule.TargetPrice.Currency == "" {
+			return postgres_db.AlertRule{}, fmt.Errorf("%w: target price must be positive and have a currency", ErrInvalidRule)
+		}
+		rule.DropPercent = 0
+	case AlertPercentDrop:
+		if rule.DropPercent <= 0 || rule.DropPercent >= 100 {
+			return postgres_db.AlertRule{}, fmt.Errorf("%w: drop percent must be between 0 and 100", ErrInvalidRule)
+		}
+		rule.TargetPrice = money.Money{}
+	case AlertAnyDrop, AlertBackInStock:
+		rule.TargetPrice, rule.DropPercent = money.Money{}, 0
+	default:
+		return postgres_db.AlertRule{}, fmt.Errorf("%w: unknown kind", ErrInvalidRule)
+	}
+
+	rule, err := s.Db.InsertAlertRuleFromDB(ctx, userId, rule)
+	if errors.Is(err, sql.ErrNoRows) {
+		return postgres_db.AlertRule{}, ErrItemNotTracked
+	}
+	return rule, err
+}
+
+func (s *Service) AlertRules(ctx context.Context, userId, itemId string) ([]postgres_db.AlertRule, error) {
+
+	return s.Db.SelectAlertRulesFromDB(ctx, userId, itemId)
+
+}
+
+func (s *Service) DeleteAlertRule(ctx context.Context, userId, id string) error {
+	err := s.Db.DeleteAlertRuleFromDB(ctx, userId, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrRuleNotFound
+	}
+	return err
+}
+
+const defaultPendingAlerts = 100
+
+// PendingAlerts returns the alert events that still have to be delivered to
+// the users, oldest first.
+func (s *Service) PendingAlerts(ctx context.Context, limit int) ([]postgres_db.AlertEvent, error) {
+	if limit <= 0 {
+		limit = defaultPendingAlerts
+	}
+
+	return s.Db.SelectPendingAlertEventsFromDB(ctx, limit)
+}
+
+// AckAlerts marks events as delivered so they are not returned by PendingAlerts again.
+func (s *Service) AckAlerts(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return s.Db.MarkAlertEventsDeliveredFromDB(ctx, ids)
+}
+
+// Triggered reports whether rule fires for the transition from previous to
+// current. Rules fire when their condition becomes true, not on every check
+// while it holds, so a user is told about a price reaching the target once.
+// Price conditions are never met by out of stock observations or by prices in
+// another currency than the one they are stated in.
+func Triggered(rule postgres_db.WatchedRule, previous *postgres_db.PricePoint, current postgres_db.PricePoint) bool {
+	inStock := func(p *postgres_db.PricePoint) bool {
+		return p != nil && parser.Stock(p.Stock) != parser.StockOutOfStock
+	}
+
+	var holds func(p *postgres_db.PricePoint) bool
+	switch AlertKind(rule.Kind) {
+	case AlertTargetPrice:
+		holds = func(p *postgres_db.PricePoint) bool {
+			if !inStock(p) {
+				return false
+			}
+			cmp, err := p.Price.Cmp(rule.TargetPrice)
+			return err == nil && cmp <= 0
+		}
+	case AlertPercentDrop:
+		// Compare price/start <= 1 - percent/100 in integers, with the
+		// percent in hundredths as stored.
+		bp := int64(math.Round(float64(rule.DropPercent) * 100))
+		holds = func(p *postgres_db.PricePoint) bool {
+			return inStock(p) && p.Price.Currency == rule.StartPrice.Currency &&
+				p.Price.Amount*10000 <= rule.StartPrice.Amount*(10000-bp)
+		}
+	case AlertAnyDrop:
+		if !inStock(previous) || !inStock(&current) {
+			return false
+		}
+		cmp, err := current.Price.Cmp(previous.Price)
+		return err == nil && cmp < 0
+	case AlertBackInStock:
+		return previous != nil && parser.Stock(previous.Stock) == parser.StockOutOfStock &&
+			parser.Stock(current.Stock) == parser.StockInStock
+	default:
+		return false
+	}
+
+	return holds(&current) && !holds(previous)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	postgres_db "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/parser"
+)
+
+func TestTriggered(t *testing.T) {
+	in := int16(parser.StockInStock)
+	out := int16(parser.StockOutOfStock)
+	point := func(price int64, stock int16) *postgres_db.PricePoint {
+		return &postgres_db.PricePoint{Price: money.New(price, "RUB"), Stock: stock}
+	}
+	rule := func(kind AlertKind, target int64, percent float32) postgres_db.WatchedRule {
+		r := postgres_db.WatchedRule{
+			AlertRule:  postgres_db.AlertRule{Kind: int16(kind), DropPercent: percent},
+			StartPrice: money.New(10000, "RUB"),
+		}
+		if target != 0 {
+			r.TargetPrice = money.New(target, "RUB")
+		}
+		return r
+	}
+	usd := func(p *postgres_db.PricePoint) *postgres_db.PricePoint {
+		p.Price.Currency = "USD"
+		return p
+	}
+
+	for name, tc := range map[string]struct {
+		rule     postgres_db.WatchedRule
+		previous *postgres_db.PricePoint
+		current  *postgres_db.PricePoint
+		want     bool
+	}{
+		"target reached":              {rule(AlertTargetPrice, 9000, 0), point(9500, in), point(9000, in), true},
+		"target already reached":      {rule(AlertTargetPrice, 9000, 0), point(8500, in), point(8000, in), false},
+		"target not reached":          {rule(AlertTargetPrice, 9000, 0), point(9900, in), point(9500, in), false},
+		"target on first check":       {rule(AlertTargetPrice, 9000, 0), nil, point(8000, in), true},
+		"target while out of stock":   {rule(AlertTargetPrice, 9000, 0), point(9500, in), point(8000, out), false},
+		"target after restock":        {rule(AlertTargetPrice, 9000, 0), point(8000, out), point(8000, in), true},
+		"percent drop reached":        {rule(AlertPercentDrop, 0, 10), point(9500, in), point(9000, in), true},
+		"percent drop not reached":    {rule(AlertPercentDrop, 0, 10), point(9500, in), point(9100, in), false},
+		"percent drop exact":          {rule(AlertPercentDrop, 0, 12.5), point(8751, in), point(8750, in), true},
+		"percent drop other currency": {rule(AlertPercentDrop, 0, 10), point(9500, in), usd(point(1000, in)), false},
+		"target other currency":       {rule(AlertTargetPrice, 9000, 0), point(9500, in), usd(point(1000, in)), false},
+		"any drop currency change":    {rule(AlertAnyDrop, 0, 0), point(9500, in), usd(point(1000, in)), false},
+		"any drop":                    {rule(AlertAnyDrop, 0, 0), point(9500, in), point(9400, in), true},
+		"price rise":                  {rule(AlertAnyDrop, 0, 0), point(9500, in), point(9600, in), false},
+		"any drop on first check":     {rule(AlertAnyDrop, 0, 0), nil, point(9400, in), false},
+		"back in stock":               {rule(AlertBackInStock, 0, 0), point(9500, out), point(9500, in), true},
+		"still in stock":              {rule(AlertBackInStock, 0, 0), point(9500, in), point(9500, in), false},
+		"unknown stock is not stock":  {rule(AlertBackInStock, 0, 0), point(9500, out), point(9500, 0), false},
+		"unspecified kind":            {rule(AlertUnspecified, 0, 0), point(9500, in), point(1000, in), false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Triggered(tc.rule, tc.previous, *tc.current))
+		})
+	}
+}
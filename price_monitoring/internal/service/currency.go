@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/rates"
+)
+
+// DisplayCurrency returns the currency the user wants to see prices in,
+// money.DefaultCurrency if they have not chosen one.
+func (s *Service) DisplayCurrency(ctx context.Context, userId string) (string, error) {
+	currency, err := s.Db.SelectDisplayCurrencyFromDB(ctx, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return money.DefaultCurrency, nil
+	}
+	return currency, err
+}
+
+// SetDisplayCurrency stores the currency the user wants to see prices in and
+// returns it normalized to upper case.
+func (s *Service) SetDisplayCurrency(ctx context.Context, userId, currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if !money.ValidCurrency(currency) {
+		return "", fmt.Errorf("%w: %q", money.ErrInvalidCurrency, currency)
+	}
+
+	return currency, s.Db.UpsertDisplayCurrencyFromDB(ctx, userId, currency)
+}
+
+// Convert returns m in currency at the current exchange rate. Without a rate
+// provider only amounts already in currency can be converted.
+func (s *Service) Convert(ctx context.Context, m money.Money, currency string) (money.Money, error) {
+	return rates.Convert(ctx, s.Rates, m, currency)
+}
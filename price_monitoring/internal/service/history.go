@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	postgres_db "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
+)
+
+const defaultHistoryRange = 30 * 24 * time.Hour
+
+var (
+	ErrItemNotTracked = errors.New("this user does not track the link")
+	ErrInvalidRange   = errors.New("history range start is after its end")
+)
+
+type Aggregation int
+
+const (
+	AggregationLast Aggregation = iota
+	AggregationMin
+	AggregationMax
+	AggregationAvg
+)
+
+type HistoryQuery struct {
+	UserId      string
+	Link        string
+	From        time.Time
+	To          time.Time
+	Step        time.Duration
+	MaxPoints   int
+	Aggregation Aggregation
+}
+
+func (s *Service) PriceHistory(ctx context.Context, query HistoryQuery) ([]postgres_db.PricePoint, error) {
+	tracked, err := s.Db.SubscribedFromDB(ctx, query.UserId, query.Link)
+	if err != nil {
+		return nil, err
+	}
+	if !tracked {
+		return nil, ErrItemNotTracked
+	}
+
+	if query.To.IsZero() {
+		query.To = time.Now()
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-defaultHistoryRange)
+	}
+	if query.From.After(query.To) {
+		return nil, ErrInvalidRange
+	}
+
+	points, err := s.Db.SelectPriceHistoryFromDB(ctx, query.Link, query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	step := query.Step
+	if step <= 0 && query.MaxPoints > 0 {
+		span := query.To.Sub(query.From)
+		step = (span + time.Duration(query.MaxPoints) - 1) / time.Duration(query.MaxPoints)
+	}
+
+	return Downsample(points, query.From, step, query.Aggregation), nil
+}
+
+// Downsample groups chronologically ordered points into buckets of width step
+// starting at from. Every bucket is reported at its start time with the price
+// aggregated by agg and the stock status of its latest observation. Prices
+// are aggregated exactly, averages rounded half away from zero; when the
+// currency changes within a bucket, only the prices in the new one count.
+// A non-positive step returns the points unchanged.
+func Downsample(points []postgres_db.PricePoint, from time.Time, step time.Duration, agg Aggregation) []postgres_db.PricePoint {
+	if step <= 0 || len(points) == 0 {
+		return points
+	}
+
+	var (
+		result  []postgres_db.PricePoint
+		current int64 = -1
+		sum     int64
+		count   int64
+	)
+
+	for _, p := range points {
+		bucket := int64(p.ObservedAt.Sub(from) / step)
+		if bucket != current || p.Price.Currency != result[len(result)-1].Price.Currency {
+			if bucket != current {
+				result = append(result, postgres_db.PricePoint{
+					Stock:      p.Stock,
+					ObservedAt: from.Add(time.Duration(bucket) * step),
+				})
+			}
+			result[len(result)-1].Price = p.Price
+			current, sum, count = bucket, 0, 0
+		}
+
+		last := &result[len(result)-1]
+		last.Stock = p.Stock
+		sum += p.Price.Amount
+		count++
+
+		switch agg {
+		case AggregationMin:
+			last.Price.Amount = min(last.Price.Amount, p.Price.Amount)
+		case AggregationMax:
+			last.Price.Amount = max(last.Price.Amount, p.Price.Amount)
+		case AggregationAvg:
+			last.Price.Amount = roundDiv(sum, count)
+		default:
+			last.Price = p.Price
+		}
+	}
+
+	return result
+}
+
+// roundDiv returns a/b rounded half away from zero, b > 0.
+func roundDiv(a, b int64) int64 {
+	if a < 0 {
+		return -roundDiv(-a, b)
+	}
+	return (2*a + b) / (2 * b)
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	postgres_db "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db/mocks"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+)
+
+func TestDownsample(t *testing.T) {
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return from.Add(time.Duration(h) * time.Hour) }
+	rub := func(amount int64) money.Money { return money.New(amount, "RUB") }
+
+	points := []postgres_db.PricePoint{
+		{Price: rub(10000), Stock: 1, ObservedAt: at(1)},
+		{Price: rub(8002), Stock: 1, ObservedAt: at(5)},
+		{Price: rub(9000), Stock: 2, ObservedAt: at(20)},
+		{Price: rub(12000), Stock: 1, ObservedAt: at(50)},
+	}
+
+	t.Run("no step returns raw points", func(t *testing.T) {
+		assert.Equal(t, points, Downsample(points, from, 0, AggregationMin))
+	})
+
+	day := 24 * time.Hour
+	for name, tc := range map[string]struct {
+		agg  Aggregation
+		want []money.Money
+	}{
+		"last": {AggregationLast, []money.Money{rub(9000), rub(12000)}},
+		"min":  {AggregationMin, []money.Money{rub(8002), rub(12000)}},
+		"max":  {AggregationMax, []money.Money{rub(10000), rub(12000)}},
+		"avg":  {AggregationAvg, []money.Money{rub(9001), rub(12000)}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got := Downsample(points, from, day, tc.agg)
+
+			assert.Len(t, got, 2)
+			assert.Equal(t, tc.want, []money.Money{got[0].Price, got[1].Price})
+			assert.Equal(t, from, got[0].ObservedAt)
+			assert.Equal(t, from.Add(2*day), got[1].ObservedAt)
+			assert.Equal(t, int16(2), got[0].Stock)
+		})
+	}
+
+	t.Run("currency change starts over", func(t *testing.T) {
+		got := Downsample([]postgres_db.PricePoint{
+			{Price: rub(10000), ObservedAt: at(1)},
+			{Price: money.New(150, "USD"), ObservedAt: at(2)},
+			{Price: money.New(100, "USD"), ObservedAt: at(3)},
+		}, from, day, AggregationAvg)
+
+		assert.Len(t, got, 1)
+		assert.Equal(t, money.New(125, "USD"), got[0].Price)
+	})
+}
+
+func TestPriceHistory(t *testing.T) {
+	to := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("item is not tracked", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		repo.EXPECT().SubscribedFromDB(mock.Anything, "123", "link").Return(false, nil)
+
+		_, err := NewService(repo, nil, nil, nil, nil).PriceHistory(context.Background(), HistoryQuery{UserId: "123", Link: "link"})
+		assert.ErrorIs(t, err, ErrItemNotTracked)
+	})
+
+	t.Run("defaults to the last month", func(t *testing.T) {
+		points := []postgres_db.PricePoint{{Price: money.New(100, "RUB"), Stock: 1, ObservedAt: to.Add(-time.Hour)}}
+
+		repo := mocks.NewRepository(t)
+		repo.EXPECT().SubscribedFromDB(mock.Anything, "123", "link").Return(true, nil)
+		repo.EXPECT().SelectPriceHistoryFromDB(mock.Anything, "link", to.Add(-defaultHistoryRange), to).Return(points, nil)
+
+		got, err := NewService(repo, nil, nil, nil, nil).PriceHistory(context.Background(), HistoryQuery{UserId: "123", Link: "link", To: to})
+		assert.NoError(t, err)
+		assert.Equal(t, points, got)
+	})
+}
@@ -0,0 +1,1281 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	money "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
+	parser "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/parser"
+
+	postgres_db "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
+
+	scraper "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/scraper"
+
+	service "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/service"
+)
+
+// ServiceManager is an autogenerated mock type for the ServiceManager type
+type ServiceManager struct {
+	mock.Mock
+}
+
+type ServiceManager_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ServiceManager) EXPECT() *ServiceManager_Expecter {
+	return &ServiceManager_Expecter{mock: &_m.Mock}
+}
+
+// AckAlerts provides a mock function with given fields: ctx, ids
+func (_m *ServiceManager) AckAlerts(ctx context.Context, ids []string) error {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AckAlerts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ServiceManager_AckAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AckAlerts'
+type ServiceManager_AckAlerts_Call struct {
+	*mock.Call
+}
+
+// AckAlerts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+func (_e *ServiceManager_Expecter) AckAlerts(ctx interface{}, ids interface{}) *ServiceManager_AckAlerts_Call {
+	return &ServiceManager_AckAlerts_Call{Call: _e.mock.On("AckAlerts", ctx, ids)}
+}
+
+func (_c *ServiceManager_AckAlerts_Call) Run(run func(ctx context.Context, ids []string)) *ServiceManager_AckAlerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_AckAlerts_Call) Return(_a0 error) *ServiceManager_AckAlerts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ServiceManager_AckAlerts_Call) RunAndReturn(run func(context.Context, []string) error) *ServiceManager_AckAlerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AlertRules provides a mock function with given fields: ctx, userId, itemId
+func (_m *ServiceManager) AlertRules(ctx context.Context, userId string, itemId string) ([]postgres_db.AlertRule, error) {
+	ret := _m.Called(ctx, userId, itemId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AlertRules")
+	}
+
+	var r0 []postgres_db.AlertRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]postgres_db.AlertRule, error)); ok {
+		return rf(ctx, userId, itemId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []postgres_db.AlertRule); ok {
+		r0 = rf(ctx, userId, itemId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]postgres_db.AlertRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, itemId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceManager_AlertRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AlertRules'
+type ServiceManager_AlertRules_Call struct {
+	*mock.Call
+}
+
+// AlertRules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - itemId string
+func (_e *ServiceManager_Expecter) AlertRules(ctx interface{}, userId interface{}, itemId interface{}) *ServiceManager_AlertRules_Call {
+	return &ServiceManager_AlertRules_Call{Call: _e.mock.On("AlertRules", ctx, userId, itemId)}
+}
+
+func (_c *ServiceManager_AlertRules_Call) Run(run func(ctx context.Context, userId string, itemId string)) *ServiceManager_AlertRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_AlertRules_Call) Return(_a0 []postgres_db.AlertRule, _a1 error) *ServiceManager_AlertRules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ServiceManager_AlertRules_Call) RunAndReturn(run func(context.Context, string, string) ([]postgres_db.AlertRule, error)) *ServiceManager_AlertRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CanonicalLink provides a mock function with given fields: ctx, link
+func (_m *ServiceManager) CanonicalLink(ctx context.Context, link string) (string, error) {
+	ret := _m.Called(ctx, link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CanonicalLink")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, link)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, link)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceManager_CanonicalLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CanonicalLink'
+type ServiceManager_CanonicalLink_Call struct {
+	*mock.Call
+}
+
+// CanonicalLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - link string
+func (_e *ServiceManager_Expecter) CanonicalLink(ctx interface{}, link interface{}) *ServiceManager_CanonicalLink_Call {
+	return &ServiceManager_CanonicalLink_Call{Call: _e.mock.On("CanonicalLink", ctx, link)}
+}
+
+func (_c *ServiceManager_CanonicalLink_Call) Run(run func(ctx context.Context, link string)) *ServiceManager_CanonicalLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_CanonicalLink_Call) Return(_a0 string, _a1 error) *ServiceManager_CanonicalLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ServiceManager_CanonicalLink_Call) RunAndReturn(run func(context.Context, string) (string, error)) *ServiceManager_CanonicalLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Convert provides a mock function with given fields: ctx, m, currency
+func (_m *ServiceManager) Convert(ctx context.Context, m money.Money, currency string) (money.Money, error) {
+	ret := _m.Called(ctx, m, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Convert")
+	}
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, money.Money, string) (money.Money, error)); ok {
+		return rf(ctx, m, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, money.Money, string) money.Money); ok {
+		r0 = rf(ctx, m, currency)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, money.Money, string) error); ok {
+		r1 = rf(ctx, m, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceManager_Convert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Convert'
+type ServiceManager_Convert_Call struct {
+	*mock.Call
+}
+
+// Convert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - m money.Money
+//   - currency string
+func (_e *ServiceManager_Expecter) Convert(ctx interface{}, m interface{}, currency interface{}) *ServiceManager_Convert_Call {
+	return &ServiceManager_Convert_Call{Call: _e.mock.On("Convert", ctx, m, currency)}
+}
+
+func (_c *ServiceManager_Convert_Call) Run(run func(ctx context.Context, m money.Money, currency string)) *ServiceManager_Convert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(money.Money), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_Convert_Call) Return(_a0 money.Money, _a1 error) *ServiceManager_Convert_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ServiceManager_Convert_Call) RunAndReturn(run func(context.Context, money.Money, string) (money.Money, error)) *ServiceManager_Convert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAlertRule provides a mock function with given fields: ctx, userId, rule
+func (_m *ServiceManager) CreateAlertRule(ctx context.Context, userId string, rule postgres_db.AlertRule) (postgres_db.AlertRule, error) {
+	ret := _m.Called(ctx, userId, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAlertRule")
+	}
+
+	var r0 postgres_db.AlertRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, postgres_db.AlertRule) (postgres_db.AlertRule, error)); ok {
+		return rf(ctx, userId, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, postgres_db.AlertRule) postgres_db.AlertRule); ok {
+		r0 = rf(ctx, userId, rule)
+	} else {
+		r0 = ret.Get(0).(postgres_db.AlertRule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, postgres_db.AlertRule) error); ok {
+		r1 = rf(ctx, userId, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceManager_CreateAlertRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAlertRule'
+type ServiceManager_CreateAlertRule_Call struct {
+	*mock.Call
+}
+
+// CreateAlertRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - rule postgres_db.AlertRule
+func (_e *ServiceManager_Expecter) CreateAlertRule(ctx interface{}, userId interface{}, rule interface{}) *ServiceManager_CreateAlertRule_Call {
+	return &ServiceManager_CreateAlertRule_Call{Call: _e.mock.On("CreateAlertRule", ctx, userId, rule)}
+}
+
+func (_c *ServiceManager_CreateAlertRule_Call) Run(run func(ctx context.Context, userId string, rule postgres_db.AlertRule)) *ServiceManager_CreateAlertRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(postgres_db.AlertRule))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_CreateAlertRule_Call) Return(_a0 postgres_db.AlertRule, _a1 error) *ServiceManager_CreateAlertRule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ServiceManager_CreateAlertRule_Call) RunAndReturn(run func(context.Context, string, postgres_db.AlertRule) (postgres_db.AlertRule, error)) *ServiceManager_CreateAlertRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, userId, rawURL
+func (_m *ServiceManager) CreateWebhook(ctx context.Context, userId string, rawURL string) (postgres_db.Webhook, error) {
+	ret := _m.Called(ctx, userId, rawURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhook")
+	}
+
+	var r0 postgres_db.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (postgres_db.Webhook, error)); ok {
+		return rf(ctx, userId, rawURL)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) postgres_db.Webhook); ok {
+		r0 = rf(ctx, userId, rawURL)
+	} else {
+		r0 = ret.Get(0).(postgres_db.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, rawURL)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceManager_CreateWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhook'
+type ServiceManager_CreateWebhook_Call struct {
+	*mock.Call
+}
+
+// CreateWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - rawURL string
+func (_e *ServiceManager_Expecter) CreateWebhook(ctx interface{}, userId interface{}, rawURL interface{}) *ServiceManager_CreateWebhook_Call {
+	return &ServiceManager_CreateWebhook_Call{Call: _e.mock.On("CreateWebhook", ctx, userId, rawURL)}
+}
+
+func (_c *ServiceManager_CreateWebhook_Call) Run(run func(ctx context.Context, userId string, rawURL string)) *ServiceManager_CreateWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_CreateWebhook_Call) Return(_a0 postgres_db.Webhook, _a1 error) *ServiceManager_CreateWebhook_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ServiceManager_CreateWebhook_Call) RunAndReturn(run func(context.Context, string, string) (postgres_db.Webhook, error)) *ServiceManager_CreateWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAlertRule provides a mock function with given fields: ctx, userId, id
+func (_m *ServiceManager) DeleteAlertRule(ctx context.Context, userId string, id string) error {
+	ret := _m.Called(ctx, userId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAlertRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ServiceManager_DeleteAlertRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAlertRule'
+type ServiceManager_DeleteAlertRule_Call struct {
+	*mock.Call
+}
+
+// DeleteAlertRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - id string
+func (_e *ServiceManager_Expecter) DeleteAlertRule(ctx interface{}, userId interface{}, id interface{}) *ServiceManager_DeleteAlertRule_Call {
+	return &ServiceManager_DeleteAlertRule_Call{Call: _e.mock.On("DeleteAlertRule", ctx, userId, id)}
+}
+
+func (_c *ServiceManager_DeleteAlertRule_Call) Run(run func(ctx context.Context, userId string, id string)) *ServiceManager_DeleteAlertRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_DeleteAlertRule_Call) Return(_a0 error) *ServiceManager_DeleteAlertRule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ServiceManager_DeleteAlertRule_Call) RunAndReturn(run func(context.Context, string, string) error) *ServiceManager_DeleteAlertRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, userId, id
+func (_m *ServiceManager) DeleteWebhook(ctx context.Context, userId string, id string) error {
+	ret := _m.Called(ctx, userId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ServiceManager_DeleteWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhook'
+type ServiceManager_DeleteWebhook_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - id string
+func (_e *ServiceManager_Expecter) DeleteWebhook(ctx interface{}, userId interface{}, id interface{}) *ServiceManager_DeleteWebhook_Call {
+	return &ServiceManager_DeleteWebhook_Call{Call: _e.mock.On("DeleteWebhook", ctx, userId, id)}
+}
+
+func (_c *ServiceManager_DeleteWebhook_Call) Run(run func(ctx context.Context, userId string, id string)) *ServiceManager_DeleteWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_DeleteWebhook_Call) Return(_a0 error) *ServiceManager_DeleteWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ServiceManager_DeleteWebhook_Call) RunAndReturn(run func(context.Context, string, string) error) *ServiceManager_DeleteWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DisplayCurrency provides a mock function with given fields: ctx, userId
+func (_m *ServiceManager) DisplayCurrency(ctx context.Context, userId string) (string, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisplayCurrency")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceManager_DisplayCurrency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisplayCurrency'
+type ServiceManager_DisplayCurrency_Call struct {
+	*mock.Call
+}
+
+// DisplayCurrency is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+func (_e *ServiceManager_Expecter) DisplayCurrency(ctx interface{}, userId interface{}) *ServiceManager_DisplayCurrency_Call {
+	return &ServiceManager_DisplayCurrency_Call{Call: _e.mock.On("DisplayCurrency", ctx, userId)}
+}
+
+func (_c *ServiceManager_DisplayCurrency_Call) Run(run func(ctx context.Context, userId string)) *ServiceManager_DisplayCurrency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_DisplayCurrency_Call) Return(_a0 string, _a1 error) *ServiceManager_DisplayCurrency_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ServiceManager_DisplayCurrency_Call) RunAndReturn(run func(context.Context, string) (string, error)) *ServiceManager_DisplayCurrency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertItem provides a mock function with given fields: ctx, userId, link, variantId, name, price
+func (_m *ServiceManager) InsertItem(ctx context.Context, userId string, link string, variantId string, name string, price money.Money) (string, error) {
+	ret := _m.Called(ctx, userId, link, variantId, name, price)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertItem")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, money.Money) (string, error)); ok {
+		return rf(ctx, userId, link, variantId, name, price)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, money.Money) string); ok {
+		r0 = rf(ctx, userId, link, variantId, name, price)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, money.Money) error); ok {
+		r1 = rf(ctx, userId, link, variantId, name, price)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceManager_InsertItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertItem'
+type ServiceManager_InsertItem_Call struct {
+	*mock.Call
+}
+
+// InsertItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - link string
+//   - variantId string
+//   - name string
+//   - price money.Money
+func (_e *ServiceManager_Expecter) InsertItem(ctx interface{}, userId interface{}, link interface{}, variantId interface{}, name interface{}, price interface{}) *ServiceManager_InsertItem_Call {
+	return &ServiceManager_InsertItem_Call{Call: _e.mock.On("InsertItem", ctx, userId, link, variantId, name, price)}
+}
+
+func (_c *ServiceManager_InsertItem_Call) Run(run func(ctx context.Context, userId string, link string, variantId string, name string, price money.Money)) *ServiceManager_InsertItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string), args[5].(money.Money))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_InsertItem_Call) Return(_a0 string, _a1 error) *ServiceManager_InsertItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ServiceManager_InsertItem_Call) RunAndReturn(run func(context.Context, string, string, string, string, money.Money) (string, error)) *ServiceManager_InsertItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ParserItem provides a mock function with given fields: ctx, link
+func (_m *ServiceManager) ParserItem(ctx context.Context, link string) (*parser.Product, error) {
+	ret := _m.Called(ctx, link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParserItem")
+	}
+
+	var r0 *parser.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*parser.Product, error)); ok {
+		return rf(ctx, link)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *parser.Product); ok {
+		r0 = rf(ctx, link)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*parser.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceManager_ParserItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ParserItem'
+type ServiceManager_ParserItem_Call struct {
+	*mock.Call
+}
+
+// ParserItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - link string
+func (_e *ServiceManager_Expecter) ParserItem(ctx interface{}, link interface{}) *ServiceManager_ParserItem_Call {
+	return &ServiceManager_ParserItem_Call{Call: _e.mock.On("ParserItem", ctx, link)}
+}
+
+func (_c *ServiceManager_ParserItem_Call) Run(run func(ctx context.Context, link string)) *ServiceManager_ParserItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_ParserItem_Call) Return(_a0 *parser.Product, _a1 error) *ServiceManager_ParserItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ServiceManager_ParserItem_Call) RunAndReturn(run func(context.Context, string) (*parser.Product, error)) *ServiceManager_ParserItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PendingAlerts provides a mock function with given fields: ctx, limit
+func (_m *ServiceManager) PendingAlerts(ctx context.Context, limit int) ([]postgres_db.AlertEvent, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PendingAlerts")
+	}
+
+	var r0 []postgres_db.AlertEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]postgres_db.AlertEvent, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []postgres_db.AlertEvent); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]postgres_db.AlertEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceManager_PendingAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PendingAlerts'
+type ServiceManager_PendingAlerts_Call struct {
+	*mock.Call
+}
+
+// PendingAlerts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *ServiceManager_Expecter) PendingAlerts(ctx interface{}, limit interface{}) *ServiceManager_PendingAlerts_Call {
+	return &ServiceManager_PendingAlerts_Call{Call: _e.mock.On("PendingAlerts", ctx, limit)}
+}
+
+func (_c *ServiceManager_PendingAlerts_Call) Run(run func(ctx context.Context, limit int)) *ServiceManager_PendingAlerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_PendingAlerts_Call) Return(_a0 []postgres_db.AlertEvent, _a1 error) *ServiceManager_PendingAlerts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ServiceManager_PendingAlerts_Call) RunAndReturn(run func(context.Context, int) ([]postgres_db.AlertEvent, error)) *ServiceManager_PendingAlerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PriceHistory provides a mock function with given fields: ctx, query
+func (_m *ServiceManager) PriceHistory(ctx context.Context, query service.HistoryQuery) ([]postgres_db.PricePoint, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PriceHistory")
+	}
+
+	var r0 []postgres_db.PricePoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.HistoryQuery) ([]postgres_db.PricePoint, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.HistoryQuery) []postgres_db.PricePoint); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]postgres_db.PricePoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.HistoryQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceManager_PriceHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PriceHistory'
+type ServiceManager_PriceHistory_Call struct {
+	*mock.Call
+}
+
+// PriceHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query service.HistoryQuery
+func (_e *ServiceManager_Expecter) PriceHistory(ctx interface{}, query interface{}) *ServiceManager_PriceHistory_Call {
+	return &ServiceManager_PriceHistory_Call{Call: _e.mock.On("PriceHistory", ctx, query)}
+}
+
+func (_c *ServiceManager_PriceHistory_Call) Run(run func(ctx context.Context, query service.HistoryQuery)) *ServiceManager_PriceHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(service.HistoryQuery))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_PriceHistory_Call) Return(_a0 []postgres_db.PricePoint, _a1 error) *ServiceManager_PriceHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ServiceManager_PriceHistory_Call) RunAndReturn(run func(context.Context, service.HistoryQuery) ([]postgres_db.PricePoint, error)) *ServiceManager_PriceHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveItem provides a mock function with given fields: ctx, userId, id
+func (_m *ServiceManager) RemoveItem(ctx context.Context, userId string, id string) error {
+	ret := _m.Called(ctx, userId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ServiceManager_RemoveItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveItem'
+type ServiceManager_RemoveItem_Call struct {
+	*mock.Call
+}
+
+// RemoveItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - id string
+func (_e *ServiceManager_Expecter) RemoveItem(ctx interface{}, userId interface{}, id interface{}) *ServiceManager_RemoveItem_Call {
+	return &ServiceManager_RemoveItem_Call{Call: _e.mock.On("RemoveItem", ctx, userId, id)}
+}
+
+func (_c *ServiceManager_RemoveItem_Call) Run(run func(ctx context.Context, userId string, id string)) *ServiceManager_RemoveItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_RemoveItem_Call) Return(_a0 error) *ServiceManager_RemoveItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ServiceManager_RemoveItem_Call) RunAndReturn(run func(context.Context, string, string) error) *ServiceManager_RemoveItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplayOutboxEvents provides a mock function with given fields: ctx, ids
+func (_m *ServiceManager) ReplayOutboxEvents(ctx context.Context, ids []string) (int64, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayOutboxEvents")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (int64, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) int64); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceManager_ReplayOutboxEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplayOutboxEvents'
+type ServiceManager_ReplayOutboxEvents_Call struct {
+	*mock.Call
+}
+
+// ReplayOutboxEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+func (_e *ServiceManager_Expecter) ReplayOutboxEvents(ctx interface{}, ids interface{}) *ServiceManager_ReplayOutboxEvents_Call {
+	return &ServiceManager_ReplayOutboxEvents_Call{Call: _e.mock.On("ReplayOutboxEvents", ctx, ids)}
+}
+
+func (_c *ServiceManager_ReplayOutboxEvents_Call) Run(run func(ctx context.Context, ids []string)) *ServiceManager_ReplayOutboxEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_ReplayOutboxEvents_Call) Return(_a0 int64, _a1 error) *ServiceManager_ReplayOutboxEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ServiceManager_ReplayOutboxEvents_Call) RunAndReturn(run func(context.Context, []string) (int64, error)) *ServiceManager_ReplayOutboxEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectItem provides a mock function with given fields: ctx, userId, link
+func (_m *ServiceManager) SelectItem(ctx context.Context, userId string, link string) (postgres_db.Subscription, error) {
+	ret := _m.Called(ctx, userId, link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectItem")
+	}
+
+	var r0 postgres_db.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (postgres_db.Subscription, error)); ok {
+		return rf(ctx, userId, link)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) postgres_db.Subscription); ok {
+		r0 = rf(ctx, userId, link)
+	} else {
+		r0 = ret.Get(0).(postgres_db.Subscription)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceManager_SelectItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectItem'
+type ServiceManager_SelectItem_Call struct {
+	*mock.Call
+}
+
+// SelectItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - link string
+func (_e *ServiceManager_Expecter) SelectItem(ctx interface{}, userId interface{}, link interface{}) *ServiceManager_SelectItem_Call {
+	return &ServiceManager_SelectItem_Call{Call: _e.mock.On("SelectItem", ctx, userId, link)}
+}
+
+func (_c *ServiceManager_SelectItem_Call) Run(run func(ctx context.Context, userId string, link string)) *ServiceManager_SelectItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_SelectItem_Call) Return(_a0 postgres_db.Subscription, _a1 error) *ServiceManager_SelectItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ServiceManager_SelectItem_Call) RunAndReturn(run func(context.Context, string, string) (postgres_db.Subscription, error)) *ServiceManager_SelectItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectItems provides a mock function with given fields: ctx, userId, page
+func (_m *ServiceManager) SelectItems(ctx context.Context, userId string, page postgres_db.Page) ([]postgres_db.Subscription, error) {
+	ret := _m.Called(ctx, userId, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectItems")
+	}
+
+	var r0 []postgres_db.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, postgres_db.Page) ([]postgres_db.Subscription, error)); ok {
+		return rf(ctx, userId, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, postgres_db.Page) []postgres_db.Subscription); ok {
+		r0 = rf(ctx, userId, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]postgres_db.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, postgres_db.Page) error); ok {
+		r1 = rf(ctx, userId, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceManager_SelectItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectItems'
+type ServiceManager_SelectItems_Call struct {
+	*mock.Call
+}
+
+// SelectItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - page postgres_db.Page
+func (_e *ServiceManager_Expecter) SelectItems(ctx interface{}, userId interface{}, page interface{}) *ServiceManager_SelectItems_Call {
+	return &ServiceManager_SelectItems_Call{Call: _e.mock.On("SelectItems", ctx, userId, page)}
+}
+
+func (_c *ServiceManager_SelectItems_Call) Run(run func(ctx context.Context, userId string, page postgres_db.Page)) *ServiceManager_SelectItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(postgres_db.Page))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_SelectItems_Call) Return(_a0 []postgres_db.Subscription, _a1 error) *ServiceManager_SelectItems_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ServiceManager_SelectItems_Call) RunAndReturn(run func(context.Context, string, postgres_db.Page) ([]postgres_db.Subscription, error)) *ServiceManager_SelectItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDisplayCurrency provides a mock function with given fields: ctx, userId, currency
+func (_m *ServiceManager) SetDisplayCurrency(ctx context.Context, userId string, currency string) (string, error) {
+	ret := _m.Called(ctx, userId, currency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDisplayCurrency")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, userId, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, userId, currency)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceManager_SetDisplayCurrency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDisplayCurrency'
+type ServiceManager_SetDisplayCurrency_Call struct {
+	*mock.Call
+}
+
+// SetDisplayCurrency is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - currency string
+func (_e *ServiceManager_Expecter) SetDisplayCurrency(ctx interface{}, userId interface{}, currency interface{}) *ServiceManager_SetDisplayCurrency_Call {
+	return &ServiceManager_SetDisplayCurrency_Call{Call: _e.mock.On("SetDisplayCurrency", ctx, userId, currency)}
+}
+
+func (_c *ServiceManager_SetDisplayCurrency_Call) Run(run func(ctx context.Context, userId string, currency string)) *ServiceManager_SetDisplayCurrency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_SetDisplayCurrency_Call) Return(_a0 string, _a1 error) *ServiceManager_SetDisplayCurrency_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ServiceManager_SetDisplayCurrency_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *ServiceManager_SetDisplayCurrency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSubscription provides a mock function with given fields: ctx, userId, id, update
+func (_m *ServiceManager) UpdateSubscription(ctx context.Context, userId string, id string, update postgres_db.SubscriptionUpdate) (postgres_db.Subscription, error) {
+	ret := _m.Called(ctx, userId, id, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSubscription")
+	}
+
+	var r0 postgres_db.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, postgres_db.SubscriptionUpdate) (postgres_db.Subscription, error)); ok {
+		return rf(ctx, userId, id, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, postgres_db.SubscriptionUpdate) postgres_db.Subscription); ok {
+		r0 = rf(ctx, userId, id, update)
+	} else {
+		r0 = ret.Get(0).(postgres_db.Subscription)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, postgres_db.SubscriptionUpdate) error); ok {
+		r1 = rf(ctx, userId, id, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceManager_UpdateSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSubscription'
+type ServiceManager_UpdateSubscription_Call struct {
+	*mock.Call
+}
+
+// UpdateSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - id string
+//   - update postgres_db.SubscriptionUpdate
+func (_e *ServiceManager_Expecter) UpdateSubscription(ctx interface{}, userId interface{}, id interface{}, update interface{}) *ServiceManager_UpdateSubscription_Call {
+	return &ServiceManager_UpdateSubscription_Call{Call: _e.mock.On("UpdateSubscription", ctx, userId, id, update)}
+}
+
+func (_c *ServiceManager_UpdateSubscription_Call) Run(run func(ctx context.Context, userId string, id string, update postgres_db.SubscriptionUpdate)) *ServiceManager_UpdateSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(postgres_db.SubscriptionUpdate))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_UpdateSubscription_Call) Return(_a0 postgres_db.Subscription, _a1 error) *ServiceManager_UpdateSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ServiceManager_UpdateSubscription_Call) RunAndReturn(run func(context.Context, string, string, postgres_db.SubscriptionUpdate) (postgres_db.Subscription, error)) *ServiceManager_UpdateSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpstreamHealth provides a mock function with given fields: ctx
+func (_m *ServiceManager) UpstreamHealth(ctx context.Context) []scraper.BreakerStatus {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpstreamHealth")
+	}
+
+	var r0 []scraper.BreakerStatus
+	if rf, ok := ret.Get(0).(func(context.Context) []scraper.BreakerStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]scraper.BreakerStatus)
+		}
+	}
+
+	return r0
+}
+
+// ServiceManager_UpstreamHealth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpstreamHealth'
+type ServiceManager_UpstreamHealth_Call struct {
+	*mock.Call
+}
+
+// UpstreamHealth is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ServiceManager_Expecter) UpstreamHealth(ctx interface{}) *ServiceManager_UpstreamHealth_Call {
+	return &ServiceManager_UpstreamHealth_Call{Call: _e.mock.On("UpstreamHealth", ctx)}
+}
+
+func (_c *ServiceManager_UpstreamHealth_Call) Run(run func(ctx context.Context)) *ServiceManager_UpstreamHealth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_UpstreamHealth_Call) Return(_a0 []scraper.BreakerStatus) *ServiceManager_UpstreamHealth_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ServiceManager_UpstreamHealth_Call) RunAndReturn(run func(context.Context) []scraper.BreakerStatus) *ServiceManager_UpstreamHealth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WebhookDeliveries provides a mock function with given fields: ctx, userId, id, limit
+func (_m *ServiceManager) WebhookDeliveries(ctx context.Context, userId string, id string, limit int) ([]postgres_db.WebhookDelivery, error) {
+	ret := _m.Called(ctx, userId, id, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookDeliveries")
+	}
+
+	var r0 []postgres_db.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) ([]postgres_db.WebhookDelivery, error)); ok {
+		return rf(ctx, userId, id, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) []postgres_db.WebhookDelivery); ok {
+		r0 = rf(ctx, userId, id, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]postgres_db.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, userId, id, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceManager_WebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebhookDeliveries'
+type ServiceManager_WebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// WebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - id string
+//   - limit int
+func (_e *ServiceManager_Expecter) WebhookDeliveries(ctx interface{}, userId interface{}, id interface{}, limit interface{}) *ServiceManager_WebhookDeliveries_Call {
+	return &ServiceManager_WebhookDeliveries_Call{Call: _e.mock.On("WebhookDeliveries", ctx, userId, id, limit)}
+}
+
+func (_c *ServiceManager_WebhookDeliveries_Call) Run(run func(ctx context.Context, userId string, id string, limit int)) *ServiceManager_WebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_WebhookDeliveries_Call) Return(_a0 []postgres_db.WebhookDelivery, _a1 error) *ServiceManager_WebhookDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ServiceManager_WebhookDeliveries_Call) RunAndReturn(run func(context.Context, string, string, int) ([]postgres_db.WebhookDelivery, error)) *ServiceManager_WebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Webhooks provides a mock function with given fields: ctx, userId
+func (_m *ServiceManager) Webhooks(ctx context.Context, userId string) ([]postgres_db.Webhook, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Webhooks")
+	}
+
+	var r0 []postgres_db.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]postgres_db.Webhook, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []postgres_db.Webhook); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]postgres_db.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceManager_Webhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Webhooks'
+type ServiceManager_Webhooks_Call struct {
+	*mock.Call
+}
+
+// Webhooks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+func (_e *ServiceManager_Expecter) Webhooks(ctx interface{}, userId interface{}) *ServiceManager_Webhooks_Call {
+	return &ServiceManager_Webhooks_Call{Call: _e.mock.On("Webhooks", ctx, userId)}
+}
+
+func (_c *ServiceManager_Webhooks_Call) Run(run func(ctx context.Context, userId string)) *ServiceManager_Webhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ServiceManager_Webhooks_Call) Return(_a0 []postgres_db.Webhook, _a1 error) *ServiceManager_Webhooks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ServiceManager_Webhooks_Call) RunAndReturn(run func(context.Context, string) ([]postgres_db.Webhook, error)) *ServiceManager_Webhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewServiceManager creates a new instance of ServiceManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewServiceManager(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ServiceManager {
+	mock := &ServiceManager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
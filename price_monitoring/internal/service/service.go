@@ -1,65 +1,203 @@
 package service
 
 import (
-	"database/sql"
+	"context"
+	"errors"
 	"fmt"
+	"log"
+	"time"
 
 	postgres_db "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/money"
 	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/parser"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/rates"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/scraper"
 )
 
+// ProductParser scrapes products, either *parser.Parser or a cache in front of it.
+type ProductParser interface {
+	Parse(ctx context.Context, link string) (*parser.Product, error)
+}
+
+// LinkCanonicalizer turns the links users paste into canonical product URLs.
+type LinkCanonicalizer interface {
+	Canonical(ctx context.Context, link string) (string, error)
+}
+
+// UpstreamMonitor reports the health of the upstream marketplaces.
+type UpstreamMonitor interface {
+	Breakers() []scraper.BreakerStatus
+}
+
 type Service struct {
-	Db postgres_db.DbService
+	Db        postgres_db.Repository
+	Parser    ProductParser
+	Links     LinkCanonicalizer
+	Upstreams UpstreamMonitor
+	Rates     rates.Provider
 }
 
 type ServiceManager interface {
-	ParserItem(link string) (string, string, float32, error)
-	SelectItem(link string) (string, float32, error)
-	UpdateItem(price float32, link string) error
-	InsertItem(userId, link, name string, price float32) error
-	SelectAllItems(userId string) (*sql.Rows, error)
+	CanonicalLink(ctx context.Context, link string) (string, error)
+	ParserItem(ctx context.Context, link string) (*parser.Product, error)
+	SelectItem(ctx context.Context, userId, link string) (postgres_db.Subscription, error)
+	InsertItem(ctx context.Context, userId, link, variantId, name string, price money.Money) (string, error)
+	SelectItems(ctx context.Context, userId string, page postgres_db.Page) ([]postgres_db.Subscription, error)
+	RemoveItem(ctx context.Context, userId, id string) error
+	UpdateSubscription(ctx context.Context, userId, id string, update postgres_db.SubscriptionUpdate) (postgres_db.Subscription, error)
+	PriceHistory(ctx context.Context, query HistoryQuery) ([]postgres_db.PricePoint, error)
+	CreateAlertRule(ctx context.Context, userId string, rule postgres_db.AlertRule) (postgres_db.AlertRule, error)
+	AlertRules(ctx context.Context, userId, itemId string) ([]postgres_db.AlertRule, error)
+	DeleteAlertRule(ctx context.Context, userId, id string) error
+	PendingAlerts(ctx context.Context, limit int) ([]postgres_db.AlertEvent, error)
+	AckAlerts(ctx context.Context, ids []string) error
+	ReplayOutboxEvents(ctx context.Context, ids []string) (int64, error)
+	CreateWebhook(ctx context.Context, userId, rawURL string) (postgres_db.Webhook, error)
+	Webhooks(ctx context.Context, userId string) ([]postgres_db.Webhook, error)
+	DeleteWebhook(ctx context.Context, userId, id string) error
+	WebhookDeliveries(ctx context.Context, userId, id string, limit int) ([]postgres_db.WebhookDelivery, error)
+	UpstreamHealth(ctx context.Context) []scraper.BreakerStatus
+	DisplayCurrency(ctx context.Context, userId string) (string, error)
+	SetDisplayCurrency(ctx context.Context, userId, currency string) (string, error)
+	Convert(ctx context.Context, m money.Money, currency string) (money.Money, error)
 }
 
-func NewService(db postgres_db.DbService) *Service {
+func NewService(db postgres_db.Repository, parser ProductParser, links LinkCanonicalizer, upstreams UpstreamMonitor, rates rates.Provider) *Service {
 	return &Service{
-		Db: db,
+		Db:        db,
+		Parser:    parser,
+		Links:     links,
+		Upstreams: upstreams,
+		Rates:     rates,
 	}
 }
 
-func (s *Service) ParserItem(link string) (string, string, float32, error) {
-	status := "Товар в наличии"
-	name, price, err := parser.Parser(link)
+// CanonicalLink returns the form of link the product is stored under, so that
+// every spelling of a link refers to the same product.
+func (s *Service) CanonicalLink(ctx context.Context, link string) (string, error) {
+	if s.Links == nil {
+		return link, nil
+	}
+
+	canonical, err := s.Links.Canonical(ctx, link)
 	if err != nil {
-		if err.Error() == "Товара нет в наличии" {
-			status = err.Error()
-		} else {
-			return "", "", 0, fmt.Errorf("cannot parse this link: %v", err)
+		return "", fmt.Errorf("cannot resolve this link: %w", err)
+	}
+	return canonical, nil
+}
+
+// ParserItem parses link, records the observed price in the price history and
+// checks the alert rules of the link against it. Products served from the
+// cache were recorded when they were scraped and are not recorded again.
+// Out of stock is not an error here: the product is returned with Stock set
+// to parser.StockOutOfStock.
+func (s *Service) ParserItem(ctx context.Context, link string) (*parser.Product, error) {
+	product, err := s.Parser.Parse(ctx, link)
+	if err != nil && !errors.Is(err, parser.ErrOutOfStock) {
+		return nil, fmt.Errorf("cannot parse this link: %w", err)
+	}
+
+	if product.FromCache {
+		return product, nil
+	}
+
+	if product.Strategy != parser.StrategyMarketplace {
+		log.Printf("parsed %s using %s markup", link, product.Strategy)
+	}
+
+	point := postgres_db.PricePoint{
+		Price:      storedPrice(product.Price),
+		Stock:      int16(product.Stock),
+		ObservedAt: time.Now(),
+	}
+
+	details := postgres_db.ProductDetails{
+		OriginalPrice: product.OriginalPrice,
+		Brand:         product.Brand,
+		Seller:        product.Seller,
+		Rating:        product.Rating,
+		Reviews:       product.Reviews,
+		Quantity:      product.Quantity,
+		ImageURL:      product.ImageURL,
+		Variants:      make([]postgres_db.Variant, len(product.Variants)),
+	}
+	for i, variant := range product.Variants {
+		details.Variants[i] = postgres_db.Variant{
+			Id:            variant.ID,
+			Name:          variant.Name,
+			Price:         storedPrice(variant.Price),
+			OriginalPrice: variant.OriginalPrice,
+			Stock:         int16(variant.Stock),
+			Quantity:      variant.Quantity,
 		}
 	}
 
-	return name, status, price, nil
+	err = s.Db.RecordObservationFromDB(ctx, link, product.Name, details, point, Triggered)
+	if err != nil {
+		log.Printf("cannot record price history for %s err: %v", link, err)
+	}
+
+	return product, nil
+}
+
+// storedPrice gives the zero price of out of stock products, which come
+// without one, the default currency the price columns require.
+func storedPrice(price money.Money) money.Money {
+	if price.Currency == "" {
+		price.Currency = money.DefaultCurrency
+	}
+	return price
 }
 
-func (s *Service) SelectItem(link string) (string, float32, error) {
+func (s *Service) SelectItem(ctx context.Context, userId, link string) (postgres_db.Subscription, error) {
 
-	return s.Db.SelectItemFromDB(link)
+	return s.Db.SelectSubscriptionFromDB(ctx, userId, link)
 
 }
 
-func (s *Service) UpdateItem(price float32, link string) error {
+func (s *Service) InsertItem(ctx context.Context, userId, link, variantId, name string, price money.Money) (string, error) {
 
-	return s.Db.UpdateItemFromDB(price, link)
+	return s.Db.InsertSubscriptionFromDB(ctx, userId, link, variantId, name, storedPrice(price))
 
 }
 
-func (s *Service) InsertItem(userId, link, name string, price float32) error {
+func (s *Service) SelectItems(ctx context.Context, userId string, page postgres_db.Page) ([]postgres_db.Subscription, error) {
+
+	return s.Db.SelectSubscriptionsFromDB(ctx, userId, page)
 
-	return s.Db.InsertItemFromDB(userId, link, name, price)
+}
+
+func (s *Service) TrackedLinks(ctx context.Context) ([]string, error) {
+
+	return s.Db.SelectTrackedLinksFromDB(ctx)
+
+}
+
+func (s *Service) RemoveItem(ctx context.Context, userId, id string) error {
+
+	return s.Db.DeleteSubscriptionFromDB(ctx, userId, id)
+
+}
+
+func (s *Service) UpdateSubscription(ctx context.Context, userId, id string, update postgres_db.SubscriptionUpdate) (postgres_db.Subscription, error) {
+
+	return s.Db.UpdateSubscriptionFromDB(ctx, userId, id, update)
+
+}
+
+// UpstreamHealth returns the circuit breaker state of every upstream marketplace.
+func (s *Service) UpstreamHealth(ctx context.Context) []scraper.BreakerStatus {
+	if s.Upstreams == nil {
+		return nil
+	}
 
+	return s.Upstreams.Breakers()
 }
 
-func (s *Service) SelectAllItems(userId string) (*sql.Rows, error) {
+// ReplayOutboxEvents gives dead outbox events another round of delivery
+// attempts. An empty ids replays all of them.
+func (s *Service) ReplayOutboxEvents(ctx context.Context, ids []string) (int64, error) {
 
-	return s.Db.SelectAllItemsFromDB(userId)
+	return s.Db.ReplayOutboxEventsFromDB(ctx, ids)
 
 }
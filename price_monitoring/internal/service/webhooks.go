@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+
+	postgres_db "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/netguard"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/webhook"
+)
+
+var (
+	ErrInvalidWebhook  = errors.New("invalid webhook")
+	ErrWebhookNotFound = errors.New("webhook not found")
+)
+
+const (
+	defaultWebhookDeliveries = 50
+	maxWebhookDeliveries     = 500
+)
+
+// CreateWebhook registers rawURL to receive the price changes and alerts of
+// the user's items. Hosts resolving to loopback or private addresses are
+// refused, the dispatcher checks the address again on every delivery. The
+// returned webhook carries the signing secret, it is not shown again.
+func (s *Service) CreateWebhook(ctx context.Context, userId, rawURL string) (postgres_db.Webhook, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return postgres_db.Webhook{}, fmt.Errorf("%w: url must be an absolute http or https url", ErrInvalidWebhook)
+	}
+	if err := netguard.CheckHost(ctx, u.Hostname()); err != nil {
+		return postgres_db.Webhook{}, fmt.Errorf("%w: %w", ErrInvalidWebhook, err)
+	}
+
+	return s.Db.InsertWebhookFromDB(ctx, postgres_db.Webhook{
+		UserId: userId,
+		URL:    u.String(),
+		Secret: webhook.NewSecret(),
+	})
+}
+
+func (s *Service) Webhooks(ctx context.Context, userId string) ([]postgres_db.Webhook, error) {
+
+	return s.Db.SelectWebhooksFromDB(ctx, userId)
+
+}
+
+func (s *Service) DeleteWebhook(ctx context.Context, userId, id string) error {
+	err := s.Db.DeleteWebhookFromDB(ctx, userId, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrWebhookNotFound
+	}
+	return err
+}
+
+// WebhookDeliveries returns the latest deliveries of one of the user's webhooks, newest first.
+func (s *Service) WebhookDeliveries(ctx context.Context, userId, id string, limit int) ([]postgres_db.WebhookDelivery, error) {
+	if limit <= 0 {
+		limit = defaultWebhookDeliveries
+	}
+	limit = min(limit, maxWebhookDeliveries)
+
+	deliveries, err := s.Db.SelectWebhookDeliveriesFromDB(ctx, userId, id, limit)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrWebhookNotFound
+	}
+	return deliveries, err
+}
@@ -0,0 +1,200 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	postgres_db "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/netguard"
+	"gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/outbox"
+)
+
+// Store is the part of the database the dispatcher needs.
+type Store interface {
+	ClaimWebhookDeliveriesFromDB(ctx context.Context, limit int, lease time.Duration) ([]postgres_db.DueDelivery, error)
+	MarkWebhookDeliveredFromDB(ctx context.Context, id string, responseCode int) error
+	MarkWebhookDeliveryFailedFromDB(ctx context.Context, id string, responseCode int, lastErr string,
+		retryAt time.Time, final bool, disableAfter int) (bool, error)
+}
+
+type Config struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// Concurrency limits the number of requests in flight.
+	Concurrency int
+	Timeout     time.Duration
+	// MaxAttempts of a single delivery before it is given up.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled after every
+	// failed attempt up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// DisableAfter consecutive failed attempts the webhook is switched off.
+	DisableAfter int
+	Lease        time.Duration
+	// AllowPrivate lets webhooks reach loopback and private addresses, for
+	// receivers on the same host in tests and local setups.
+	AllowPrivate bool
+}
+
+// Dispatcher sends the queued deliveries to the user webhooks.
+type Dispatcher struct {
+	store  Store
+	client *http.Client
+	cfg    Config
+}
+
+func NewDispatcher(store Store, cfg Config) *Dispatcher {
+	if cfg.BatchSize < 1 {
+		cfg.BatchSize = 100
+	}
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.DisableAfter < 1 {
+		cfg.DisableAfter = 1
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if !cfg.AllowPrivate {
+		dialer.Control = netguard.Control
+	}
+
+	return &Dispatcher{
+		store: store,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+				TLSHandshakeTimeout: 10 * time.Second,
+			},
+		},
+		cfg: cfg,
+	}
+}
+
+// Run dispatches deliveries until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		if d.RunOnce(ctx) == d.cfg.BatchSize {
+			continue
+		}
+
+		timer := time.NewTimer(d.cfg.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// RunOnce sends one batch of due deliveries and returns its size.
+func (d *Dispatcher) RunOnce(ctx context.Context) int {
+	if ctx.Err() != nil {
+		return 0
+	}
+
+	deliveries, err := d.store.ClaimWebhookDeliveriesFromDB(ctx, d.cfg.BatchSize, d.cfg.Lease)
+	if err != nil {
+		log.Printf("webhooks: cannot claim deliveries err: %v", err)
+		return 0
+	}
+
+	sem := make(chan struct{}, d.cfg.Concurrency)
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			d.dispatch(ctx, delivery)
+		}()
+	}
+	wg.Wait()
+
+	return len(deliveries)
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, delivery postgres_db.DueDelivery) {
+	code, err := d.send(ctx, delivery)
+	if err == nil {
+		if err := d.store.MarkWebhookDeliveredFromDB(ctx, delivery.Id, code); err != nil {
+			log.Printf("webhooks: cannot mark %s delivered err: %v", delivery.Id, err)
+		}
+		return
+	}
+
+	attempts := delivery.Attempts + 1
+	final := attempts >= d.cfg.MaxAttempts
+	disabled, markErr := d.store.MarkWebhookDeliveryFailedFromDB(ctx, delivery.Id, code, err.Error(),
+		time.Now().Add(d.backoff(attempts)), final, d.cfg.DisableAfter)
+	if markErr != nil {
+		log.Printf("webhooks: cannot record failure of %s err: %v", delivery.Id, markErr)
+	}
+	if disabled {
+		log.Printf("webhooks: disabled webhook %s after %d failures in a row", delivery.WebhookId, d.cfg.DisableAfter)
+	}
+}
+
+// send posts the event and returns the response status code, 0 if there was no response.
+func (d *Dispatcher) send(ctx context.Context, delivery postgres_db.DueDelivery) (int, error) {
+	body, err := json.Marshal(outbox.Envelope{
+		Id:        delivery.Event.Id,
+		Topic:     delivery.Event.Topic,
+		CreatedAt: delivery.Event.CreatedAt,
+		Data:      delivery.Event.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.Event.Topic)
+	req.Header.Set(HeaderEventId, delivery.Event.Id)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, now, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.Backoff
+	for i := 1; i < attempts && delay < d.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.cfg.MaxBackoff)
+}
@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderSignature = "X-Tracker-Signature"
+	HeaderTimestamp = "X-Tracker-Timestamp"
+	HeaderEvent     = "X-Tracker-Event"
+	HeaderEventId   = "X-Tracker-Event-Id"
+)
+
+// Sign returns the signature header value of body sent at ts: the hex encoded
+// HMAC-SHA256 of "<unix seconds>.<body>" keyed with the webhook secret.
+// Receivers recompute it and reject stale timestamps to prevent replays.
+func Sign(secret string, ts time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(ts.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature produced by Sign.
+func Verify(secret string, ts time.Time, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, ts, body)), []byte(signature))
+}
+
+// NewSecret generates the signing secret of a new webhook.
+func NewSecret() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	postgres_db "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
+)
+
+// Queue is the part of the database the sink needs.
+type Queue interface {
+	SelectSubscribersFromDB(ctx context.Context, link string) ([]string, error)
+	EnqueueWebhookDeliveriesFromDB(ctx context.Context, eventId string, userIds []string) error
+}
+
+// Sink is an outbox sink that schedules every event for the webhooks of the
+// users it concerns: the owner of the rule for alerts, every subscriber of the
+// link for price changes. The Dispatcher sends them.
+type Sink struct {
+	queue Queue
+}
+
+func NewSink(queue Queue) *Sink {
+	return &Sink{
+		queue: queue,
+	}
+}
+
+func (s *Sink) Name() string {
+	return "user webhooks"
+}
+
+func (s *Sink) Publish(ctx context.Context, event postgres_db.OutboxEvent) error {
+	var users []string
+
+	switch event.Topic {
+	case postgres_db.TopicAlert:
+		var alert postgres_db.AlertEvent
+		if err := json.Unmarshal(event.Payload, &alert); err != nil {
+			return fmt.Errorf("invalid alert payload: %w", err)
+		}
+		users = []string{alert.UserId}
+	case postgres_db.TopicPriceChanged:
+		var change postgres_db.PriceChange
+		if err := json.Unmarshal(event.Payload, &change); err != nil {
+			return fmt.Errorf("invalid price change payload: %w", err)
+		}
+
+		var err error
+		if users, err = s.queue.SelectSubscribersFromDB(ctx, change.Link); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	if len(users) == 0 {
+		return nil
+	}
+	return s.queue.EnqueueWebhookDeliveriesFromDB(ctx, event.Id, users)
+}
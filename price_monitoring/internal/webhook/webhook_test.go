@@ -0,0 +1,218 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	postgres_db "gitlab.crja72.ru/golang/2025/spring/course/projects/go2/price-tracker/price_monitoring/internal/db"
+)
+
+func TestSign(t *testing.T) {
+	ts := time.Unix(1714564800, 0)
+	body := []byte(`{"id":"1"}`)
+
+	signature := Sign("secret", ts, body)
+	assert.Equal(t, "sha256=", signature[:7])
+	assert.Len(t, signature, 7+64)
+
+	assert.True(t, Verify("secret", ts, body, signature))
+	assert.False(t, Verify("other", ts, body, signature))
+	assert.False(t, Verify("secret", ts.Add(time.Second), body, signature))
+	assert.False(t, Verify("secret", ts, []byte(`{"id":"2"}`), signature))
+}
+
+type failure struct {
+	code     int
+	retryAt  time.Time
+	final    bool
+	disabled bool
+}
+
+type fakeStore struct {
+	mu         sync.Mutex
+	deliveries []postgres_db.DueDelivery
+	delivered  map[string]int
+	failed     map[string]failure
+	failures   map[string]int
+}
+
+func (f *fakeStore) ClaimWebhookDeliveriesFromDB(ctx context.Context, limit int, lease time.Duration) ([]postgres_db.DueDelivery, error) {
+	deliveries := f.deliveries[:min(limit, len(f.deliveries))]
+	f.deliveries = f.deliveries[len(deliveries):]
+	return deliveries, nil
+}
+
+func (f *fakeStore) MarkWebhookDeliveredFromDB(ctx context.Context, id string, responseCode int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.delivered[id] = responseCode
+	return nil
+}
+
+func (f *fakeStore) MarkWebhookDeliveryFailedFromDB(ctx context.Context, id string, responseCode int, lastErr string,
+	retryAt time.Time, final bool, disableAfter int) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.failures[id]++
+	disabled := f.failures[id] == disableAfter
+	f.failed[id] = failure{code: responseCode, retryAt: retryAt, final: final, disabled: disabled}
+	return disabled, nil
+}
+
+func TestDispatcherRunOnce(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received = map[string]*http.Request{}
+		bodies   = map[string][]byte{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		received[r.Header.Get(HeaderEventId)] = r
+		bodies[r.Header.Get(HeaderEventId)] = body
+		mu.Unlock()
+
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	created := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	store := &fakeStore{
+		deliveries: []postgres_db.DueDelivery{
+			{Id: "ok", URL: server.URL + "/hook", Secret: "secret", Event: postgres_db.OutboxEvent{
+				Id: "event-ok", Topic: postgres_db.TopicAlert, Payload: []byte(`{"price":{"amount":9900,"currency":"RUB"}}`), CreatedAt: created,
+			}},
+			{Id: "retry", Attempts: 1, URL: server.URL + "/broken", Secret: "secret", Event: postgres_db.OutboxEvent{
+				Id: "event-retry", Topic: postgres_db.TopicPriceChanged, Payload: []byte(`{}`),
+			}},
+			{Id: "final", Attempts: 4, URL: server.URL + "/broken", Secret: "secret", Event: postgres_db.OutboxEvent{
+				Id: "event-final", Topic: postgres_db.TopicPriceChanged, Payload: []byte(`{}`),
+			}},
+		},
+		delivered: map[string]int{},
+		failed:    map[string]failure{},
+		failures:  map[string]int{},
+	}
+
+	dispatcher := NewDispatcher(store, Config{
+		BatchSize:    10,
+		Concurrency:  2,
+		Timeout:      time.Second,
+		MaxAttempts:  5,
+		Backoff:      time.Minute,
+		MaxBackoff:   time.Hour,
+		DisableAfter: 1,
+		AllowPrivate: true,
+	})
+
+	start := time.Now()
+	assert.Equal(t, 3, dispatcher.RunOnce(context.Background()))
+
+	assert.Equal(t, map[string]int{"ok": http.StatusNoContent}, store.delivered)
+
+	req := received["event-ok"]
+	assert.Equal(t, postgres_db.TopicAlert, req.Header.Get(HeaderEvent))
+	ts, err := strconv.ParseInt(req.Header.Get(HeaderTimestamp), 10, 64)
+	assert.NoError(t, err)
+	assert.True(t, Verify("secret", time.Unix(ts, 0), bodies["event-ok"], req.Header.Get(HeaderSignature)))
+
+	var envelope struct {
+		Id    string          `json:"id"`
+		Topic string          `json:"topic"`
+		Data  json.RawMessage `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(bodies["event-ok"], &envelope))
+	assert.Equal(t, "event-ok", envelope.Id)
+	assert.JSONEq(t, `{"price":{"amount":9900,"currency":"RUB"}}`, string(envelope.Data))
+
+	retry := store.failed["retry"]
+	assert.Equal(t, http.StatusInternalServerError, retry.code)
+	assert.False(t, retry.final)
+	assert.WithinDuration(t, start.Add(2*time.Minute), retry.retryAt, 5*time.Second)
+
+	assert.True(t, store.failed["final"].final)
+	assert.True(t, store.failed["final"].disabled)
+}
+
+func TestDispatcherRefusesPrivateAddresses(t *testing.T) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	store := &fakeStore{
+		deliveries: []postgres_db.DueDelivery{
+			{Id: "local", URL: server.URL, Secret: "secret", Event: postgres_db.OutboxEvent{
+				Id: "event-local", Topic: postgres_db.TopicAlert, Payload: []byte(`{}`),
+			}},
+		},
+		delivered: map[string]int{},
+		failed:    map[string]failure{},
+		failures:  map[string]int{},
+	}
+
+	dispatcher := NewDispatcher(store, Config{Timeout: time.Second, MaxAttempts: 5, Backoff: time.Minute, MaxBackoff: time.Hour})
+	assert.Equal(t, 1, dispatcher.RunOnce(context.Background()))
+
+	assert.False(t, called)
+	assert.Empty(t, store.delivered)
+	assert.Contains(t, store.failed, "local")
+}
+
+func TestBackoff(t *testing.T) {
+	dispatcher := NewDispatcher(nil, Config{Backoff: time.Second, MaxBackoff: 10 * time.Second})
+
+	assert.Equal(t, time.Second, dispatcher.backoff(1))
+	assert.Equal(t, 2*time.Second, dispatcher.backoff(2))
+	assert.Equal(t, 8*time.Second, dispatcher.backoff(4))
+	assert.Equal(t, 10*time.Second, dispatcher.backoff(10))
+}
+
+type fakeQueue struct {
+	subscribers map[string][]string
+	enqueued    map[string][]string
+}
+
+func (f *fakeQueue) SelectSubscribersFromDB(ctx context.Context, link string) ([]string, error) {
+	return f.subscribers[link], nil
+}
+
+func (f *fakeQueue) EnqueueWebhookDeliveriesFromDB(ctx context.Context, eventId string, userIds []string) error {
+	f.enqueued[eventId] = userIds
+	return nil
+}
+
+func TestSinkPublish(t *testing.T) {
+	queue := &fakeQueue{
+		subscribers: map[string][]string{"https://shop/item": {"u1", "u2"}},
+		enqueued:    map[string][]string{},
+	}
+	sink := NewSink(queue)
+
+	alert, _ := json.Marshal(postgres_db.AlertEvent{Id: "a", UserId: "u1"})
+	change, _ := json.Marshal(postgres_db.PriceChange{Link: "https://shop/item"})
+	unwatched, _ := json.Marshal(postgres_db.PriceChange{Link: "https://shop/other"})
+
+	assert.NoError(t, sink.Publish(context.Background(), postgres_db.OutboxEvent{Id: "e1", Topic: postgres_db.TopicAlert, Payload: alert}))
+	assert.NoError(t, sink.Publish(context.Background(), postgres_db.OutboxEvent{Id: "e2", Topic: postgres_db.TopicPriceChanged, Payload: change}))
+	assert.NoError(t, sink.Publish(context.Background(), postgres_db.OutboxEvent{Id: "e3", Topic: postgres_db.TopicPriceChanged, Payload: unwatched}))
+	assert.Error(t, sink.Publish(context.Background(), postgres_db.OutboxEvent{Id: "e4", Topic: postgres_db.TopicAlert, Payload: []byte("{")}))
+
+	assert.Equal(t, map[string][]string{"e1": {"u1"}, "e2": {"u1", "u2"}}, queue.enqueued)
+}
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS user_preferences;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
+DROP TABLE IF EXISTS outbox;
+DROP TABLE IF EXISTS alert_events;
+DROP TABLE IF EXISTS alert_rules;
+DROP TABLE IF EXISTS product_variants;
+DROP TABLE IF EXISTS price_history;
+DROP TABLE IF EXISTS subscriptions;
+DROP TABLE IF EXISTS products;
@@ -0,0 +1,145 @@
+-- Prices are integer amounts in minor units (kopecks) with the ISO 4217 code
+-- of their currency.
+CREATE TABLE IF NOT EXISTS products(
+    id UUID PRIMARY KEY,
+    link TEXT NOT NULL UNIQUE,
+    product_name TEXT NOT NULL,
+    current_price BIGINT,
+    last_checked_at TIMESTAMP,
+    creation_date TIMESTAMP NOT NULL,
+    stock_status SMALLINT NOT NULL DEFAULT 0,
+    currency TEXT NOT NULL DEFAULT 'RUB',
+    -- The price before the discount, zero if the shop shows none.
+    original_price BIGINT NOT NULL DEFAULT 0,
+    brand TEXT NOT NULL DEFAULT '',
+    seller TEXT NOT NULL DEFAULT '',
+    rating REAL NOT NULL DEFAULT 0,
+    review_count INTEGER NOT NULL DEFAULT 0,
+    quantity INTEGER NOT NULL DEFAULT 0,
+    image_url TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS subscriptions(
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(100) NOT NULL,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    start_price BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    custom_name TEXT,
+    paused BOOLEAN NOT NULL DEFAULT FALSE,
+    currency TEXT NOT NULL DEFAULT 'RUB',
+    -- The variant the subscription is pinned to, empty for the whole product.
+    variant_id TEXT NOT NULL DEFAULT '',
+    UNIQUE (user_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS subscriptions_product_id_idx ON subscriptions (product_id);
+
+CREATE TABLE IF NOT EXISTS price_history(
+    id BIGSERIAL PRIMARY KEY,
+    price BIGINT NOT NULL,
+    stock_status SMALLINT NOT NULL,
+    observed_at TIMESTAMPTZ NOT NULL,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    currency TEXT NOT NULL DEFAULT 'RUB'
+);
+
+CREATE INDEX IF NOT EXISTS price_history_product_id_observed_at_idx ON price_history (product_id, observed_at);
+
+-- Latest state of every variant (size, color) of a product as the shop
+-- lists it. Variants the shop stops listing are removed.
+CREATE TABLE IF NOT EXISTS product_variants(
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    price BIGINT NOT NULL,
+    currency TEXT NOT NULL,
+    original_price BIGINT NOT NULL DEFAULT 0,
+    stock_status SMALLINT NOT NULL,
+    quantity INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (product_id, variant_id)
+);
+
+CREATE TABLE IF NOT EXISTS alert_rules(
+    id UUID PRIMARY KEY,
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    kind SMALLINT NOT NULL,
+    target_price BIGINT NOT NULL DEFAULT 0,
+    drop_percent NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL,
+    target_currency TEXT NOT NULL DEFAULT 'RUB'
+);
+
+CREATE INDEX IF NOT EXISTS alert_rules_subscription_id_idx ON alert_rules (subscription_id);
+
+CREATE TABLE IF NOT EXISTS alert_events(
+    id UUID PRIMARY KEY,
+    rule_id UUID NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+    user_id VARCHAR(100) NOT NULL,
+    link TEXT NOT NULL,
+    product_name TEXT NOT NULL,
+    kind SMALLINT NOT NULL,
+    price BIGINT NOT NULL,
+    previous_price BIGINT,
+    created_at TIMESTAMPTZ NOT NULL,
+    delivered_at TIMESTAMPTZ,
+    currency TEXT NOT NULL DEFAULT 'RUB'
+);
+
+CREATE INDEX IF NOT EXISTS alert_events_user_id_created_at_idx ON alert_events (user_id, created_at);
+CREATE INDEX IF NOT EXISTS alert_events_pending_idx ON alert_events (created_at) WHERE delivered_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS outbox(
+    id UUID PRIMARY KEY,
+    topic TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    -- 0 pending, 1 sent, 2 dead
+    status SMALLINT NOT NULL DEFAULT 0,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    sent_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS outbox_pending_idx ON outbox (next_attempt_at) WHERE status = 0;
+CREATE INDEX IF NOT EXISTS outbox_dead_idx ON outbox (created_at) WHERE status = 2;
+
+CREATE TABLE IF NOT EXISTS webhooks(
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(100) NOT NULL,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    consecutive_failures INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL,
+    disabled_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS webhooks_user_id_idx ON webhooks (user_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries(
+    id UUID PRIMARY KEY,
+    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES outbox(id) ON DELETE CASCADE,
+    -- 0 pending, 1 delivered, 2 failed
+    status SMALLINT NOT NULL DEFAULT 0,
+    attempts INT NOT NULL DEFAULT 0,
+    response_code INT,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    delivered_at TIMESTAMPTZ,
+    UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at) WHERE status = 0;
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_id_created_at_idx ON webhook_deliveries (webhook_id, created_at);
+
+-- Display currency of each user. Users without a row see prices in RUB.
+CREATE TABLE IF NOT EXISTS user_preferences(
+    user_id VARCHAR(100) PRIMARY KEY,
+    display_currency TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,2 @@
+-- Serves the pages of the subscriptions of a user, ordered by creation.
+CREATE INDEX IF NOT EXISTS subscriptions_user_id_created_at_idx ON subscriptions (user_id, created_at, id);
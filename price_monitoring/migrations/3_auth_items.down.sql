@@ -0,0 +1,20 @@
+-- The subscriptions go back to auth.items, prices in rubles. The links are
+-- TEXT, the tracker accepts links longer than the VARCHAR(100) of the auth
+-- service.
+CREATE SCHEMA IF NOT EXISTS auth;
+
+CREATE TABLE IF NOT EXISTS auth.items(
+    Id UUID PRIMARY KEY,
+    user_id VARCHAR(100),
+    link TEXT,
+    product_name TEXT,
+    start_price REAL,
+    current_price REAL,
+    creation_date TIMESTAMP
+);
+
+INSERT INTO auth.items (Id, user_id, link, product_name, start_price, current_price, creation_date)
+SELECT s.id, s.user_id, p.link, p.product_name, s.start_price / 100.0, p.current_price / 100.0, s.created_at
+FROM tracker.subscriptions s
+JOIN tracker.products p ON p.id = s.product_id
+ON CONFLICT (Id) DO NOTHING;
@@ -0,0 +1,81 @@
+-- The auth service used to keep every tracked item in auth.items, one row per
+-- user and link with prices in rubles. They become products, one per
+-- canonical link, and subscriptions with prices in kopecks, then the table is
+-- dropped.
+
+-- Mirrors parser.Links for the links already stored. Short links cannot be
+-- resolved here, they are resolved when their users request them again.
+CREATE FUNCTION pg_temp.canonical_link(link TEXT) RETURNS TEXT LANGUAGE plpgsql IMMUTABLE AS $$
+DECLARE
+    m TEXT[];
+    query TEXT;
+BEGIN
+    link := btrim(split_part(link, '#', 1));
+
+    m := regexp_match(link, '^https?://(?:[^/?]*\.)?wildberries\.ru(?::\d+)?/catalog/(\d+)/detail', 'i');
+    IF m IS NOT NULL THEN
+        RETURN 'https://www.wildberries.ru/catalog/' || m[1] || '/detail.aspx';
+    END IF;
+
+    m := regexp_match(link, '^([a-z]+://[^/?]+)([^?]*)\??(.*)$', 'i');
+    IF m IS NULL THEN
+        RETURN link;
+    END IF;
+
+    SELECT string_agg(p, '&' ORDER BY p) INTO query
+    FROM regexp_split_to_table(m[3], '&') AS p
+    WHERE p <> ''
+        AND lower(split_part(p, '=', 1)) !~ '^(utm_.*|gclid|gbraid|wbraid|yclid|ysclid|fbclid|msclkid|_openstat|erid|mc_cid|mc_eid)$';
+
+    RETURN regexp_replace(lower(m[1]), '^(http://.+):80$|^(https://.+):443$', '\1\2')
+        || COALESCE(NULLIF(m[2], ''), '/')
+        || COALESCE('?' || query, '');
+END;
+$$;
+
+DO $$
+BEGIN
+    IF to_regclass('auth.items') IS NULL THEN
+        RETURN;
+    END IF;
+
+    CREATE TEMP TABLE canonical_items AS
+    SELECT i.id, i.user_id, pg_temp.canonical_link(i.link) AS link, i.product_name, i.start_price,
+        i.current_price, i.creation_date
+    FROM auth.items i
+    WHERE btrim(i.link) <> '';
+
+    -- One product per canonical link, keeping the oldest item as its origin
+    -- and the price of the newest one. None has been checked by the
+    -- scheduler yet.
+    INSERT INTO tracker.products (id, link, product_name, current_price, creation_date)
+    SELECT DISTINCT ON (o.link) o.id, o.link, COALESCE(o.product_name, ''),
+        round(l.current_price::numeric * 100)::bigint, COALESCE(o.creation_date, now())
+    FROM canonical_items o
+    CROSS JOIN LATERAL (
+        SELECT current_price
+        FROM canonical_items l
+        WHERE l.link = o.link
+        ORDER BY l.creation_date DESC NULLS LAST
+        LIMIT 1
+    ) l
+    ORDER BY o.link, o.creation_date, o.id
+    ON CONFLICT (link) DO NOTHING;
+
+    -- One subscription per user and product. A user who added several
+    -- spellings of a link keeps the baseline of the first one.
+    INSERT INTO tracker.subscriptions (id, user_id, product_id, start_price, created_at)
+    SELECT DISTINCT ON (i.user_id, p.id) i.id, i.user_id, p.id,
+        round(COALESCE(i.start_price, i.current_price, 0)::numeric * 100)::bigint, COALESCE(i.creation_date, now())
+    FROM canonical_items i
+    JOIN tracker.products p ON p.link = i.link
+    WHERE i.user_id IS NOT NULL
+    ORDER BY i.user_id, p.id, i.creation_date, i.id
+    ON CONFLICT DO NOTHING;
+
+    DROP TABLE canonical_items;
+    DROP TABLE auth.items;
+END;
+$$;
+
+DROP FUNCTION pg_temp.canonical_link(TEXT);
@@ -0,0 +1,3 @@
+DELETE FROM alert_events e WHERE NOT EXISTS (SELECT 1 FROM alert_rules r WHERE r.id = e.rule_id);
+ALTER TABLE alert_events ADD CONSTRAINT alert_events_rule_id_fkey
+    FOREIGN KEY (rule_id) REFERENCES alert_rules(id) ON DELETE CASCADE;
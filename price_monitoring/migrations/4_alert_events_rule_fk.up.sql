@@ -0,0 +1,3 @@
+-- Alert events outlive their rules: a rule deleted while its event waits in
+-- the outbox must not make the delivery fail. rule_id is kept as plain data.
+ALTER TABLE alert_events DROP CONSTRAINT IF EXISTS alert_events_rule_id_fkey;
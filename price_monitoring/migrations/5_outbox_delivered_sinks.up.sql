@@ -0,0 +1,3 @@
+-- The sinks that accepted an event, a failed event is published again only
+-- to the others.
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS delivered_sinks TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,8 @@
+// Package migrations embeds the versioned SQL migrations of the tracker
+// schema. They run with the search path set to the schema.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"io/fs"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var migrationRe = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+func TestMigrationsArePaired(t *testing.T) {
+	names, err := fs.Glob(FS, "*.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, names)
+
+	directions := make(map[int]map[string]bool)
+	for _, name := range names {
+		match := migrationRe.FindStringSubmatch(name)
+		require.NotNil(t, match, "%s is not named <version>_<name>.<up|down>.sql", name)
+
+		version, _ := strconv.Atoi(match[1])
+		if directions[version] == nil {
+			directions[version] = make(map[string]bool)
+		}
+		directions[version][match[3]] = true
+	}
+
+	for version := 1; version <= len(directions); version++ {
+		assert.True(t, directions[version]["up"], "version %d has no up migration", version)
+		assert.True(t, directions[version]["down"], "version %d has no down migration", version)
+	}
+}